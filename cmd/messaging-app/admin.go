@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"messaging-app/internal/adapters/postgres"
+)
+
+const adminUsage = `Usage: messaging-app admin <operation> [arguments]
+
+Operations:
+  purge-user -user ID [-yes]  delete everything stored about a user
+
+`
+
+const purgeUserUsage = `Usage: messaging-app admin purge-user -user ID [-yes]
+
+Deletes the messages the user sent, their reactions, memberships, mutes,
+presence, pending events, uploaded attachments and blocks in either direction.
+Direct messages other users sent to the user are kept. Without -yes it only
+reports what would be deleted.
+
+`
+
+func (c *cli) admin(args []string) error {
+	flags := c.flags("admin", adminUsage)
+	flags.Parse(args)
+	args = flags.Args()
+
+	if len(args) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "purge-user":
+		return c.purgeUser(args[1:])
+	default:
+		return fmt.Errorf("unknown admin operation %q", args[0])
+	}
+}
+
+func (c *cli) purgeUser(args []string) error {
+	flags := c.flags("purge-user", purgeUserUsage)
+	userID := flags.String("user", "", "ID of the user whose data is purged")
+	confirmed := flags.Bool("yes", false, "delete the data rather than report what would be deleted")
+	flags.Parse(args)
+
+	if *userID == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	config, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+	logger := newLogger(config, os.Stderr, false)
+
+	db, err := openDatabase(config, logger)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := postgres.NewPostgreSQLUserDataRepository(db, logger)
+	ctx := context.Background()
+
+	if !*confirmed {
+		export, err := repo.ExportUserData(ctx, *userID)
+		if err != nil {
+			return err
+		}
+
+		sent := 0
+		for _, msg := range export.Messages {
+			if msg.SenderID == *userID {
+				sent++
+			}
+		}
+		fmt.Printf("Would purge %s: %d messages, %d reactions, %d memberships, %d blocks made, %d mutes, %d attachments\n",
+			*userID, sent, len(export.Reactions), len(export.Memberships), len(export.Blocks), len(export.Mutes), len(export.Attachments))
+		fmt.Println("Run again with -yes to purge")
+		return nil
+	}
+
+	// The blob store is opened first so a misconfigured store cannot leave orphaned files behind
+	blobStore, err := initializeBlobStore(config, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize attachment storage: %w", err)
+	}
+
+	purge, err := repo.PurgeUserData(ctx, *userID)
+	if err != nil {
+		return err
+	}
+
+	// The rows are gone already, so a file that cannot be deleted is reported rather than undone
+	failed := 0
+	for _, id := range purge.AttachmentIDs {
+		if err := blobStore.Delete(ctx, id); err != nil {
+			logger.Error("Failed to delete attachment content", "attachment_id", id, "error", err)
+			failed++
+		}
+	}
+
+	fmt.Printf("Purged %s: %d messages, %d reactions, %d memberships, %d blocks, %d mutes, %d attachments\n",
+		*userID, purge.Messages, purge.Reactions, purge.Memberships, purge.Blocks, purge.Mutes, len(purge.AttachmentIDs))
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete the content of %d attachments", failed)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"messaging-app/internal/adapters/postgres"
+)
+
+const exportUsage = `Usage: messaging-app export -user ID [-output FILE]
+
+Writes the user's messages, memberships, reactions, blocks, mutes and
+attachment metadata as JSON, to standard output unless -output is given.
+
+`
+
+func (c *cli) export(args []string) error {
+	flags := c.flags("export", exportUsage)
+	userID := flags.String("user", "", "ID of the user whose data is exported")
+	output := flags.String("output", "", "file to write the export to")
+	flags.Parse(args)
+
+	if *userID == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	config, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+	logger := newLogger(config, os.Stderr, false)
+
+	db, err := openDatabase(config, logger)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	export, err := postgres.NewPostgreSQLUserDataRepository(db, logger).ExportUserData(context.Background(), *userID)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	logger.Info("User data exported", "user_id", *userID, "messages", len(export.Messages))
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+
+	"messaging-app/internal/adapters/postgres"
+	"messaging-app/internal/adapters/storage"
+	"messaging-app/internal/application"
+	"messaging-app/internal/ports"
+)
+
+const usage = `Usage: messaging-app [-config FILE] <command> [arguments]
+
+Commands:
+`
+
+const configUsage = "configuration file; defaults to config.yaml in the working directory or ./config"
+
+// command is a subcommand of the binary; run receives the arguments after its name
+type command struct {
+	name    string
+	summary string
+	run     func(c *cli, args []string) error
+}
+
+var commands = []command{
+	{"serve", "run the HTTP and WebSocket server", (*cli).serve},
+	{"migrate", "apply or roll back database migrations", (*cli).migrate},
+	{"seed", "load a demo or test dataset into the database", (*cli).seed},
+	{"export", "write everything stored about a user as JSON", (*cli).export},
+	{"admin", "administrative operations such as purging a user's data", (*cli).admin},
+}
+
+// cli holds the options shared by every command
+type cli struct {
+	configPath string
+}
+
+func main() {
+	c := &cli{}
+
+	flags := flag.NewFlagSet("messaging-app", flag.ExitOnError)
+	flags.StringVar(&c.configPath, "config", "", configUsage)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		for _, cmd := range commands {
+			fmt.Fprintf(flags.Output(), "  %-9s %s\n", cmd.name, cmd.summary)
+		}
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == flags.Arg(0) {
+			if err := cmd.run(c, flags.Args()[1:]); err != nil {
+				log.Fatalf("%s failed: %v", cmd.name, err)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(flags.Output(), "unknown command %q\n\n", flags.Arg(0))
+	flags.Usage()
+	os.Exit(2)
+}
+
+// flags creates the flag set of a command; -config is accepted after the command name too
+func (c *cli) flags(name, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.StringVar(&c.configPath, "config", c.configPath, configUsage)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+func (c *cli) loadConfig() (application.FullConfig, error) {
+	config, err := application.LoadConfig(c.configPath)
+	if err != nil {
+		return config, fmt.Errorf("failed to load config: %w", err)
+	}
+	return config, nil
+}
+
+// newLogger logs JSON for the server and text for the other commands, which are run by hand
+func newLogger(config application.FullConfig, output io.Writer, json bool) ports.Logger {
+	logLevel := slog.LevelInfo
+	switch config.Logging.Level {
+	case "debug":
+		logLevel = slog.LevelDebug
+	case "warn":
+		logLevel = slog.LevelWarn
+	case "error":
+		logLevel = slog.LevelError
+	}
+
+	opts := &slog.HandlerOptions{Level: logLevel}
+	var handler slog.Handler = slog.NewTextHandler(output, opts)
+	if json {
+		handler = slog.NewJSONHandler(output, opts)
+	}
+	return ports.NewSlogAdapter(slog.New(handler))
+}
+
+func openDatabase(config application.FullConfig, logger ports.Logger) (*sql.DB, error) {
+	return postgres.NewConnection(postgres.Config{
+		Host:            config.Database.Host,
+		Port:            config.Database.Port,
+		User:            config.Database.User,
+		Password:        config.Database.Password,
+		Database:        config.Database.Database,
+		SSLMode:         config.Database.SSLMode,
+		MaxConnections:  config.Database.MaxConnections,
+		MaxIdleTime:     config.Database.MaxIdleTime,
+		ConnMaxLifetime: config.Database.ConnMaxLifetime,
+	}, logger)
+}
+
+// initializeBlobStore creates the store holding attachment content
+func initializeBlobStore(config application.FullConfig, logger ports.Logger) (ports.BlobStore, error) {
+	switch config.Attachments.Storage.Type {
+	case "local":
+		return storage.NewLocalBlobStore(config.Attachments.Storage.Path, logger)
+	default:
+		return nil, fmt.Errorf("unsupported attachment storage type %q", config.Attachments.Storage.Type)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"messaging-app/internal/adapters/postgres"
+	"messaging-app/migrations"
+)
+
+const migrateUsage = `Usage: messaging-app migrate [-dry-run] <command>
+
+Commands:
+  up          apply every pending migration
+  down N      roll back the latest N migrations
+  status      list migrations and when they were applied
+  baseline V  record migrations up to version V as applied without running them
+
+`
+
+func (c *cli) migrate(args []string) error {
+	flags := c.flags("migrate", migrateUsage)
+	dryRun := flags.Bool("dry-run", false, "run the migrations in a transaction that is rolled back")
+	flags.Parse(args)
+	args = flags.Args()
+
+	if len(args) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	config, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+	logger := newLogger(config, os.Stderr, false)
+
+	db, err := openDatabase(config, logger)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := postgres.NewMigrator(db, migrations.FS, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx, *dryRun)
+		if err != nil {
+			return err
+		}
+		printMigrations(applied, *dryRun, "apply", "Applied")
+
+	case "down":
+		if len(args) != 2 {
+			return fmt.Errorf("down needs the number of migrations to roll back")
+		}
+		steps, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid number of migrations %q", args[1])
+		}
+		rolledBack, err := migrator.Down(ctx, steps, *dryRun)
+		if err != nil {
+			return err
+		}
+		printMigrations(rolledBack, *dryRun, "roll back", "Rolled back")
+
+	case "baseline":
+		if len(args) != 2 {
+			return fmt.Errorf("baseline needs the version the schema is at")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid migration version %q", args[1])
+		}
+		recorded, err := migrator.Baseline(ctx, version)
+		if err != nil {
+			return err
+		}
+		printMigrations(recorded, false, "record", "Recorded")
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Up == "" {
+				state += " (unknown to this binary)"
+			}
+			fmt.Printf("%03d_%s\t%s\n", status.Version, status.Name, state)
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+
+	return nil
+}
+
+func printMigrations(migrations []postgres.Migration, dryRun bool, verb, done string) {
+	if len(migrations) == 0 {
+		fmt.Printf("Nothing to %s\n", verb)
+		return
+	}
+
+	for _, migration := range migrations {
+		if dryRun {
+			fmt.Printf("Would %s %03d_%s\n", verb, migration.Version, migration.Name)
+		} else {
+			fmt.Printf("%s %03d_%s\n", done, migration.Version, migration.Name)
+		}
+	}
+}
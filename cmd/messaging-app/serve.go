@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"messaging-app/internal/adapters/auth"
+	"messaging-app/internal/adapters/metrics"
+	natsAdapter "messaging-app/internal/adapters/nats"
+	"messaging-app/internal/adapters/postgres"
+	"messaging-app/internal/adapters/ratelimit"
+	"messaging-app/internal/adapters/tracing"
+	"messaging-app/internal/application"
+	"messaging-app/internal/ports"
+	"messaging-app/migrations"
+)
+
+const serveUsage = `Usage: messaging-app serve
+
+Runs the HTTP and WebSocket server, connected to Postgres and NATS.
+`
+
+func (c *cli) serve(args []string) error {
+	c.flags("serve", serveUsage).Parse(args)
+
+	fullConfig, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+	appLogger := newLogger(fullConfig, os.Stdout, true)
+
+	// Tracing is set up first so every adapter records its spans
+	shutdownTracing, err := tracing.Init(context.Background(), fullConfig.GetTracingConfig())
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			appLogger.Error("Failed to flush traces", "error", err)
+		}
+	}()
+
+	// Initialize database
+	db, err := initializeDatabase(fullConfig, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	// Initialize NATS
+	natsConn, err := initializeNATS(fullConfig, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize NATS: %w", err)
+	}
+	defer natsConn.Close()
+
+	appMetrics := initializeMetrics(fullConfig, db, natsConn)
+
+	// Initialize adapters
+	messageRepo := postgres.NewPostgreSQLMessageRepository(db, appMetrics, appLogger)
+	chatRepo := postgres.NewPostgreSQLChatRepository(db, appLogger)
+	outboxRepo := postgres.NewPostgreSQLOutboxRepository(db, appLogger)
+	presenceRepo := postgres.NewPostgreSQLPresenceRepository(db, appLogger)
+	blockRepo := postgres.NewPostgreSQLBlockRepository(db, appLogger)
+	blobStore, err := initializeBlobStore(fullConfig, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize attachment storage: %w", err)
+	}
+	publisher, subscriber, err := initializeRealtime(fullConfig, natsConn, appMetrics, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize real-time delivery: %w", err)
+	}
+	authenticator, err := auth.NewAuthenticator(fullConfig.GetAuthConfig())
+	if err != nil {
+		return fmt.Errorf("failed to initialize authentication: %w", err)
+	}
+	rateLimiter, err := initializeRateLimiter(fullConfig, db, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize rate limiting: %w", err)
+	}
+
+	// Create application with interfaces and HTTP configuration
+	app := application.NewApplication(
+		fullConfig.GetApplicationConfig(),
+		appLogger,
+		messageRepo,
+		chatRepo,
+		outboxRepo,
+		presenceRepo,
+		blockRepo,
+		blobStore,
+		publisher,
+		subscriber,
+		authenticator,
+		rateLimiter,
+		[]ports.HealthChecker{
+			postgres.NewDatabaseHealthChecker(db),
+			natsAdapter.NewConnectionHealthChecker(natsConn),
+		},
+		appMetrics,
+		fullConfig.GetHTTPConfig(),
+	)
+
+	// Initialize and start application
+	if err := app.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+
+	if err := app.Start(); err != nil {
+		return fmt.Errorf("failed to start application: %w", err)
+	}
+
+	return nil
+}
+
+func initializeDatabase(config application.FullConfig, logger ports.Logger) (*sql.DB, error) {
+	db, err := openDatabase(config, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Instances starting together wait on the migration lock, so each migration is applied once
+	if config.Database.MigrateOnStart {
+		migrator, err := postgres.NewMigrator(db, migrations.FS, logger)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		if _, err := migrator.Up(context.Background(), false); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	return db, nil
+}
+
+// initializeMetrics collects metrics for the scrape endpoint, or discards them when it is disabled
+func initializeMetrics(config application.FullConfig, db *sql.DB, conn *nats.Conn) ports.Metrics {
+	if !config.Metrics.Enabled {
+		return metrics.NewNopMetrics()
+	}
+
+	prometheusMetrics := metrics.NewPrometheusMetrics()
+	prometheusMetrics.RegisterDatabase(db)
+	prometheusMetrics.RegisterNATSConnection(conn)
+	return prometheusMetrics
+}
+
+func initializeNATS(config application.FullConfig, logger ports.Logger) (*nats.Conn, error) {
+	return natsAdapter.NewConnection(natsConfig(config), logger)
+}
+
+// initializeRealtime picks durable JetStream delivery when enabled, plain core NATS otherwise
+func initializeRealtime(config application.FullConfig, conn *nats.Conn, metrics ports.Metrics, logger ports.Logger) (ports.MessagePublisher, ports.MessageSubscriber, error) {
+	if !config.NATS.EnableJetStream {
+		return natsAdapter.NewNATSMessagePublisher(conn, metrics, logger), natsAdapter.NewNATSMessageSubscriber(conn, logger), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.NATS.RequestTimeout)
+	defer cancel()
+
+	publisher, err := natsAdapter.NewNATSJetStreamPublisher(ctx, conn, natsConfig(config), metrics, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subscriber, err := natsAdapter.NewNATSJetStreamSubscriber(conn, natsConfig(config), logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return publisher, subscriber, nil
+}
+
+// initializeRateLimiter picks where token buckets live; the postgres store shares limits across instances
+func initializeRateLimiter(config application.FullConfig, db *sql.DB, logger ports.Logger) (ports.RateLimiter, error) {
+	switch config.RateLimit.Store {
+	case "memory":
+		return ratelimit.NewMemoryRateLimiter(), nil
+	case "postgres":
+		return postgres.NewPostgreSQLRateLimiter(db, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store %q", config.RateLimit.Store)
+	}
+}
+
+func natsConfig(config application.FullConfig) natsAdapter.Config {
+	return natsAdapter.Config{
+		URL:             config.NATS.URL,
+		MaxReconnects:   config.NATS.MaxReconnects,
+		ReconnectWait:   config.NATS.ReconnectWait,
+		ConnectTimeout:  config.NATS.ConnectTimeout,
+		RequestTimeout:  config.NATS.RequestTimeout,
+		EnableJetStream: config.NATS.EnableJetStream,
+		ClusterName:     config.NATS.ClusterName,
+		StreamName:      config.NATS.StreamName,
+		StreamMaxAge:    config.NATS.StreamMaxAge,
+		DuplicateWindow: config.NATS.DuplicateWindow,
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"time"
 
 	"messaging-app/e2e/testclient"
+	"messaging-app/internal/adapters/auth"
+	"messaging-app/internal/adapters/metrics"
 	natsAdapter "messaging-app/internal/adapters/nats"
 	"messaging-app/internal/adapters/postgres"
+	"messaging-app/internal/adapters/storage"
 	"messaging-app/internal/application"
 	"messaging-app/internal/domain"
 	"messaging-app/internal/ports"
@@ -105,22 +108,22 @@ func (s *E2ETestSuite) TearDownSuite() {
 func (s *E2ETestSuite) TearDownTest() {
 	s.T().Log("Cleaning up database after test...")
 
-	// Clean up messages table for test isolation
-	_, err := s.db.Exec("TRUNCATE messages")
-	s.Require().NoError(err, "Failed to truncate messages table")
+	// Clean up messages and group tables for test isolation
+	_, err := s.db.Exec("TRUNCATE messages, chats, chat_members, outbox, hidden_messages, message_reactions, user_presence, attachments, user_blocks, chat_mutes, rate_limit_buckets")
+	s.Require().NoError(err, "Failed to truncate messages tables")
 
 	s.T().Log("Database cleanup completed")
 }
 
 func (s *E2ETestSuite) loadTestConfiguration() (application.FullConfig, error) {
 	// Load base configuration
-	config, err := application.LoadConfig()
+	config, err := application.LoadConfig("")
 	if err != nil {
 		return config, err
 	}
 
 	// Override with test-specific settings
-	config.Server.Port = 8081 // Use different port to avoid conflict with NATS WebSocket
+	config.Server.Port = 8081 // Use different port to avoid conflict with a locally running server
 	config.Server.Host = "localhost"
 
 	// Use test database settings (assuming docker-compose setup)
@@ -132,7 +135,7 @@ func (s *E2ETestSuite) loadTestConfiguration() (application.FullConfig, error) {
 	config.Database.SSLMode = "disable"
 
 	// Use test NATS settings
-	config.NATS.URL = "ws://localhost:8080"
+	config.NATS.URL = "nats://localhost:4222"
 
 	// Set test environment
 	config.Environment = "test"
@@ -215,21 +218,48 @@ func (s *E2ETestSuite) initializeNATS() {
 func (s *E2ETestSuite) startApplication() {
 	s.T().Log("Starting application server...")
 
+	appMetrics := metrics.NewPrometheusMetrics()
+	appMetrics.RegisterDatabase(s.db)
+	appMetrics.RegisterNATSConnection(s.natsConn)
+
 	// Initialize adapters
-	messageRepo := postgres.NewPostgreSQLMessageRepository(s.db, s.logger)
-	publisher := natsAdapter.NewNATSMessagePublisher(s.natsConn, s.logger)
+	messageRepo := postgres.NewPostgreSQLMessageRepository(s.db, appMetrics, s.logger)
+	chatRepo := postgres.NewPostgreSQLChatRepository(s.db, s.logger)
+	outboxRepo := postgres.NewPostgreSQLOutboxRepository(s.db, s.logger)
+	presenceRepo := postgres.NewPostgreSQLPresenceRepository(s.db, s.logger)
+	blockRepo := postgres.NewPostgreSQLBlockRepository(s.db, s.logger)
+	blobStore, err := storage.NewLocalBlobStore(s.T().TempDir(), s.logger)
+	s.Require().NoError(err, "Failed to create blob store")
+	publisher := natsAdapter.NewNATSMessagePublisher(s.natsConn, appMetrics, s.logger)
+	subscriber := natsAdapter.NewNATSMessageSubscriber(s.natsConn, s.logger)
+	authenticator, err := auth.NewAuthenticator(s.config.GetAuthConfig())
+	s.Require().NoError(err, "Failed to create authenticator")
+	rateLimiter := postgres.NewPostgreSQLRateLimiter(s.db, s.logger)
 
 	// Create application
 	s.app = application.NewApplication(
 		s.config.GetApplicationConfig(),
 		s.logger,
 		messageRepo,
+		chatRepo,
+		outboxRepo,
+		presenceRepo,
+		blockRepo,
+		blobStore,
 		publisher,
+		subscriber,
+		authenticator,
+		rateLimiter,
+		[]ports.HealthChecker{
+			postgres.NewDatabaseHealthChecker(s.db),
+			natsAdapter.NewConnectionHealthChecker(s.natsConn),
+		},
+		appMetrics,
 		s.config.GetHTTPConfig(),
 	)
 
 	// Initialize application
-	err := s.app.Initialize()
+	err = s.app.Initialize()
 	s.Require().NoError(err, "Failed to initialize application")
 
 	// Start application in background
@@ -251,7 +281,7 @@ func (s *E2ETestSuite) waitForApplicationReady() {
 	s.T().Log("Waiting for application to be ready...")
 
 	s.baseURL = fmt.Sprintf("http://%s:%d", s.config.Server.Host, s.config.Server.Port)
-	healthURL := s.baseURL + "/health"
+	healthURL := s.baseURL + "/health/ready"
 
 	// Wait up to 30 seconds for server to be ready
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -285,7 +315,7 @@ func (s *E2ETestSuite) initializeTestClients() {
 	s.httpManager = testclient.NewTestUserManager(s.baseURL)
 
 	// Initialize NATS test client manager
-	s.natsURL = "ws://localhost:8080" // Use direct NATS connection for now
+	s.natsURL = "nats://localhost:4222" // Observe published events directly on NATS
 	s.natsManager = testclient.NewNATSTestManager(s.natsURL)
 
 	// Verify HTTP client works
@@ -176,6 +176,15 @@ func (c *Client) GetMessages(ctx context.Context, chatID string, options *GetMes
 		if options.Cursor != "" {
 			params.Add("cursor", options.Cursor)
 		}
+		if options.Before != "" {
+			params.Add("before", options.Before)
+		}
+		if options.After != "" {
+			params.Add("after", options.After)
+		}
+		if options.Around != "" {
+			params.Add("around", options.Around)
+		}
 		if options.Limit > 0 {
 			params.Add("limit", fmt.Sprintf("%d", options.Limit))
 		}
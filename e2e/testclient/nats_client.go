@@ -87,7 +87,7 @@ func NewNATSClient(config NATSConfig) (*NATSClient, error) {
 // DefaultNATSConfig returns default NATS configuration for testing
 func DefaultNATSConfig(userID string) NATSConfig {
 	return NATSConfig{
-		URL:           "ws://localhost:8080", // WebSocket connection
+		URL:           "nats://localhost:4222",
 		UserID:        userID,
 		Timeout:       30 * time.Second,
 		MaxReconnects: 5,
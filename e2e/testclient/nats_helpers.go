@@ -283,7 +283,7 @@ func (s *NATSTestScenario) Close() error {
 	return s.manager.CloseAll()
 }
 
-// DefaultNATSURL returns the default NATS WebSocket URL for testing
+// DefaultNATSURL returns the default NATS URL for testing
 func DefaultNATSURL() string {
-	return "ws://localhost:8080"
+	return "nats://localhost:4222"
 }
\ No newline at end of file
@@ -4,7 +4,10 @@ import "fmt"
 
 // GetMessagesOptions holds options for message retrieval
 type GetMessagesOptions struct {
-	Cursor string // RFC3339 timestamp for pagination
+	Cursor string // Opaque cursor or RFC3339 timestamp (deprecated, same as Before)
+	Before string // Opaque cursor, page towards older messages
+	After  string // Opaque cursor, page towards newer messages
+	Around string // Message ID to centre the page on
 	Limit  int    // Maximum number of messages to retrieve
 }
 
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"messaging-app/internal/ports"
+)
+
+const (
+	// ModeHeaders trusts user headers set by an authenticating gateway
+	ModeHeaders = "headers"
+	// ModeJWT verifies bearer tokens issued by an identity provider
+	ModeJWT = "jwt"
+)
+
+type Config struct {
+	Mode string
+
+	// Trusted headers used in headers mode
+	UserIDHeader  string
+	EmailHeader   string
+	HandlerHeader string
+
+	JWT JWTConfig
+}
+
+type JWTConfig struct {
+	// Algorithm is either HS256 (shared Secret) or RS256 (public keys from JWKSFile)
+	Algorithm string
+	Secret    string
+	JWKSFile  string
+
+	// Issuer and Audience are checked against the iss and aud claims when set
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+
+	// Claims mapped onto domain.UserContext
+	UserIDClaim  string
+	EmailClaim   string
+	HandlerClaim string
+}
+
+// NewAuthenticator creates the authenticator selected by config.Mode
+func NewAuthenticator(config Config) (ports.Authenticator, error) {
+	switch config.Mode {
+	case ModeHeaders, "":
+		return NewHeaderAuthenticator(config.UserIDHeader, config.EmailHeader, config.HandlerHeader), nil
+	case ModeJWT:
+		return NewJWTAuthenticator(config.JWT)
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", config.Mode)
+	}
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"messaging-app/internal/domain"
+)
+
+// HeaderAuthenticator trusts the user headers populated by a gateway in front of the service
+// It must only be used when clients cannot reach the service directly
+type HeaderAuthenticator struct {
+	userIDHeader  string
+	emailHeader   string
+	handlerHeader string
+}
+
+func NewHeaderAuthenticator(userIDHeader, emailHeader, handlerHeader string) *HeaderAuthenticator {
+	return &HeaderAuthenticator{
+		userIDHeader:  userIDHeader,
+		emailHeader:   emailHeader,
+		handlerHeader: handlerHeader,
+	}
+}
+
+// Authenticate implements ports.Authenticator
+func (a *HeaderAuthenticator) Authenticate(ctx context.Context, header http.Header) (domain.UserContext, error) {
+	userID := header.Get(a.userIDHeader)
+	if userID == "" {
+		return domain.UserContext{}, fmt.Errorf("%w: %s header is required", domain.ErrMissingUserID, a.userIDHeader)
+	}
+
+	userContext := domain.UserContext{
+		UserID:  userID,
+		Email:   header.Get(a.emailHeader),
+		Handler: header.Get(a.handlerHeader),
+	}
+
+	if err := userContext.Validate(); err != nil {
+		return domain.UserContext{}, err
+	}
+
+	return userContext, nil
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"messaging-app/internal/domain"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderAuthenticator(t *testing.T) {
+	authenticator := NewHeaderAuthenticator("X-User-ID", "X-User-Email", "X-User-Handler")
+
+	t.Run("valid headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-User-ID", testdata.Alice.UserID)
+		header.Set("X-User-Email", testdata.Alice.Email)
+		header.Set("X-User-Handler", testdata.Alice.Handler)
+
+		user, err := authenticator.Authenticate(context.Background(), header)
+
+		require.NoError(t, err)
+		assert.Equal(t, testdata.Alice, user)
+	})
+
+	t.Run("missing user ID", func(t *testing.T) {
+		_, err := authenticator.Authenticate(context.Background(), http.Header{})
+
+		assert.ErrorIs(t, err, domain.ErrMissingUserID)
+	})
+
+	t.Run("user ID containing the chat separator", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-User-ID", "alice---bob")
+		header.Set("X-User-Email", testdata.Alice.Email)
+		header.Set("X-User-Handler", testdata.Alice.Handler)
+
+		_, err := authenticator.Authenticate(context.Background(), header)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidUserID)
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-User-ID", testdata.Alice.UserID)
+		header.Set("X-User-Handler", testdata.Alice.Handler)
+
+		_, err := authenticator.Authenticate(context.Background(), header)
+
+		assert.ErrorIs(t, err, domain.ErrMissingEmail)
+	})
+}
+
+func TestNewAuthenticator_Modes(t *testing.T) {
+	authenticator, err := NewAuthenticator(Config{Mode: ModeHeaders, UserIDHeader: "X-User-ID"})
+	require.NoError(t, err)
+	assert.IsType(t, &HeaderAuthenticator{}, authenticator)
+
+	authenticator, err = NewAuthenticator(Config{Mode: ModeJWT, JWT: JWTConfig{Algorithm: AlgorithmHS256, Secret: testSecret}})
+	require.NoError(t, err)
+	assert.IsType(t, &JWTAuthenticator{}, authenticator)
+
+	_, err = NewAuthenticator(Config{Mode: "basic"})
+	assert.Error(t, err)
+}
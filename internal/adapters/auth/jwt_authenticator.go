@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"messaging-app/internal/domain"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// JWTAuthenticator verifies bearer tokens and maps their claims onto a user context
+type JWTAuthenticator struct {
+	config JWTConfig
+	secret []byte
+	keys   map[string]*rsa.PublicKey
+	now    func() time.Time
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+func NewJWTAuthenticator(config JWTConfig) (*JWTAuthenticator, error) {
+	if config.UserIDClaim == "" {
+		config.UserIDClaim = "sub"
+	}
+	if config.EmailClaim == "" {
+		config.EmailClaim = "email"
+	}
+	if config.HandlerClaim == "" {
+		config.HandlerClaim = "handler"
+	}
+
+	a := &JWTAuthenticator{
+		config: config,
+		now:    time.Now,
+	}
+
+	switch config.Algorithm {
+	case AlgorithmHS256:
+		if config.Secret == "" {
+			return nil, fmt.Errorf("auth.jwt.secret is required for %s", AlgorithmHS256)
+		}
+		a.secret = []byte(config.Secret)
+	case AlgorithmRS256:
+		if config.JWKSFile == "" {
+			return nil, fmt.Errorf("auth.jwt.jwks_file is required for %s", AlgorithmRS256)
+		}
+		keys, err := LoadJWKS(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.keys = keys
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", config.Algorithm)
+	}
+
+	return a, nil
+}
+
+// Authenticate implements ports.Authenticator
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, header http.Header) (domain.UserContext, error) {
+	authorization := header.Get("Authorization")
+	if authorization == "" {
+		return domain.UserContext{}, fmt.Errorf("%w: Authorization header is required", domain.ErrMissingToken)
+	}
+
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return domain.UserContext{}, fmt.Errorf("%w: expected a Bearer token", domain.ErrMalformedToken)
+	}
+
+	claims, err := a.verify(strings.TrimSpace(token))
+	if err != nil {
+		return domain.UserContext{}, err
+	}
+
+	if err := a.validateClaims(claims); err != nil {
+		return domain.UserContext{}, err
+	}
+
+	userContext := domain.UserContext{
+		UserID:  stringClaim(claims, a.config.UserIDClaim),
+		Email:   stringClaim(claims, a.config.EmailClaim),
+		Handler: stringClaim(claims, a.config.HandlerClaim),
+	}
+
+	if err := userContext.Validate(); err != nil {
+		return domain.UserContext{}, err
+	}
+
+	return userContext, nil
+}
+
+// verify checks the token signature and returns its decoded claims
+func (a *JWTAuthenticator) verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected three segments", domain.ErrMalformedToken)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", domain.ErrMalformedToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", domain.ErrMalformedToken, err)
+	}
+
+	// The algorithm is pinned by configuration, never chosen by the token
+	if header.Algorithm != a.config.Algorithm {
+		return nil, fmt.Errorf("%w: unexpected signing algorithm %q", domain.ErrInvalidToken, header.Algorithm)
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	switch a.config.Algorithm {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, a.secret)
+		mac.Write(signed)
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return nil, fmt.Errorf("%w: signature mismatch", domain.ErrInvalidToken)
+		}
+	case AlgorithmRS256:
+		key, err := a.publicKey(header.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return nil, fmt.Errorf("%w: signature mismatch", domain.ErrInvalidToken)
+		}
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", domain.ErrMalformedToken, err)
+	}
+	return claims, nil
+}
+
+func (a *JWTAuthenticator) publicKey(keyID string) (*rsa.PublicKey, error) {
+	if keyID == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, nil
+		}
+	}
+
+	key, ok := a.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key ID %q", domain.ErrInvalidToken, keyID)
+	}
+	return key, nil
+}
+
+// validateClaims checks the registered time, issuer and audience claims
+func (a *JWTAuthenticator) validateClaims(claims map[string]interface{}) error {
+	now := a.now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("%w: exp claim is required", domain.ErrInvalidToken)
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(a.config.Leeway)) {
+		return domain.ErrTokenExpired
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(a.config.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("%w: token is not valid yet", domain.ErrInvalidToken)
+	}
+
+	if a.config.Issuer != "" && stringClaim(claims, "iss") != a.config.Issuer {
+		return fmt.Errorf("%w: unexpected issuer", domain.ErrInvalidToken)
+	}
+
+	if a.config.Audience != "" && !hasAudience(claims["aud"], a.config.Audience) {
+		return fmt.Errorf("%w: unexpected audience", domain.ErrInvalidToken)
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func stringClaim(claims map[string]interface{}, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// hasAudience accepts both the single string and the array form of the aud claim
+func hasAudience(claim interface{}, audience string) bool {
+	switch aud := claim.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, value := range aud {
+			if value == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
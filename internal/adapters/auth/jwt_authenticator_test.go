@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+var testNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func signHS256(t *testing.T, header, claims map[string]interface{}) string {
+	t.Helper()
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, header, claims map[string]interface{}) string {
+	t.Helper()
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":     testdata.Alice.UserID,
+		"email":   testdata.Alice.Email,
+		"handler": testdata.Alice.Handler,
+		"iss":     "https://issuer.example.com",
+		"aud":     []string{"messaging-app"},
+		"exp":     testNow.Add(time.Hour).Unix(),
+	}
+}
+
+func withClaim(name string, value interface{}) map[string]interface{} {
+	claims := validClaims()
+	if value == nil {
+		delete(claims, name)
+	} else {
+		claims[name] = value
+	}
+	return claims
+}
+
+func bearer(token string) http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	return header
+}
+
+func newHS256Authenticator(t *testing.T) *JWTAuthenticator {
+	t.Helper()
+	authenticator, err := NewJWTAuthenticator(JWTConfig{
+		Algorithm: AlgorithmHS256,
+		Secret:    testSecret,
+		Issuer:    "https://issuer.example.com",
+		Audience:  "messaging-app",
+		Leeway:    30 * time.Second,
+	})
+	require.NoError(t, err)
+	authenticator.now = func() time.Time { return testNow }
+	return authenticator
+}
+
+func TestJWTAuthenticator_HS256(t *testing.T) {
+	authenticator := newHS256Authenticator(t)
+	hs256 := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		wantErr error
+	}{
+		{"valid token", bearer(signHS256(t, hs256, validClaims())), nil},
+		{"missing header", http.Header{}, domain.ErrMissingToken},
+		{"basic scheme", http.Header{"Authorization": []string{"Basic dXNlcjpwYXNz"}}, domain.ErrMalformedToken},
+		{"two segments", bearer("abc.def"), domain.ErrMalformedToken},
+		{"undecodable header", bearer("!!!.e30.sig"), domain.ErrMalformedToken},
+		{"wrong signature", bearer(signHS256(t, hs256, validClaims()) + "x"), domain.ErrInvalidToken},
+		{"algorithm none", bearer(encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, validClaims()) + "."), domain.ErrInvalidToken},
+		{"expired", bearer(signHS256(t, hs256, withClaim("exp", testNow.Add(-time.Minute).Unix()))), domain.ErrTokenExpired},
+		{"expired within leeway", bearer(signHS256(t, hs256, withClaim("exp", testNow.Add(-10*time.Second).Unix()))), nil},
+		{"missing exp", bearer(signHS256(t, hs256, withClaim("exp", nil))), domain.ErrInvalidToken},
+		{"not yet valid", bearer(signHS256(t, hs256, withClaim("nbf", testNow.Add(time.Hour).Unix()))), domain.ErrInvalidToken},
+		{"wrong issuer", bearer(signHS256(t, hs256, withClaim("iss", "https://other.example.com"))), domain.ErrInvalidToken},
+		{"wrong audience", bearer(signHS256(t, hs256, withClaim("aud", "other-app"))), domain.ErrInvalidToken},
+		{"single audience", bearer(signHS256(t, hs256, withClaim("aud", "messaging-app"))), nil},
+		{"missing email", bearer(signHS256(t, hs256, withClaim("email", nil))), domain.ErrMissingEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := authenticator.Authenticate(context.Background(), tt.header)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testdata.Alice, user)
+		})
+	}
+}
+
+func TestJWTAuthenticator_RS256WithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+			{"kty": "EC", "kid": "ec-key", "crv": "P-256"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(jwksFile, data, 0o600))
+
+	authenticator, err := NewJWTAuthenticator(JWTConfig{Algorithm: AlgorithmRS256, JWKSFile: jwksFile})
+	require.NoError(t, err)
+	authenticator.now = func() time.Time { return testNow }
+
+	t.Run("valid token", func(t *testing.T) {
+		token := signRS256(t, key, map[string]interface{}{"alg": "RS256", "kid": "key-1"}, validClaims())
+
+		user, err := authenticator.Authenticate(context.Background(), bearer(token))
+
+		require.NoError(t, err)
+		assert.Equal(t, testdata.Alice, user)
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		token := signRS256(t, otherKey, map[string]interface{}{"alg": "RS256", "kid": "key-1"}, validClaims())
+
+		_, err := authenticator.Authenticate(context.Background(), bearer(token))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("unknown key ID", func(t *testing.T) {
+		token := signRS256(t, key, map[string]interface{}{"alg": "RS256", "kid": "key-2"}, validClaims())
+
+		_, err := authenticator.Authenticate(context.Background(), bearer(token))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("HS256 token signed with the public key", func(t *testing.T) {
+		header := map[string]interface{}{"alg": "HS256", "kid": "key-1"}
+		signed := encodeSegment(t, header) + "." + encodeSegment(t, validClaims())
+		mac := hmac.New(sha256.New, key.N.Bytes())
+		mac.Write([]byte(signed))
+		token := signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+		_, err := authenticator.Authenticate(context.Background(), bearer(token))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
+
+func TestNewJWTAuthenticator_InvalidConfig(t *testing.T) {
+	_, err := NewJWTAuthenticator(JWTConfig{Algorithm: AlgorithmHS256})
+	assert.Error(t, err)
+
+	_, err = NewJWTAuthenticator(JWTConfig{Algorithm: AlgorithmRS256, JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Error(t, err)
+
+	_, err = NewJWTAuthenticator(JWTConfig{Algorithm: "ES256"})
+	assert.Error(t, err)
+}
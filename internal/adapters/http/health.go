@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// HealthConfig tunes the readiness probe
+type HealthConfig struct {
+	CheckTimeout  time.Duration // Checks still running after this count as down
+	ShutdownDelay time.Duration // How long readiness fails before the server stops accepting connections
+}
+
+// RegisterHealthCheckers adds dependencies checked by the readiness probe
+func (s *Server) RegisterHealthCheckers(checkers ...ports.HealthChecker) {
+	s.healthCheckers = append(s.healthCheckers, checkers...)
+}
+
+// handleLive reports that the process is serving requests
+// It checks no dependency, so an outage of one does not get every instance restarted
+func (s *Server) handleLive(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, domain.HealthReport{Status: domain.HealthStatusUp})
+}
+
+// handleReady reports whether the instance should receive traffic
+// It fails while the server drains, so load balancers stop routing to it before it shuts down
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if s.draining.Load() {
+		writeHealthReport(w, domain.HealthReport{Status: domain.HealthStatusDown, Draining: true})
+		return
+	}
+
+	writeHealthReport(w, s.checkHealth(r.Context()))
+}
+
+// checkHealth runs every checker concurrently, bounded by the check timeout
+func (s *Server) checkHealth(ctx context.Context) domain.HealthReport {
+	timeout := s.config.Health.CheckTimeout
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	results := make([]domain.ComponentHealth, len(s.healthCheckers))
+	var wg sync.WaitGroup
+	for i, checker := range s.healthCheckers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := checker.Check(ctx)
+			results[i] = domain.NewComponentHealth(err, time.Since(start))
+			if err != nil {
+				s.logger.Warn("Health check failed", "component", checker.Name(), "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	components := make(map[string]domain.ComponentHealth, len(results))
+	for i, checker := range s.healthCheckers {
+		components[checker.Name()] = results[i]
+	}
+	return domain.NewHealthReport(components)
+}
+
+func writeHealthReport(w http.ResponseWriter, report domain.HealthReport) {
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/ports"
+	"messaging-app/internal/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newHealthChecker(name string, err error) *mocks.HealthChecker {
+	checker := &mocks.HealthChecker{}
+	checker.On("Name").Return(name)
+	checker.On("Check", mock.Anything).Return(err)
+	return checker
+}
+
+func newHealthServer(t *testing.T, config HealthConfig, checkers ...ports.HealthChecker) *Server {
+	server := NewServer(Config{Health: config}, &mocks.Authenticator{}, &mocks.RateLimiter{}, &mocks.Metrics{}, testutils.NewTestLogger(t))
+	server.RegisterHealthCheckers(checkers...)
+	require.NoError(t, server.Initialize())
+	return server
+}
+
+func getHealth(t *testing.T, server *Server, path string) (int, domain.HealthReport) {
+	recorder := httptest.NewRecorder()
+	server.mux.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+
+	var report domain.HealthReport
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	return recorder.Code, report
+}
+
+func TestHealth_Ready(t *testing.T) {
+	tests := map[string]struct {
+		outboxErr    error
+		natsErr      error
+		expectedCode int
+		expected     domain.HealthStatus
+	}{
+		"all up":          {expectedCode: http.StatusOK, expected: domain.HealthStatusUp},
+		"degraded outbox": {outboxErr: fmt.Errorf("%w: lagging", domain.ErrHealthDegraded), expectedCode: http.StatusOK, expected: domain.HealthStatusDegraded},
+		"nats down":       {natsErr: errors.New("connection is RECONNECTING"), expectedCode: http.StatusServiceUnavailable, expected: domain.HealthStatusDown},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newHealthServer(t, HealthConfig{},
+				newHealthChecker("database", nil),
+				newHealthChecker("nats", tt.natsErr),
+				newHealthChecker("outbox", tt.outboxErr),
+			)
+
+			code, report := getHealth(t, server, "/health/ready")
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expected, report.Status)
+			require.Len(t, report.Components, 3)
+			assert.Equal(t, domain.HealthStatusUp, report.Components["database"].Status)
+			assert.Empty(t, report.Components["database"].Error)
+
+			// Liveness does not depend on the components
+			code, report = getHealth(t, server, "/health/live")
+			assert.Equal(t, http.StatusOK, code)
+			assert.Equal(t, domain.HealthStatusUp, report.Status)
+		})
+	}
+}
+
+func TestHealth_ReadyCheckTimeout(t *testing.T) {
+	slow := &mocks.HealthChecker{}
+	slow.On("Name").Return("database")
+	slow.On("Check", mock.Anything).Return(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	server := newHealthServer(t, HealthConfig{CheckTimeout: 10 * time.Millisecond}, slow)
+
+	code, report := getHealth(t, server, "/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, domain.HealthStatusDown, report.Components["database"].Status)
+	assert.Contains(t, report.Components["database"].Error, "deadline exceeded")
+	assert.GreaterOrEqual(t, report.Components["database"].LatencyMs, 10.0)
+}
+
+func TestHealth_ReadyFailsWhileDraining(t *testing.T) {
+	server := newHealthServer(t, HealthConfig{ShutdownDelay: 50 * time.Millisecond}, newHealthChecker("database", nil))
+
+	code, _ := getHealth(t, server, "/health/ready")
+	require.Equal(t, http.StatusOK, code)
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- server.Shutdown(context.Background())
+	}()
+
+	// Readiness fails during the delay, while the server still answers requests
+	assert.Eventually(t, func() bool {
+		code, report := getHealth(t, server, "/health/ready")
+		return code == http.StatusServiceUnavailable && report.Draining
+	}, time.Second, time.Millisecond)
+
+	code, _ = getHealth(t, server, "/health/live")
+	assert.Equal(t, http.StatusOK, code)
+
+	require.NoError(t, <-shutdown)
+}
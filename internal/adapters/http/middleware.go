@@ -1,52 +1,104 @@
 package http
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
 	"messaging-app/internal/domain"
 )
 
+const tracerName = "messaging-app/internal/adapters/http"
+
 // withMiddleware applies all global middleware to the handler
 func (s *Server) withMiddleware(next http.Handler) http.Handler {
 	return s.withRecovery(
-		s.withLogging(
-			s.withCORS(
-				s.withContentType(next),
+		s.withTracing(
+			s.withMetrics(
+				s.withLogging(
+					s.withCORS(
+						s.withContentType(next),
+					),
+				),
 			),
 		),
 	)
 }
 
-// withUserContext extracts user context from configured headers
+// withUserContext resolves the user context through the configured authenticator
 func (s *Server) withUserContext(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID := r.Header.Get(s.config.Auth.UserIDHeader)
-		email := r.Header.Get(s.config.Auth.EmailHeader)
-		handler := r.Header.Get(s.config.Auth.HandlerHeader)
-
-		if userID == "" {
-			s.writeErrorResponse(w, http.StatusUnauthorized, "Missing user context", "MISSING_USER_ID",
-				s.config.Auth.UserIDHeader+" header is required")
+		userContext, err := s.authenticator.Authenticate(r.Context(), authHeader(r))
+		if err != nil {
+			s.writeAuthError(w, err)
 			return
 		}
 
-		userContext := domain.UserContext{
-			UserID:  userID,
-			Email:   email,
-			Handler: handler,
+		ctx := context.WithValue(r.Context(), UserContextKey, userContext)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
+
+// authHeader returns the headers the user is authenticated from
+// A WebSocket upgrade may pass its bearer token as a query parameter instead of the Authorization header
+func authHeader(r *http.Request) http.Header {
+	token := r.URL.Query().Get(AccessTokenParam)
+	if token == "" || r.Header.Get("Authorization") != "" || !websocket.IsWebSocketUpgrade(r) {
+		return r.Header
+	}
+
+	header := r.Header.Clone()
+	header.Set("Authorization", "Bearer "+token)
+	return header
+}
+
+// withRateLimit throttles the route with a token bucket per client
+// Limiter failures let requests through so an unavailable store does not take the API down with it
+func (s *Server) withRateLimit(route Route, next http.HandlerFunc) http.HandlerFunc {
+	limit, limited := s.config.RateLimit.limitFor(route)
+	if !limited || s.rateLimiter == nil {
+		return next
+	}
+
+	routeKey := route.rateLimitKey()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := routeKey + "|" + s.rateLimitClient(r)
+
+		decision, err := s.rateLimiter.Allow(r.Context(), key, limit)
+		if err != nil {
+			s.logger.Error("Failed to apply rate limit", "error", err, "key", key)
+			next(w, r)
+			return
 		}
 
-		if err := userContext.Validate(); err != nil {
-			s.writeErrorResponse(w, http.StatusUnauthorized, "Invalid user context", "INVALID_USER_CONTEXT", err.Error())
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+
+		if !decision.Allowed {
+			retryAfter := max(ceilSeconds(decision.RetryAfter), 1)
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			s.writeErrorResponse(w, http.StatusTooManyRequests, "Too many requests", "RATE_LIMITED", fmt.Sprintf("retry in %d seconds", retryAfter))
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), UserContextKey, userContext)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next(w, r)
 	}
 }
 
@@ -61,14 +113,72 @@ func (s *Server) withLogging(next http.Handler) http.Handler {
 		next.ServeHTTP(wrapped, r)
 
 		duration := time.Since(start)
-		s.logger.Info("HTTP request",
+		args := []any{
 			"method", r.Method,
 			"path", r.URL.Path,
 			"status", wrapped.statusCode,
 			"duration", duration,
 			"user_agent", r.UserAgent(),
 			"remote_addr", r.RemoteAddr,
+		}
+
+		// The trace ID finds the spans of a request from its log line
+		if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.HasTraceID() {
+			args = append(args, "trace_id", spanContext.TraceID().String())
+		}
+		s.logger.Info("HTTP request", args...)
+	})
+}
+
+// withTracing starts a server span for each request, continuing the trace of the client when it sent one
+func (s *Server) withTracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := otel.Tracer(tracerName).Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+			),
 		)
+		defer span.End()
+
+		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		r = r.WithContext(ctx)
+
+		next.ServeHTTP(wrapped, r)
+
+		// The span is named after the route once the mux has matched one
+		if route := matchedRoute(r); route != "" {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(wrapped.statusCode))
+		if wrapped.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(wrapped.statusCode))
+		}
+	})
+}
+
+// withMetrics records the duration of requests under the pattern of the route they matched,
+// so path parameters do not create a series per chat or message
+func (s *Server) withMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		next.ServeHTTP(wrapped, r)
+
+		// WebSocket sessions last as long as the client stays connected, which is not a latency
+		if wrapped.statusCode == http.StatusSwitchingProtocols {
+			return
+		}
+
+		route := matchedRoute(r)
+		if route == "" {
+			route = "unmatched"
+		}
+		s.metrics.ObserveHTTPRequest(r.Method, route, wrapped.statusCode, time.Since(start))
 	})
 }
 
@@ -107,6 +217,7 @@ func (s *Server) withCORS(next http.Handler) http.Handler {
 			defaultHeaders := []string{
 				"Content-Type",
 				"Authorization",
+				"Idempotency-Key",
 				s.config.Auth.UserIDHeader,
 				s.config.Auth.EmailHeader,
 				s.config.Auth.HandlerHeader,
@@ -114,6 +225,9 @@ func (s *Server) withCORS(next http.Handler) http.Handler {
 			w.Header().Set("Access-Control-Allow-Headers", strings.Join(defaultHeaders, ", "))
 		}
 
+		// Let browser clients read the rate limit headers
+		w.Header().Set("Access-Control-Expose-Headers", "Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset")
+
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
 			return
@@ -162,6 +276,66 @@ func (s *Server) writeErrorResponse(w http.ResponseWriter, statusCode int, messa
 	}
 }
 
+// writeAuthError maps authenticator errors onto 401 responses
+func (s *Server) writeAuthError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrMissingUserID):
+		s.writeErrorResponse(w, http.StatusUnauthorized, "Missing user context", "MISSING_USER_ID", err.Error())
+	case errors.Is(err, domain.ErrMissingToken):
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		s.writeErrorResponse(w, http.StatusUnauthorized, "Missing token", "MISSING_TOKEN", err.Error())
+	case errors.Is(err, domain.ErrMalformedToken):
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		s.writeErrorResponse(w, http.StatusUnauthorized, "Malformed token", "MALFORMED_TOKEN", err.Error())
+	case errors.Is(err, domain.ErrTokenExpired):
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token", error_description="token has expired"`)
+		s.writeErrorResponse(w, http.StatusUnauthorized, "Token expired", "TOKEN_EXPIRED", err.Error())
+	case errors.Is(err, domain.ErrInvalidToken):
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		s.writeErrorResponse(w, http.StatusUnauthorized, "Invalid token", "INVALID_TOKEN", err.Error())
+	default:
+		s.writeErrorResponse(w, http.StatusUnauthorized, "Invalid user context", "INVALID_USER_CONTEXT", err.Error())
+	}
+}
+
+// rateLimitClient identifies who a request counts against
+func (s *Server) rateLimitClient(r *http.Request) string {
+	if user, ok := GetUserFromContext(r.Context()); ok {
+		return "user:" + user.UserID
+	}
+	return "ip:" + s.clientIP(r)
+}
+
+// clientIP is the address of the client, or of the first proxy unless forwarded headers are trusted
+func (s *Server) clientIP(r *http.Request) string {
+	if s.config.RateLimit.TrustForwardedFor {
+		client, _, _ := strings.Cut(r.Header.Get("X-Forwarded-For"), ",")
+		if client = strings.TrimSpace(client); client != "" {
+			return client
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// matchedRoute is the path of the pattern the mux matched, such as "/api/v1/chats/{chatId}",
+// or "" when none did; the mux sets it on the request it was given
+func matchedRoute(r *http.Request) string {
+	if _, path, ok := strings.Cut(r.Pattern, " "); ok {
+		return path
+	}
+	return r.Pattern
+}
+
+// ceilSeconds rounds a duration up to whole seconds for rate limit headers
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
 // responseWriter wraps http.ResponseWriter to capture status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -172,3 +346,26 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Hijack lets WebSocket upgrades take over the connection through the wrapper
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	rw.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// isOriginAllowed checks a request origin against the configured CORS origins
+func isOriginAllowed(allowedOrigins []string, origin string) bool {
+	if len(allowedOrigins) == 0 || origin == "" {
+		return true
+	}
+	for _, allowedOrigin := range allowedOrigins {
+		if allowedOrigin == "*" || allowedOrigin == origin {
+			return true
+		}
+	}
+	return false
+}
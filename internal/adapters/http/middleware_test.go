@@ -0,0 +1,266 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"messaging-app/internal/adapters/metrics"
+	"messaging-app/internal/adapters/ratelimit"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/ports"
+	"messaging-app/internal/testutils"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newRateLimitedServer(t *testing.T, config RateLimitConfig, limiter ports.RateLimiter, routes ...Route) *Server {
+	authenticator := &mocks.Authenticator{}
+	authenticator.On("Authenticate", mock.Anything, mock.Anything).Return(func(_ context.Context, header http.Header) (domain.UserContext, error) {
+		if header.Get("X-User-ID") == "" {
+			return domain.UserContext{}, domain.ErrMissingUserID
+		}
+		return domain.UserContext{UserID: header.Get("X-User-ID")}, nil
+	})
+
+	server := NewServer(Config{RateLimit: config}, authenticator, limiter, &mocks.Metrics{}, testutils.NewTestLogger(t))
+	server.RegisterRoutes(routes)
+	return server
+}
+
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func serve(server *Server, method, path, userID, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	if remoteAddr != "" {
+		req.RemoteAddr = remoteAddr
+	}
+	recorder := httptest.NewRecorder()
+	server.mux.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRateLimit_PerUser(t *testing.T) {
+	server := newRateLimitedServer(t,
+		RateLimitConfig{Enabled: true, Default: domain.RateLimit{Requests: 100, Window: time.Minute}},
+		ratelimit.NewMemoryRateLimiter(),
+		Route{Method: "POST", Pattern: "/send", Handler: okHandler, RequireAuth: true, RateLimit: domain.RateLimit{Requests: 2, Window: time.Minute}},
+	)
+
+	for remaining := 1; remaining >= 0; remaining-- {
+		recorder := serve(server, "POST", "/send", testdata.Alice.UserID, "")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, strconv.Itoa(remaining), recorder.Header().Get("X-RateLimit-Remaining"))
+		assert.Empty(t, recorder.Header().Get("Retry-After"))
+	}
+
+	recorder := serve(server, "POST", "/send", testdata.Alice.UserID, "")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "30", recorder.Header().Get("Retry-After"))
+	assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", recorder.Header().Get("X-RateLimit-Reset"))
+	assert.Contains(t, recorder.Body.String(), "RATE_LIMITED")
+
+	// Other users, even behind the same address, have their own bucket
+	recorder = serve(server, "POST", "/send", testdata.Bob.UserID, "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestRateLimit_PerIP(t *testing.T) {
+	limit := domain.RateLimit{Requests: 1, Window: time.Minute}
+
+	t.Run("remote address", func(t *testing.T) {
+		server := newRateLimitedServer(t, RateLimitConfig{Enabled: true, Default: limit}, ratelimit.NewMemoryRateLimiter(),
+			Route{Method: "GET", Pattern: "/public", Handler: okHandler},
+		)
+
+		assert.Equal(t, http.StatusOK, serve(server, "GET", "/public", "", "203.0.113.7:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve(server, "GET", "/public", "", "203.0.113.7:5678").Code)
+		assert.Equal(t, http.StatusOK, serve(server, "GET", "/public", "", "203.0.113.8:1234").Code)
+	})
+
+	t.Run("forwarded for", func(t *testing.T) {
+		limiter := &mocks.RateLimiter{}
+		limiter.On("Allow", mock.Anything, "GET /public|ip:198.51.100.1", limit).Return(domain.RateLimitDecision{Allowed: true, Limit: 1}, nil).Once()
+		limiter.On("Allow", mock.Anything, "GET /public|ip:203.0.113.7", limit).Return(domain.RateLimitDecision{Allowed: true, Limit: 1}, nil).Once()
+
+		trusting := newRateLimitedServer(t, RateLimitConfig{Enabled: true, Default: limit, TrustForwardedFor: true}, limiter,
+			Route{Method: "GET", Pattern: "/public", Handler: okHandler},
+		)
+		req := httptest.NewRequest("GET", "/public", nil)
+		req.RemoteAddr = "203.0.113.7:1234"
+		req.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7")
+		trusting.mux.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Without trust the header is ignored so clients cannot pick their own bucket
+		untrusting := newRateLimitedServer(t, RateLimitConfig{Enabled: true, Default: limit}, limiter,
+			Route{Method: "GET", Pattern: "/public", Handler: okHandler},
+		)
+		untrusting.mux.ServeHTTP(httptest.NewRecorder(), req)
+
+		limiter.AssertExpectations(t)
+	})
+}
+
+func TestRateLimit_LimiterFailureLetsRequestsThrough(t *testing.T) {
+	limiter := &mocks.RateLimiter{}
+	limiter.On("Allow", mock.Anything, "POST /send|user:"+testdata.Alice.UserID, mock.Anything).Return(domain.RateLimitDecision{}, errors.New("database error"))
+
+	server := newRateLimitedServer(t, RateLimitConfig{Enabled: true, Default: domain.RateLimit{Requests: 1, Window: time.Minute}}, limiter,
+		Route{Method: "POST", Pattern: "/send", Handler: okHandler, RequireAuth: true},
+	)
+
+	recorder := serve(server, "POST", "/send", testdata.Alice.UserID, "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("X-RateLimit-Limit"))
+	limiter.AssertExpectations(t)
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	// The mock has no expectations, so any call to the limiter fails the test
+	limiter := &mocks.RateLimiter{}
+	server := newRateLimitedServer(t, RateLimitConfig{Enabled: false, Default: domain.RateLimit{Requests: 1, Window: time.Minute}}, limiter,
+		Route{Method: "POST", Pattern: "/send", Handler: okHandler, RequireAuth: true},
+	)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, serve(server, "POST", "/send", testdata.Alice.UserID, "").Code)
+	}
+
+	// Unauthenticated requests are rejected before they reach the limiter
+	assert.Equal(t, http.StatusUnauthorized, serve(server, "POST", "/send", "", "").Code)
+}
+
+func TestMetrics_ObservesRoutePattern(t *testing.T) {
+	metrics := mocks.NewMetrics(t)
+	metrics.On("ObserveHTTPRequest", "GET", "/chats/{chatId}", http.StatusOK, mock.Anything).Once()
+	metrics.On("ObserveHTTPRequest", "GET", "unmatched", http.StatusNotFound, mock.Anything).Once()
+	metrics.On("Handler").Return(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("messaging_messages_read_total 0\n"))
+	}))
+	metrics.On("ObserveHTTPRequest", "GET", "/metrics", http.StatusOK, mock.Anything).Once()
+
+	server := NewServer(Config{Metrics: MetricsConfig{Enabled: true, Path: "/metrics"}}, &mocks.Authenticator{}, &mocks.RateLimiter{}, metrics, testutils.NewTestLogger(t))
+	server.RegisterRoutes([]Route{{Method: "GET", Pattern: "/chats/{chatId}", Handler: okHandler}})
+	require.NoError(t, server.Initialize())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		server.server.Handler.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+		return recorder
+	}
+
+	// Requests for different chats share one series
+	assert.Equal(t, http.StatusOK, get("/chats/"+testdata.Alice.UserID).Code)
+	assert.Equal(t, http.StatusNotFound, get("/missing").Code)
+
+	recorder := get("/metrics")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "messaging_messages_read_total")
+}
+
+func TestMetrics_EndpointDisabled(t *testing.T) {
+	metrics := mocks.NewMetrics(t)
+	metrics.On("ObserveHTTPRequest", "GET", "unmatched", http.StatusNotFound, mock.Anything).Once()
+
+	server := NewServer(Config{Metrics: MetricsConfig{Enabled: false, Path: "/metrics"}}, &mocks.Authenticator{}, &mocks.RateLimiter{}, metrics, testutils.NewTestLogger(t))
+	require.NoError(t, server.Initialize())
+
+	recorder := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestTracing_ContinuesClientTrace(t *testing.T) {
+	spans := testutils.NewTestTracer(t)
+
+	var handlerSpan trace.SpanContext
+	server := NewServer(Config{}, &mocks.Authenticator{}, &mocks.RateLimiter{}, metrics.NewNopMetrics(), testutils.NewTestLogger(t))
+	server.RegisterRoutes([]Route{
+		{Method: "GET", Pattern: "/chats/{chatId}", Handler: func(w http.ResponseWriter, r *http.Request) {
+			handlerSpan = trace.SpanContextFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}},
+		{Method: "GET", Pattern: "/fail", Handler: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}},
+	})
+	require.NoError(t, server.Initialize())
+
+	req := httptest.NewRequest("GET", "/chats/"+testdata.Alice.UserID, nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	server.server.Handler.ServeHTTP(httptest.NewRecorder(), req)
+	server.server.Handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/fail", nil))
+
+	ended := spans.GetSpans()
+	require.Len(t, ended, 2)
+
+	span := ended[0]
+	assert.Equal(t, "GET /chats/{chatId}", span.Name)
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+	assert.Contains(t, span.Attributes, attribute.String("http.route", "/chats/{chatId}"))
+	assert.Contains(t, span.Attributes, attribute.Int("http.response.status_code", http.StatusOK))
+	assert.Equal(t, codes.Unset, span.Status.Code)
+
+	// Handlers see the server span, so the spans of the adapters they call are its children
+	assert.Equal(t, span.SpanContext.SpanID(), handlerSpan.SpanID())
+
+	assert.Equal(t, "GET /fail", ended[1].Name)
+	assert.False(t, ended[1].Parent.IsValid())
+	assert.Equal(t, codes.Error, ended[1].Status.Code)
+}
+
+func TestUserContext_WebSocketAccessToken(t *testing.T) {
+	authenticator := &mocks.Authenticator{}
+	authenticator.On("Authenticate", mock.Anything, mock.Anything).Return(func(_ context.Context, header http.Header) (domain.UserContext, error) {
+		if header.Get("Authorization") != "Bearer token" {
+			return domain.UserContext{}, domain.ErrMissingUserID
+		}
+		return domain.UserContext{UserID: testdata.Alice.UserID}, nil
+	})
+
+	server := NewServer(Config{}, authenticator, &mocks.RateLimiter{}, &mocks.Metrics{}, testutils.NewTestLogger(t))
+	server.RegisterRoutes([]Route{{Method: "GET", Pattern: WebSocketPath, Handler: okHandler, RequireAuth: true}})
+
+	upgrade := func() *http.Request {
+		req := httptest.NewRequest("GET", WebSocketPath+"?"+AccessTokenParam+"=token", nil)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		return req
+	}
+
+	recorder := httptest.NewRecorder()
+	server.mux.ServeHTTP(recorder, upgrade())
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// The Authorization header wins over the query parameter
+	req := upgrade()
+	req.Header.Set("Authorization", "Bearer other")
+	recorder = httptest.NewRecorder()
+	server.mux.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	// Plain requests must send the header, so tokens stay out of URLs where they can
+	recorder = httptest.NewRecorder()
+	server.mux.ServeHTTP(recorder, httptest.NewRequest("GET", WebSocketPath+"?"+AccessTokenParam+"=token", nil))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
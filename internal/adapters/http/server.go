@@ -5,16 +5,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
 
+	"messaging-app/internal/domain"
 	"messaging-app/internal/ports"
 )
 
 type Server struct {
-	config Config
-	logger ports.Logger
-	server *http.Server
-	mux    *http.ServeMux
+	config        Config
+	authenticator ports.Authenticator
+	rateLimiter   ports.RateLimiter
+	metrics       ports.Metrics
+	logger        ports.Logger
+	server        *http.Server
+	mux           *http.ServeMux
+	onShutdown    []func()
+
+	healthCheckers []ports.HealthChecker
+	draining       atomic.Bool
 }
 
 type Config struct {
@@ -24,8 +33,13 @@ type Config struct {
 	WriteTimeout time.Duration
 	Auth         AuthConfig
 	CORS         CORSConfig
+	WebSocket    WebSocketConfig
+	RateLimit    RateLimitConfig
+	Health       HealthConfig
+	Metrics      MetricsConfig
 }
 
+// AuthConfig lists the trusted user headers, advertised in the default CORS allowed headers
 type AuthConfig struct {
 	UserIDHeader  string
 	EmailHeader   string
@@ -38,18 +52,49 @@ type CORSConfig struct {
 	AllowedHeaders []string
 }
 
+// RateLimitConfig throttles each client per route, by user on authenticated routes and by IP otherwise
+type RateLimitConfig struct {
+	Enabled           bool
+	Default           domain.RateLimit // Applies to routes without their own limit
+	TrustForwardedFor bool             // Take the client IP from X-Forwarded-For when behind a proxy
+}
+
+// limitFor returns the limit applied to route, and whether it is limited at all
+func (c RateLimitConfig) limitFor(route Route) (domain.RateLimit, bool) {
+	limit := route.RateLimit
+	if limit.IsZero() {
+		limit = c.Default
+	}
+	return limit, c.Enabled && !limit.IsZero()
+}
+
+// MetricsConfig exposes the collected metrics to scrapers at Path
+type MetricsConfig struct {
+	Enabled bool
+	Path    string
+}
+
 type Route struct {
 	Method      string
 	Pattern     string
 	Handler     http.HandlerFunc
 	RequireAuth bool
+	RateLimit   domain.RateLimit // Overrides the default rate limit when set
+}
+
+// rateLimitKey prefixes the buckets of the route; every route has its own
+func (r Route) rateLimitKey() string {
+	return r.Method + " " + r.Pattern
 }
 
-func NewServer(config Config, logger ports.Logger) *Server {
+func NewServer(config Config, authenticator ports.Authenticator, rateLimiter ports.RateLimiter, metrics ports.Metrics, logger ports.Logger) *Server {
 	return &Server{
-		config: config,
-		logger: logger,
-		mux:    http.NewServeMux(),
+		config:        config,
+		authenticator: authenticator,
+		rateLimiter:   rateLimiter,
+		metrics:       metrics,
+		logger:        logger,
+		mux:           http.NewServeMux(),
 	}
 }
 
@@ -57,11 +102,10 @@ func (s *Server) RegisterRoutes(routes []Route) {
 	for _, route := range routes {
 		pattern := fmt.Sprintf("%s %s", route.Method, route.Pattern)
 
-		var handler http.HandlerFunc
+		// Rate limiting runs after authentication so authenticated clients are limited by user
+		handler := s.withRateLimit(route, route.Handler)
 		if route.RequireAuth {
-			handler = s.withUserContext(route.Handler)
-		} else {
-			handler = route.Handler
+			handler = s.withUserContext(handler)
 		}
 
 		s.mux.HandleFunc(pattern, handler)
@@ -69,15 +113,27 @@ func (s *Server) RegisterRoutes(routes []Route) {
 	}
 }
 
+// RegisterOnShutdown registers a function to call when the server shuts down,
+// used to close long-lived connections such as WebSockets
+func (s *Server) RegisterOnShutdown(fn func()) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
 func (s *Server) Initialize() error {
 	s.logger.Info("Initializing HTTP server...")
 
-	// Add default health check route
+	// Add health check routes; /health predates the liveness and readiness probes
 	s.mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 	})
+	s.mux.HandleFunc("GET /health/live", s.handleLive)
+	s.mux.HandleFunc("GET /health/ready", s.handleReady)
+
+	if s.config.Metrics.Enabled {
+		s.mux.Handle("GET "+s.config.Metrics.Path, s.metrics.Handler())
+	}
 
 	// Create HTTP server
 	s.server = &http.Server{
@@ -87,6 +143,10 @@ func (s *Server) Initialize() error {
 		WriteTimeout: s.config.WriteTimeout,
 	}
 
+	for _, fn := range s.onShutdown {
+		s.server.RegisterOnShutdown(fn)
+	}
+
 	s.logger.Info("HTTP server initialized successfully")
 	return nil
 }
@@ -104,6 +164,16 @@ func (s *Server) Start() error {
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down HTTP server...")
 
+	// Readiness fails from now on; the delay lets load balancers notice before connections are refused
+	s.draining.Store(true)
+	if delay := s.config.Health.ShutdownDelay; delay > 0 {
+		s.logger.Info("Draining before shutdown", "delay", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
 	if err := s.server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("failed to shutdown HTTP server: %w", err)
 	}
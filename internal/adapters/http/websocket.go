@@ -0,0 +1,398 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+const (
+	// WebSocketPath is where the real-time gateway is served
+	WebSocketPath = "/api/v1/ws"
+
+	// ResumeAfterParam asks the gateway to replay events after the given stream sequence
+	ResumeAfterParam = "resume_after"
+
+	// AccessTokenParam carries the bearer token of a WebSocket upgrade, since browsers cannot set its headers
+	AccessTokenParam = "access_token"
+
+	// Frame types produced by the gateway itself; forwarded NATS envelopes keep their own type
+	FrameTypeAck   = "ack"
+	FrameTypeError = "error"
+)
+
+type WebSocketConfig struct {
+	PingInterval   time.Duration
+	PongTimeout    time.Duration
+	WriteTimeout   time.Duration
+	MaxMessageSize int64
+	SendBufferSize int
+}
+
+// WebSocketFrame is the command/response format exchanged with clients
+type WebSocketFrame struct {
+	Type      string          `json:"type"`
+	RequestID string          `json:"request_id,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Error     *ErrorResponse  `json:"error,omitempty"`
+}
+
+// CommandError is returned by command handlers to report a client-facing failure
+type CommandError struct {
+	Message string
+	Code    string
+	Details string
+}
+
+func (e *CommandError) Error() string {
+	return e.Message
+}
+
+// WebSocketCommandHandler executes a command sent over the socket on behalf of the user
+// The returned value is encoded as the data of the ack frame
+type WebSocketCommandHandler func(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error)
+
+type webSocketCommand struct {
+	route   Route
+	handler WebSocketCommandHandler
+}
+
+// WebSocketGateway relays a user's real-time events to a WebSocket and accepts commands from it
+type WebSocketGateway struct {
+	config      WebSocketConfig
+	rateLimit   RateLimitConfig
+	subscriber  ports.MessageSubscriber
+	rateLimiter ports.RateLimiter
+	logger      ports.Logger
+	upgrader    websocket.Upgrader
+	commands    map[string]webSocketCommand
+
+	mu    sync.Mutex
+	conns map[*websocket.Conn]struct{}
+}
+
+func NewWebSocketGateway(config WebSocketConfig, corsConfig CORSConfig, rateLimit RateLimitConfig, subscriber ports.MessageSubscriber, rateLimiter ports.RateLimiter, logger ports.Logger) *WebSocketGateway {
+	defaults := DefaultWebSocketConfig()
+	if config.PingInterval <= 0 {
+		config.PingInterval = defaults.PingInterval
+	}
+	if config.PongTimeout <= config.PingInterval {
+		config.PongTimeout = config.PingInterval + config.PingInterval/2
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = defaults.WriteTimeout
+	}
+	if config.MaxMessageSize <= 0 {
+		config.MaxMessageSize = defaults.MaxMessageSize
+	}
+	if config.SendBufferSize <= 0 {
+		config.SendBufferSize = defaults.SendBufferSize
+	}
+
+	return &WebSocketGateway{
+		config:      config,
+		rateLimit:   rateLimit,
+		subscriber:  subscriber,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return isOriginAllowed(corsConfig.AllowedOrigins, r.Header.Get("Origin"))
+			},
+		},
+		commands: make(map[string]webSocketCommand),
+		conns:    make(map[*websocket.Conn]struct{}),
+	}
+}
+
+func DefaultWebSocketConfig() WebSocketConfig {
+	return WebSocketConfig{
+		PingInterval:   30 * time.Second,
+		PongTimeout:    45 * time.Second,
+		WriteTimeout:   10 * time.Second,
+		MaxMessageSize: 64 * 1024,
+		SendBufferSize: 64,
+	}
+}
+
+// HandleCommand registers the handler for a command frame type
+// route is the HTTP route performing the same operation; the command shares its rate limit buckets,
+// so a client gets no more requests by switching transports
+func (g *WebSocketGateway) HandleCommand(commandType string, route Route, handler WebSocketCommandHandler) {
+	g.commands[commandType] = webSocketCommand{route: route, handler: handler}
+}
+
+// Route returns the authenticated route serving the gateway
+func (g *WebSocketGateway) Route() Route {
+	return Route{
+		Method:      "GET",
+		Pattern:     WebSocketPath,
+		Handler:     g.ServeWS,
+		RequireAuth: true,
+	}
+}
+
+// ServeWS handles GET /api/v1/ws
+func (g *WebSocketGateway) ServeWS(w http.ResponseWriter, r *http.Request) {
+	user, ok := GetUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(ErrorResponse{Error: "User context not found", Code: "NO_USER_CONTEXT"})
+		return
+	}
+
+	var opts ports.SubscribeOptions
+	if raw := r.URL.Query().Get(ResumeAfterParam); raw != "" {
+		resumeAfter, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "Invalid resume sequence", Code: "INVALID_RESUME_AFTER", Details: err.Error()})
+			return
+		}
+		opts.ResumeAfter = resumeAfter
+	}
+
+	conn, err := g.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade already wrote the HTTP error response
+		g.logger.Warn("WebSocket upgrade failed", "error", err, "user", user.UserID)
+		return
+	}
+
+	g.track(conn)
+	defer g.untrack(conn)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	send := make(chan []byte, g.config.SendBufferSize)
+
+	sub, err := g.subscriber.SubscribeUser(ctx, user.UserID, opts, func(event ports.Event) {
+		payload := event.Payload
+		if event.Sequence > 0 {
+			payload = withSequence(payload, event.Sequence)
+		}
+
+		select {
+		case send <- payload:
+		default:
+			// Slow consumer: drop the connection instead of blocking the subscriber
+			g.logger.Warn("WebSocket send buffer full, closing connection", "user", user.UserID)
+			cancel()
+		}
+	})
+	if err != nil {
+		g.logger.Error("Failed to subscribe user", "error", err, "user", user.UserID)
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "subscription failed"),
+			time.Now().Add(g.config.WriteTimeout))
+		conn.Close()
+		return
+	}
+	defer sub.Unsubscribe()
+
+	g.logger.Info("WebSocket connected", "user", user.UserID, "remote_addr", r.RemoteAddr, "resume_after", opts.ResumeAfter)
+
+	go g.writePump(ctx, cancel, conn, send)
+	g.readPump(ctx, cancel, conn, user, send)
+
+	g.logger.Info("WebSocket disconnected", "user", user.UserID)
+}
+
+// Close terminates every open connection; used during server shutdown
+func (g *WebSocketGateway) Close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for conn := range g.conns {
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+			time.Now().Add(time.Second))
+		conn.Close()
+	}
+}
+
+// readPump processes client frames until the connection fails or the context ends
+func (g *WebSocketGateway) readPump(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, user domain.UserContext, send chan<- []byte) {
+	defer cancel()
+
+	conn.SetReadLimit(g.config.MaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(g.config.PongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(g.config.PongTimeout))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				g.logger.Warn("WebSocket read failed", "error", err, "user", user.UserID)
+			}
+			return
+		}
+
+		var response WebSocketFrame
+		var frame WebSocketFrame
+		if err := json.Unmarshal(data, &frame); err != nil {
+			response = WebSocketFrame{
+				Type:  FrameTypeError,
+				Error: &ErrorResponse{Error: "Invalid JSON", Code: "INVALID_JSON", Details: err.Error()},
+			}
+		} else {
+			response = g.dispatch(ctx, user, frame)
+		}
+
+		payload, err := json.Marshal(response)
+		if err != nil {
+			g.logger.Error("Failed to marshal WebSocket response", "error", err, "user", user.UserID)
+			continue
+		}
+
+		select {
+		case send <- payload:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// writePump is the only goroutine writing to the connection
+func (g *WebSocketGateway) writePump(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, send <-chan []byte) {
+	ticker := time.NewTicker(g.config.PingInterval)
+	defer func() {
+		ticker.Stop()
+		cancel()
+		conn.Close()
+	}()
+
+	for {
+		select {
+		case payload := <-send:
+			conn.SetWriteDeadline(time.Now().Add(g.config.WriteTimeout))
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(g.config.WriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				time.Now().Add(g.config.WriteTimeout))
+			return
+		}
+	}
+}
+
+// dispatch runs the command handler for a frame and builds the reply
+func (g *WebSocketGateway) dispatch(ctx context.Context, user domain.UserContext, frame WebSocketFrame) WebSocketFrame {
+	command, ok := g.commands[frame.Type]
+	if !ok {
+		return WebSocketFrame{
+			Type:      FrameTypeError,
+			RequestID: frame.RequestID,
+			Error:     &ErrorResponse{Error: "Unknown command", Code: "UNKNOWN_COMMAND", Details: frame.Type},
+		}
+	}
+
+	if errResponse := g.checkRateLimit(ctx, user, command.route); errResponse != nil {
+		return WebSocketFrame{
+			Type:      FrameTypeError,
+			RequestID: frame.RequestID,
+			Error:     errResponse,
+		}
+	}
+
+	result, err := command.handler(ctx, user, frame.Data)
+	if err != nil {
+		errResponse := &ErrorResponse{Error: "Command failed", Code: "COMMAND_ERROR"}
+		if cmdErr, ok := err.(*CommandError); ok {
+			errResponse = &ErrorResponse{Error: cmdErr.Message, Code: cmdErr.Code, Details: cmdErr.Details}
+		} else {
+			g.logger.Error("WebSocket command failed", "error", err, "command", frame.Type, "user", user.UserID)
+		}
+
+		return WebSocketFrame{
+			Type:      FrameTypeError,
+			RequestID: frame.RequestID,
+			Error:     errResponse,
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		g.logger.Error("Failed to marshal command result", "error", err, "command", frame.Type, "user", user.UserID)
+		return WebSocketFrame{
+			Type:      FrameTypeError,
+			RequestID: frame.RequestID,
+			Error:     &ErrorResponse{Error: "Command failed", Code: "COMMAND_ERROR"},
+		}
+	}
+
+	return WebSocketFrame{
+		Type:      FrameTypeAck,
+		RequestID: frame.RequestID,
+		Data:      data,
+	}
+}
+
+// checkRateLimit takes a token from the user's bucket for the route of a command
+// Like withRateLimit, limiter failures let the command through
+func (g *WebSocketGateway) checkRateLimit(ctx context.Context, user domain.UserContext, route Route) *ErrorResponse {
+	limit, limited := g.rateLimit.limitFor(route)
+	if !limited || g.rateLimiter == nil {
+		return nil
+	}
+
+	key := route.rateLimitKey() + "|user:" + user.UserID
+	decision, err := g.rateLimiter.Allow(ctx, key, limit)
+	if err != nil {
+		g.logger.Error("Failed to apply rate limit", "error", err, "key", key)
+		return nil
+	}
+	if decision.Allowed {
+		return nil
+	}
+
+	retryAfter := max(ceilSeconds(decision.RetryAfter), 1)
+	return &ErrorResponse{Error: "Too many requests", Code: "RATE_LIMITED", Details: fmt.Sprintf("retry in %d seconds", retryAfter)}
+}
+
+// withSequence adds the stream sequence to a forwarded envelope so clients can resume from it
+func withSequence(payload []byte, sequence uint64) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return payload
+	}
+
+	fields["sequence"] = json.RawMessage(strconv.FormatUint(sequence, 10))
+
+	annotated, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+	return annotated
+}
+
+func (g *WebSocketGateway) track(conn *websocket.Conn) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.conns[conn] = struct{}{}
+}
+
+func (g *WebSocketGateway) untrack(conn *websocket.Conn) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.conns, conn)
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"messaging-app/internal/adapters/ratelimit"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/testutils"
+	"messaging-app/testdata"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocket_CommandsShareRouteRateLimit(t *testing.T) {
+	rateLimit := RateLimitConfig{Enabled: true, Default: domain.RateLimit{Requests: 100, Window: time.Minute}}
+	limiter := ratelimit.NewMemoryRateLimiter()
+	sendRoute := Route{Method: "POST", Pattern: "/send", Handler: okHandler, RequireAuth: true, RateLimit: domain.RateLimit{Requests: 2, Window: time.Minute}}
+
+	subscription := &mocks.Subscription{}
+	subscription.On("Unsubscribe").Return(nil)
+	subscriber := &mocks.MessageSubscriber{}
+	subscriber.On("SubscribeUser", mock.Anything, testdata.Alice.UserID, mock.Anything, mock.Anything).Return(subscription, nil)
+
+	gateway := NewWebSocketGateway(WebSocketConfig{}, CORSConfig{}, rateLimit, subscriber, limiter, testutils.NewTestLogger(t))
+	gateway.HandleCommand("send_message", sendRoute, func(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+		return map[string]string{"status": "sent"}, nil
+	})
+
+	server := newRateLimitedServer(t, rateLimit, limiter, sendRoute, gateway.Route())
+	httpServer := httptest.NewServer(server.mux)
+	defer httpServer.Close()
+	defer gateway.Close()
+
+	header := http.Header{}
+	header.Set("X-User-ID", testdata.Alice.UserID)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http")+WebSocketPath, header)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	send := func(requestID string) WebSocketFrame {
+		require.NoError(t, conn.WriteJSON(WebSocketFrame{Type: "send_message", RequestID: requestID, Data: json.RawMessage(`{}`)}))
+		var reply WebSocketFrame
+		require.NoError(t, conn.ReadJSON(&reply))
+		require.Equal(t, requestID, reply.RequestID)
+		return reply
+	}
+
+	// The socket gets the same two sends the HTTP route allows
+	assert.Equal(t, FrameTypeAck, send("1").Type)
+	assert.Equal(t, FrameTypeAck, send("2").Type)
+
+	reply := send("3")
+	assert.Equal(t, FrameTypeError, reply.Type)
+	require.NotNil(t, reply.Error)
+	assert.Equal(t, "RATE_LIMITED", reply.Error.Code)
+	assert.Equal(t, "retry in 30 seconds", reply.Error.Details)
+
+	// Both transports draw from one bucket
+	recorder := serve(server, "POST", "/send", testdata.Alice.UserID, "")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+}
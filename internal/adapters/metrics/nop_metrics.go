@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+)
+
+// NopMetrics discards every measurement, for when metrics are disabled
+type NopMetrics struct{}
+
+func NewNopMetrics() NopMetrics {
+	return NopMetrics{}
+}
+
+// ObserveHTTPRequest implements ports.Metrics
+func (NopMetrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {}
+
+// MessageSent implements ports.Metrics
+func (NopMetrics) MessageSent(chatType string) {}
+
+// MessagesRead implements ports.Metrics
+func (NopMetrics) MessagesRead(count int64) {}
+
+// PublishFailed implements ports.Metrics
+func (NopMetrics) PublishFailed(event string) {}
+
+// ObserveQuery implements ports.Metrics
+func (NopMetrics) ObserveQuery(operation string, duration time.Duration, err error) {}
+
+// Handler implements ports.Metrics
+func (NopMetrics) Handler() http.Handler {
+	return http.NotFoundHandler()
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "messaging"
+
+// PrometheusMetrics collects metrics in its own registry, exposed in the Prometheus text format
+type PrometheusMetrics struct {
+	registry        *prometheus.Registry
+	httpRequests    *prometheus.HistogramVec
+	messagesSent    *prometheus.CounterVec
+	messagesRead    prometheus.Counter
+	publishFailures *prometheus.CounterVec
+	queries         *prometheus.HistogramVec
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	m := &PrometheusMetrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests by method, route pattern and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		messagesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_sent_total",
+			Help:      "Messages stored, by chat type.",
+		}, []string{"chat_type"}),
+		messagesRead: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_read_total",
+			Help:      "Messages marked as read.",
+		}),
+		publishFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "nats",
+			Name:      "publish_failures_total",
+			Help:      "Real-time events that could not be published, by event.",
+		}, []string{"event"}),
+		queries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of repository operations by operation and result.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.messagesSent,
+		m.messagesRead,
+		m.publishFailures,
+		m.queries,
+	)
+
+	return m
+}
+
+// RegisterDatabase exposes the connection pool statistics of db
+func (m *PrometheusMetrics) RegisterDatabase(db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// RegisterNATSConnection exposes how many times conn reconnected to the server
+func (m *PrometheusMetrics) RegisterNATSConnection(conn *nats.Conn) {
+	m.registry.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "nats",
+		Name:      "reconnects_total",
+		Help:      "Reconnections of the NATS connection.",
+	}, func() float64 {
+		return float64(conn.Stats().Reconnects)
+	}))
+}
+
+// ObserveHTTPRequest implements ports.Metrics
+func (m *PrometheusMetrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// MessageSent implements ports.Metrics
+func (m *PrometheusMetrics) MessageSent(chatType string) {
+	m.messagesSent.WithLabelValues(chatType).Inc()
+}
+
+// MessagesRead implements ports.Metrics
+func (m *PrometheusMetrics) MessagesRead(count int64) {
+	if count > 0 {
+		m.messagesRead.Add(float64(count))
+	}
+}
+
+// PublishFailed implements ports.Metrics
+func (m *PrometheusMetrics) PublishFailed(event string) {
+	m.publishFailures.WithLabelValues(event).Inc()
+}
+
+// ObserveQuery implements ports.Metrics
+func (m *PrometheusMetrics) ObserveQuery(operation string, duration time.Duration, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.queries.WithLabelValues(operation, result).Observe(duration.Seconds())
+}
+
+// Handler implements ports.Metrics
+func (m *PrometheusMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *PrometheusMetrics) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, recorder.Code)
+
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics()
+
+	m.ObserveHTTPRequest("GET", "/api/v1/chats/{chatId}/messages", 200, 30*time.Millisecond)
+	m.ObserveHTTPRequest("GET", "/api/v1/chats/{chatId}/messages", 200, 70*time.Millisecond)
+	m.MessageSent("direct")
+	m.MessageSent("group")
+	m.MessageSent("group")
+	m.MessagesRead(3)
+	m.MessagesRead(0)
+	m.PublishFailed("new_message")
+	m.ObserveQuery("save_message", 2*time.Millisecond, nil)
+	m.ObserveQuery("save_message", 2*time.Millisecond, errors.New("connection refused"))
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `messaging_http_request_duration_seconds_count{method="GET",route="/api/v1/chats/{chatId}/messages",status="200"} 2`)
+	assert.Contains(t, body, `messaging_http_request_duration_seconds_sum{method="GET",route="/api/v1/chats/{chatId}/messages",status="200"} 0.1`)
+	assert.Contains(t, body, `messaging_messages_sent_total{chat_type="direct"} 1`)
+	assert.Contains(t, body, `messaging_messages_sent_total{chat_type="group"} 2`)
+	assert.Contains(t, body, `messaging_messages_read_total 3`)
+	assert.Contains(t, body, `messaging_nats_publish_failures_total{event="new_message"} 1`)
+	assert.Contains(t, body, `messaging_db_query_duration_seconds_count{operation="save_message",result="ok"} 1`)
+	assert.Contains(t, body, `messaging_db_query_duration_seconds_count{operation="save_message",result="error"} 1`)
+
+	// Runtime metrics come with the registry
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestPrometheusMetrics_DatabasePool(t *testing.T) {
+	// Opening does not connect, the pool statistics are read from the handle
+	db, err := sql.Open("postgres", "postgres://localhost/messaging?sslmode=disable")
+	require.NoError(t, err)
+	defer db.Close()
+
+	m := NewPrometheusMetrics()
+	m.RegisterDatabase(db)
+
+	assert.Contains(t, scrape(t, m), `go_sql_open_connections{db_name="messaging"} 0`)
+}
@@ -17,6 +17,11 @@ type Config struct {
 	RequestTimeout  time.Duration
 	EnableJetStream bool
 	ClusterName     string
+
+	// JetStream stream holding messages.> and status.> when EnableJetStream is set
+	StreamName      string
+	StreamMaxAge    time.Duration
+	DuplicateWindow time.Duration
 }
 
 func NewConnection(config Config, logger ports.Logger) (*nats.Conn, error) {
@@ -59,5 +64,8 @@ func DefaultConfig() Config {
 		RequestTimeout:  10 * time.Second,
 		EnableJetStream: false,
 		ClusterName:     "",
+		StreamName:      "MESSAGING",
+		StreamMaxAge:    7 * 24 * time.Hour,
+		DuplicateWindow: 2 * time.Minute,
 	}
 }
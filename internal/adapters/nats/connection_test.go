@@ -8,14 +8,18 @@ import (
 	"testing"
 	"time"
 
+	"messaging-app/internal/adapters/metrics"
 	"messaging-app/internal/adapters/nats"
 	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
 	"messaging-app/internal/ports"
 	"messaging-app/internal/testutils"
 	"messaging-app/testdata"
 
 	natsgo "github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type TestSuite struct {
@@ -31,7 +35,7 @@ func (s *TestSuite) TearDownTest() {
 func (s *TestSuite) SetupSuite() {
 	conn := setupTestNATS(s.T())
 	s.conn = conn
-	s.publisher = nats.NewNATSMessagePublisher(conn, testutils.NewTestLogger(s.T()))
+	s.publisher = nats.NewNATSMessagePublisher(conn, metrics.NewNopMetrics(), testutils.NewTestLogger(s.T()))
 }
 
 func (s *TestSuite) TearDownSuite() {
@@ -165,6 +169,67 @@ func (s *TestSuite) TestPublishMessage() {
 	}
 }
 
+func (s *TestSuite) TestPublishFailureCounted() {
+	conn := setupTestNATS(s.T())
+	conn.Close()
+
+	metrics := mocks.NewMetrics(s.T())
+	metrics.On("PublishFailed", string(domain.MessageTypeNewMessage)).Once()
+	publisher := nats.NewNATSMessagePublisher(conn, metrics, testutils.NewTestLogger(s.T()))
+
+	err := publisher.PublishMessage(context.Background(), testdata.ValidMessages()[0])
+	s.ErrorIs(err, natsgo.ErrConnectionClosed)
+}
+
+func (s *TestSuite) TestPublishPropagatesTraceContext() {
+	spans := testutils.NewTestTracer(s.T())
+	ctx, request := otel.Tracer("test").Start(context.Background(), "request")
+	message := testdata.ValidMessages()[0]
+
+	received := make(chan natsgo.Header, 1)
+	sub, err := s.conn.Subscribe(domain.GetMessageTopic(message.ReceiverID), func(msg *natsgo.Msg) {
+		received <- msg.Header
+	})
+	s.Require().NoError(err)
+	defer sub.Unsubscribe()
+
+	delivered := make(chan struct{}, 1)
+	subscriber := nats.NewNATSMessageSubscriber(s.conn, testutils.NewTestLogger(s.T()))
+	subscription, err := subscriber.SubscribeUser(ctx, message.ReceiverID, ports.SubscribeOptions{}, func(event ports.Event) {
+		delivered <- struct{}{}
+	})
+	s.Require().NoError(err)
+	defer subscription.Unsubscribe()
+
+	s.Require().NoError(s.publisher.PublishMessage(ctx, message))
+	request.End()
+
+	select {
+	case header := <-received:
+		s.Contains(header.Get("traceparent"), request.SpanContext().TraceID().String())
+	case <-time.After(2 * time.Second):
+		s.FailNow("timeout waiting for published message")
+	}
+	select {
+	case <-delivered:
+	case <-time.After(2 * time.Second):
+		s.FailNow("timeout waiting for delivered message")
+	}
+
+	// Subscribers continue the trace from the publish span
+	s.Eventually(func() bool { return len(spans.GetSpans()) == 3 }, time.Second, 10*time.Millisecond)
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range spans.GetSpans().Snapshots() {
+		byName[span.Name()] = span
+	}
+	publish, deliver := byName["publish new_message"], byName["deliver messages"]
+	s.Require().NotNil(publish)
+	s.Require().NotNil(deliver)
+	s.Equal(request.SpanContext().SpanID(), publish.Parent().SpanID())
+	s.Equal(publish.SpanContext().SpanID(), deliver.Parent().SpanID())
+	s.Equal(request.SpanContext().TraceID(), deliver.SpanContext().TraceID())
+}
+
 func (s *TestSuite) TestPublishStatusUpdate() {
 	ctx := context.Background()
 
@@ -236,3 +301,16 @@ func (s *TestSuite) TestPublishStatusUpdate() {
 		s.FailNow("timeout waiting for published status update")
 	}
 }
+
+func TestConnectionHealthCheckerIntegration(t *testing.T) {
+	conn := setupTestNATS(t)
+	checker := nats.NewConnectionHealthChecker(conn)
+	if err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("expected connection to be healthy: %v", err)
+	}
+
+	conn.Close()
+	if err := checker.Check(context.Background()); err == nil {
+		t.Fatalf("expected closed connection to be unhealthy")
+	}
+}
@@ -0,0 +1,31 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ConnectionHealthChecker checks that the NATS connection is established
+type ConnectionHealthChecker struct {
+	conn *nats.Conn
+}
+
+func NewConnectionHealthChecker(conn *nats.Conn) *ConnectionHealthChecker {
+	return &ConnectionHealthChecker{conn: conn}
+}
+
+// Name implements ports.HealthChecker
+func (c *ConnectionHealthChecker) Name() string {
+	return "nats"
+}
+
+// Check implements ports.HealthChecker
+// The status is kept up to date by the client, so checking it makes no round trip
+func (c *ConnectionHealthChecker) Check(ctx context.Context) error {
+	if status := c.conn.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("connection is %s", status)
+	}
+	return nil
+}
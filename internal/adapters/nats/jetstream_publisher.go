@@ -0,0 +1,300 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// NATSJetStreamPublisher persists every event in a JetStream stream so offline users can catch up
+type NATSJetStreamPublisher struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	metrics ports.Metrics
+	logger  ports.Logger
+}
+
+func NewNATSJetStreamPublisher(ctx context.Context, conn *nats.Conn, config Config, metrics ports.Metrics, logger ports.Logger) (*NATSJetStreamPublisher, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	if err := EnsureStream(ctx, js, config, logger); err != nil {
+		return nil, err
+	}
+
+	return &NATSJetStreamPublisher{
+		conn:    conn,
+		js:      js,
+		metrics: metrics,
+		logger:  logger,
+	}, nil
+}
+
+// EnsureStream creates or updates the stream capturing messages.> and status.>
+func EnsureStream(ctx context.Context, js jetstream.JetStream, config Config, logger ports.Logger) error {
+	stream, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name: config.StreamName,
+		Subjects: []string{
+			domain.MessageTopicPrefix + ".>",
+			domain.StatusTopicPrefix + ".>",
+		},
+		Storage:    jetstream.FileStorage,
+		Retention:  jetstream.LimitsPolicy,
+		MaxAge:     config.StreamMaxAge,
+		Duplicates: config.DuplicateWindow,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create stream %s: %w", config.StreamName, err)
+	}
+
+	logger.Info("JetStream stream ready",
+		"stream", stream.CachedInfo().Config.Name,
+		"max_age", config.StreamMaxAge,
+		"duplicate_window", config.DuplicateWindow,
+	)
+
+	return nil
+}
+
+// PublishMessage implements ports.MessagePublisher
+func (p *NATSJetStreamPublisher) PublishMessage(ctx context.Context, message domain.Message) error {
+	subject := domain.GetMessageTopic(message.ReceiverID)
+
+	payload, err := marshalMessageEnvelope(message)
+	if err != nil {
+		return err
+	}
+
+	ack, err := p.publish(ctx, domain.MessageTypeNewMessage, subject, payload, messageDedupID(subject, message.Key()))
+	if err != nil {
+		return err
+	}
+
+	p.logger.Debug("Message published to JetStream",
+		"subject", subject,
+		"sender", message.SenderID,
+		"receiver", message.ReceiverID,
+		"sequence", ack.Sequence,
+		"duplicate", ack.Duplicate,
+	)
+
+	return nil
+}
+
+// PublishGroupMessage implements ports.MessagePublisher
+func (p *NATSJetStreamPublisher) PublishGroupMessage(ctx context.Context, message domain.Message, memberIDs []string) error {
+	payload, err := marshalMessageEnvelope(message)
+	if err != nil {
+		return err
+	}
+
+	// The dedup ID includes the subject, so each member keeps their own copy
+	var errs []error
+	for _, memberID := range memberIDs {
+		if memberID == message.SenderID {
+			continue
+		}
+
+		subject := domain.GetMessageTopic(memberID)
+		if _, err := p.publish(ctx, domain.MessageTypeNewMessage, subject, payload, messageDedupID(subject, message.Key())); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.logger.Debug("Group message published to JetStream",
+		"chat_id", message.ReceiverID,
+		"sender", message.SenderID,
+		"members", len(memberIDs),
+	)
+
+	return nil
+}
+
+// PublishMessageUpdate implements ports.MessagePublisher
+func (p *NATSJetStreamPublisher) PublishMessageUpdate(ctx context.Context, eventType domain.MessageType, message domain.Message, userIDs []string) error {
+	payload, err := marshalMessageUpdateEnvelope(eventType, message)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		subject := domain.GetMessageTopic(userID)
+		if _, err := p.publish(ctx, eventType, subject, payload, messageUpdateDedupID(subject, eventType, message)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.logger.Debug("Message update published to JetStream",
+		"type", eventType,
+		"message_id", message.ID,
+		"recipients", len(userIDs),
+	)
+
+	return nil
+}
+
+// PublishReactionUpdate implements ports.MessagePublisher
+func (p *NATSJetStreamPublisher) PublishReactionUpdate(ctx context.Context, update domain.ReactionUpdate, userIDs []string) error {
+	payload, err := marshalReactionEnvelope(update)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		subject := domain.GetMessageTopic(userID)
+		if _, err := p.publish(ctx, domain.MessageTypeReactionUpdated, subject, payload, reactionDedupID(subject, update)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.logger.Debug("Reaction update published to JetStream",
+		"message_id", update.MessageID,
+		"user", update.UserID,
+		"action", update.Action,
+		"recipients", len(userIDs),
+	)
+
+	return nil
+}
+
+// PublishPresence implements ports.MessagePublisher
+func (p *NATSJetStreamPublisher) PublishPresence(ctx context.Context, presence domain.Presence, userIDs []string) error {
+	payload, err := marshalPresenceEnvelope(presence)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		subject := domain.GetStatusTopic(userID)
+		if _, err := p.publish(ctx, domain.MessageTypePresenceUpdate, subject, payload, presenceDedupID(subject, presence)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.logger.Debug("Presence published to JetStream",
+		"user", presence.UserID,
+		"status", presence.Status,
+		"recipients", len(userIDs),
+	)
+
+	return nil
+}
+
+// PublishStatusUpdate implements ports.MessagePublisher
+func (p *NATSJetStreamPublisher) PublishStatusUpdate(ctx context.Context, userID string, statusUpdate ports.StatusUpdate) error {
+	subject := domain.GetStatusTopic(userID)
+
+	payload, err := marshalStatusEnvelope(statusUpdate)
+	if err != nil {
+		return err
+	}
+
+	ack, err := p.publish(ctx, domain.MessageTypeStatusUpdate, subject, payload, statusDedupID(subject, statusUpdate))
+	if err != nil {
+		return err
+	}
+
+	p.logger.Debug("Status update published to JetStream",
+		"subject", subject,
+		"user", userID,
+		"status", statusUpdate.Status,
+		"sequence", ack.Sequence,
+	)
+
+	return nil
+}
+
+// Close implements ports.MessagePublisher
+func (p *NATSJetStreamPublisher) Close() error {
+	if p.conn != nil {
+		p.conn.Close()
+		p.logger.Info("NATS connection closed")
+	}
+	return nil
+}
+
+// publish stores payload in the stream in a producer span whose context travels in the message headers
+func (p *NATSJetStreamPublisher) publish(ctx context.Context, eventType domain.MessageType, subject string, payload []byte, dedupID string) (*jetstream.PubAck, error) {
+	ctx, span := startPublishSpan(ctx, eventType, subject)
+	defer span.End()
+
+	msg := &nats.Msg{Subject: subject, Data: payload, Header: nats.Header{}}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(msg.Header))
+
+	ack, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(dedupID))
+	if err != nil {
+		p.metrics.PublishFailed(string(eventType))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to publish to subject %s: %w", subject, err)
+	}
+	return ack, nil
+}
+
+// messageDedupID identifies a message delivery so retried publishes are stored once
+func messageDedupID(subject string, id domain.MessageID) string {
+	return subject + "|" + id.String()
+}
+
+// messageUpdateDedupID identifies an edit or deletion delivery; successive edits of a message stay distinct
+func messageUpdateDedupID(subject string, eventType domain.MessageType, message domain.Message) string {
+	var changedAt time.Time
+	switch {
+	case message.DeletedAt != nil:
+		changedAt = *message.DeletedAt
+	case message.EditedAt != nil:
+		changedAt = *message.EditedAt
+	}
+	return fmt.Sprintf("%s|%s|%s|%d", subject, message.Key().String(), eventType, changedAt.UnixNano())
+}
+
+// reactionDedupID identifies a reaction change delivery so retried publishes are stored once
+func reactionDedupID(subject string, update domain.ReactionUpdate) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%d", subject, update.MessageID, update.UserID, update.Action, update.UpdatedAt.UnixNano())
+}
+
+// presenceDedupID identifies a presence change delivery so retried publishes are stored once
+func presenceDedupID(subject string, presence domain.Presence) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%d", subject, presence.UserID, presence.Status, presence.ChatID, presence.LastSeen.UnixNano())
+}
+
+// statusDedupID identifies a status change delivery so retried publishes are stored once
+// Receipts from different group members for the same message stay distinct
+func statusDedupID(subject string, statusUpdate ports.StatusUpdate) string {
+	id := fmt.Sprintf("%s|%s|%s|%s|%d", subject, statusUpdate.MessageID, statusUpdate.Status, statusUpdate.UpdatedBy, statusUpdate.UpdatedAt.UnixNano())
+	if statusUpdate.Range != nil {
+		id += fmt.Sprintf("|%d|%d", statusUpdate.Range.From.UnixNano(), statusUpdate.Range.To.UnixNano())
+	}
+	return id
+}
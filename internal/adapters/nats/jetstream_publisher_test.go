@@ -0,0 +1,128 @@
+package nats_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"messaging-app/internal/adapters/metrics"
+	"messaging-app/internal/adapters/nats"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/ports"
+	"messaging-app/internal/testutils"
+	"messaging-app/testdata"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func (s *TestSuite) TestJetStreamPublishAndResume() {
+	ctx := context.Background()
+	logger := testutils.NewTestLogger(s.T())
+
+	cfg := nats.DefaultConfig()
+	cfg.StreamName = "MESSAGING_TEST"
+
+	publisher, err := nats.NewNATSJetStreamPublisher(ctx, s.conn, cfg, metrics.NewNopMetrics(), logger)
+	s.Require().NoError(err)
+
+	js, err := jetstream.New(s.conn)
+	s.Require().NoError(err)
+	defer js.DeleteStream(ctx, cfg.StreamName)
+
+	subscriber, err := nats.NewNATSJetStreamSubscriber(s.conn, cfg, logger)
+	s.Require().NoError(err)
+
+	testMessage := testdata.ValidMessages()[0] // Alice to Bob message
+
+	// Publishing the same message twice is stored once
+	s.Require().NoError(publisher.PublishMessage(ctx, testMessage))
+	s.Require().NoError(publisher.PublishMessage(ctx, testMessage))
+
+	second := testMessage
+	second.CreatedAt = testMessage.CreatedAt.Add(time.Second)
+	second.Content = "Second message"
+	s.Require().NoError(publisher.PublishMessage(ctx, second))
+
+	stream, err := js.Stream(ctx, cfg.StreamName)
+	s.Require().NoError(err)
+	info, err := stream.Info(ctx)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), info.State.Msgs)
+
+	// Resuming after the first event replays only the second one
+	received := make(chan ports.Event, 2)
+	sub, err := subscriber.SubscribeUser(ctx, testdata.Bob.UserID, ports.SubscribeOptions{ResumeAfter: info.State.FirstSeq}, func(event ports.Event) {
+		received <- event
+	})
+	s.Require().NoError(err)
+	defer sub.Unsubscribe()
+
+	select {
+	case event := <-received:
+		var envelope domain.MessageEnvelope
+		s.Require().NoError(json.Unmarshal(event.Payload, &envelope))
+		s.Equal(info.State.LastSeq, event.Sequence)
+		s.Equal(second.Content, envelope.Data.Content)
+	case <-time.After(2 * time.Second):
+		s.FailNow("timeout waiting for replayed message")
+	}
+}
+
+func (s *TestSuite) TestJetStreamKeepsReceiptsFromEachReader() {
+	ctx := context.Background()
+	logger := testutils.NewTestLogger(s.T())
+
+	cfg := nats.DefaultConfig()
+	cfg.StreamName = "MESSAGING_RECEIPTS_TEST"
+
+	publisher, err := nats.NewNATSJetStreamPublisher(ctx, s.conn, cfg, metrics.NewNopMetrics(), logger)
+	s.Require().NoError(err)
+
+	js, err := jetstream.New(s.conn)
+	s.Require().NoError(err)
+	defer js.DeleteStream(ctx, cfg.StreamName)
+
+	// Bob and Charlie read the same group message; both receipts go to Alice
+	message := domain.Message{ID: domain.NewMessageID(), SenderID: testdata.Alice.UserID, CreatedAt: time.Now().UTC()}
+	readAt := time.Now().UTC()
+	for _, reader := range []string{testdata.Bob.UserID, testdata.Charlie.UserID} {
+		statusUpdate := ports.StatusUpdate{
+			MessageID: message.Key(),
+			Status:    domain.MessageStatusRead,
+			UpdatedBy: reader,
+			UpdatedAt: readAt,
+			Range:     &domain.MessageRange{From: message.CreatedAt, To: message.CreatedAt, Count: 1},
+		}
+		s.Require().NoError(publisher.PublishStatusUpdate(ctx, testdata.Alice.UserID, statusUpdate))
+
+		// A retried publish of the same receipt is still stored once
+		s.Require().NoError(publisher.PublishStatusUpdate(ctx, testdata.Alice.UserID, statusUpdate))
+	}
+
+	stream, err := js.Stream(ctx, cfg.StreamName)
+	s.Require().NoError(err)
+	info, err := stream.Info(ctx)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), info.State.Msgs)
+}
+
+func (s *TestSuite) TestJetStreamPublishFailureCounted() {
+	ctx := context.Background()
+	conn := setupTestNATS(s.T())
+
+	cfg := nats.DefaultConfig()
+	cfg.StreamName = "MESSAGING_FAILURE_TEST"
+
+	metrics := mocks.NewMetrics(s.T())
+	metrics.On("PublishFailed", string(domain.MessageTypeNewMessage)).Once()
+	publisher, err := nats.NewNATSJetStreamPublisher(ctx, conn, cfg, metrics, testutils.NewTestLogger(s.T()))
+	s.Require().NoError(err)
+
+	js, err := jetstream.New(s.conn)
+	s.Require().NoError(err)
+	defer js.DeleteStream(ctx, cfg.StreamName)
+
+	conn.Close()
+	s.Error(publisher.PublishMessage(ctx, testdata.ValidMessages()[0]))
+}
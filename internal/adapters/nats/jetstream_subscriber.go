@@ -0,0 +1,83 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// NATSJetStreamSubscriber reads user events from the JetStream stream and can replay missed ones
+type NATSJetStreamSubscriber struct {
+	js     jetstream.JetStream
+	stream string
+	logger ports.Logger
+}
+
+func NewNATSJetStreamSubscriber(conn *nats.Conn, config Config, logger ports.Logger) (*NATSJetStreamSubscriber, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	return &NATSJetStreamSubscriber{
+		js:     js,
+		stream: config.StreamName,
+		logger: logger,
+	}, nil
+}
+
+// SubscribeUser implements ports.MessageSubscriber
+func (s *NATSJetStreamSubscriber) SubscribeUser(ctx context.Context, userID string, opts ports.SubscribeOptions, handler func(event ports.Event)) (ports.Subscription, error) {
+	config := jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{
+			domain.GetMessageTopic(userID),
+			domain.GetStatusTopic(userID),
+		},
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+	}
+	if opts.ResumeAfter > 0 {
+		config.DeliverPolicy = jetstream.DeliverByStartSequencePolicy
+		config.OptStartSeq = opts.ResumeAfter + 1
+	}
+
+	consumer, err := s.js.OrderedConsumer(ctx, s.stream, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer for user %s: %w", userID, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		_, span := startDeliverSpan(msg.Headers(), msg.Subject())
+		defer span.End()
+
+		event := ports.Event{Payload: msg.Data()}
+		if metadata, err := msg.Metadata(); err == nil {
+			event.Sequence = metadata.Sequence.Stream
+		}
+		handler(event)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume events for user %s: %w", userID, err)
+	}
+
+	s.logger.Debug("Subscribed user to JetStream",
+		"user", userID,
+		"stream", s.stream,
+		"resume_after", opts.ResumeAfter,
+	)
+
+	return &jetStreamSubscription{consumeCtx: consumeCtx}, nil
+}
+
+type jetStreamSubscription struct {
+	consumeCtx jetstream.ConsumeContext
+}
+
+func (s *jetStreamSubscription) Unsubscribe() error {
+	s.consumeCtx.Stop()
+	return nil
+}
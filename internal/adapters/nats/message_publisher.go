@@ -3,24 +3,30 @@ package nats
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 
 	"messaging-app/internal/domain"
 	"messaging-app/internal/ports"
 )
 
 type NATSMessagePublisher struct {
-	conn   *nats.Conn
-	logger ports.Logger
+	conn    *nats.Conn
+	metrics ports.Metrics
+	logger  ports.Logger
 }
 
-func NewNATSMessagePublisher(conn *nats.Conn, logger ports.Logger) *NATSMessagePublisher {
+func NewNATSMessagePublisher(conn *nats.Conn, metrics ports.Metrics, logger ports.Logger) *NATSMessagePublisher {
 	return &NATSMessagePublisher{
-		conn:   conn,
-		logger: logger,
+		conn:    conn,
+		metrics: metrics,
+		logger:  logger,
 	}
 }
 
@@ -28,19 +34,12 @@ func NewNATSMessagePublisher(conn *nats.Conn, logger ports.Logger) *NATSMessageP
 func (p *NATSMessagePublisher) PublishMessage(ctx context.Context, message domain.Message) error {
 	subject := domain.GetMessageTopic(message.ReceiverID)
 
-	// Create message envelope with metadata
-	envelope := domain.MessageEnvelope{
-		Type:      domain.MessageTypeNewMessage,
-		Timestamp: time.Now().UTC(),
-		Data:      message,
-	}
-
-	payload, err := json.Marshal(envelope)
+	payload, err := marshalMessageEnvelope(message)
 	if err != nil {
-		return fmt.Errorf("failed to marshal message: %w", err)
+		return err
 	}
 
-	if err := p.conn.Publish(subject, payload); err != nil {
+	if err := p.publish(ctx, domain.MessageTypeNewMessage, subject, payload); err != nil {
 		return fmt.Errorf("failed to publish message to subject %s: %w", subject, err)
 	}
 
@@ -53,23 +52,134 @@ func (p *NATSMessagePublisher) PublishMessage(ctx context.Context, message domai
 	return nil
 }
 
+// PublishGroupMessage implements ports.MessagePublisher
+func (p *NATSMessagePublisher) PublishGroupMessage(ctx context.Context, message domain.Message, memberIDs []string) error {
+	payload, err := marshalMessageEnvelope(message)
+	if err != nil {
+		return err
+	}
+
+	// Publish to every member individually so each keeps a single inbox subject
+	var errs []error
+	for _, memberID := range memberIDs {
+		if memberID == message.SenderID {
+			continue
+		}
+
+		subject := domain.GetMessageTopic(memberID)
+		if err := p.publish(ctx, domain.MessageTypeNewMessage, subject, payload); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish message to subject %s: %w", subject, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.logger.Debug("Group message published to NATS",
+		"chat_id", message.ReceiverID,
+		"sender", message.SenderID,
+		"members", len(memberIDs),
+	)
+
+	return nil
+}
+
+// PublishMessageUpdate implements ports.MessagePublisher
+func (p *NATSMessagePublisher) PublishMessageUpdate(ctx context.Context, eventType domain.MessageType, message domain.Message, userIDs []string) error {
+	payload, err := marshalMessageUpdateEnvelope(eventType, message)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		subject := domain.GetMessageTopic(userID)
+		if err := p.publish(ctx, eventType, subject, payload); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish %s to subject %s: %w", eventType, subject, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.logger.Debug("Message update published to NATS",
+		"type", eventType,
+		"message_id", message.ID,
+		"recipients", len(userIDs),
+	)
+
+	return nil
+}
+
+// PublishReactionUpdate implements ports.MessagePublisher
+func (p *NATSMessagePublisher) PublishReactionUpdate(ctx context.Context, update domain.ReactionUpdate, userIDs []string) error {
+	payload, err := marshalReactionEnvelope(update)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		subject := domain.GetMessageTopic(userID)
+		if err := p.publish(ctx, domain.MessageTypeReactionUpdated, subject, payload); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish reaction update to subject %s: %w", subject, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.logger.Debug("Reaction update published to NATS",
+		"message_id", update.MessageID,
+		"user", update.UserID,
+		"action", update.Action,
+		"recipients", len(userIDs),
+	)
+
+	return nil
+}
+
+// PublishPresence implements ports.MessagePublisher
+func (p *NATSMessagePublisher) PublishPresence(ctx context.Context, presence domain.Presence, userIDs []string) error {
+	payload, err := marshalPresenceEnvelope(presence)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		subject := domain.GetStatusTopic(userID)
+		if err := p.publish(ctx, domain.MessageTypePresenceUpdate, subject, payload); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish presence to subject %s: %w", subject, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.logger.Debug("Presence published to NATS",
+		"user", presence.UserID,
+		"status", presence.Status,
+		"recipients", len(userIDs),
+	)
+
+	return nil
+}
+
 // PublishStatusUpdate implements ports.MessagePublisher
 func (p *NATSMessagePublisher) PublishStatusUpdate(ctx context.Context, userID string, statusUpdate ports.StatusUpdate) error {
 	subject := domain.GetStatusTopic(userID)
 
-	// Create status update envelope
-	envelope := domain.StatusUpdateEnvelope{
-		Type:      domain.MessageTypeStatusUpdate,
-		Timestamp: time.Now().UTC(),
-		Data:      statusUpdate,
-	}
-
-	payload, err := json.Marshal(envelope)
+	payload, err := marshalStatusEnvelope(statusUpdate)
 	if err != nil {
-		return fmt.Errorf("failed to marshal status update: %w", err)
+		return err
 	}
 
-	if err := p.conn.Publish(subject, payload); err != nil {
+	if err := p.publish(ctx, domain.MessageTypeStatusUpdate, subject, payload); err != nil {
 		return fmt.Errorf("failed to publish status update to subject %s: %w", subject, err)
 	}
 
@@ -91,3 +201,85 @@ func (p *NATSMessagePublisher) Close() error {
 	return nil
 }
 
+// publish sends payload to subject in a producer span whose context travels in the message headers,
+// so subscribers continue the trace; failures are counted by event type
+func (p *NATSMessagePublisher) publish(ctx context.Context, eventType domain.MessageType, subject string, payload []byte) error {
+	ctx, span := startPublishSpan(ctx, eventType, subject)
+	defer span.End()
+
+	msg := &nats.Msg{Subject: subject, Data: payload, Header: nats.Header{}}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(msg.Header))
+
+	if err := p.conn.PublishMsg(msg); err != nil {
+		p.metrics.PublishFailed(string(eventType))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// marshalMessageEnvelope wraps a message with its envelope metadata
+func marshalMessageEnvelope(message domain.Message) ([]byte, error) {
+	return marshalMessageUpdateEnvelope(domain.MessageTypeNewMessage, message)
+}
+
+// marshalMessageUpdateEnvelope wraps a message with the envelope of the given event type
+func marshalMessageUpdateEnvelope(eventType domain.MessageType, message domain.Message) ([]byte, error) {
+	envelope := domain.MessageEnvelope{
+		Type:      eventType,
+		Timestamp: time.Now().UTC(),
+		Data:      message,
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return payload, nil
+}
+
+// marshalReactionEnvelope wraps a reaction update with its envelope metadata
+func marshalReactionEnvelope(update domain.ReactionUpdate) ([]byte, error) {
+	envelope := domain.ReactionUpdateEnvelope{
+		Type:      domain.MessageTypeReactionUpdated,
+		Timestamp: time.Now().UTC(),
+		Data:      update,
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reaction update: %w", err)
+	}
+	return payload, nil
+}
+
+// marshalPresenceEnvelope wraps a presence change with its envelope metadata
+func marshalPresenceEnvelope(presence domain.Presence) ([]byte, error) {
+	envelope := domain.StatusUpdateEnvelope{
+		Type:      domain.MessageTypePresenceUpdate,
+		Timestamp: time.Now().UTC(),
+		Data:      presence,
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal presence: %w", err)
+	}
+	return payload, nil
+}
+
+// marshalStatusEnvelope wraps a status update with its envelope metadata
+func marshalStatusEnvelope(statusUpdate ports.StatusUpdate) ([]byte, error) {
+	envelope := domain.StatusUpdateEnvelope{
+		Type:      domain.MessageTypeStatusUpdate,
+		Timestamp: time.Now().UTC(),
+		Data:      statusUpdate,
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal status update: %w", err)
+	}
+	return payload, nil
+}
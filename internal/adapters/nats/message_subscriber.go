@@ -0,0 +1,73 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+type NATSMessageSubscriber struct {
+	conn   *nats.Conn
+	logger ports.Logger
+}
+
+func NewNATSMessageSubscriber(conn *nats.Conn, logger ports.Logger) *NATSMessageSubscriber {
+	return &NATSMessageSubscriber{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// SubscribeUser implements ports.MessageSubscriber
+// Core NATS keeps no history, so opts.ResumeAfter cannot be honoured
+func (s *NATSMessageSubscriber) SubscribeUser(ctx context.Context, userID string, opts ports.SubscribeOptions, handler func(event ports.Event)) (ports.Subscription, error) {
+	subjects := []string{
+		domain.GetMessageTopic(userID),
+		domain.GetStatusTopic(userID),
+	}
+
+	subscription := &natsSubscription{}
+	for _, subject := range subjects {
+		sub, err := s.conn.Subscribe(subject, func(msg *nats.Msg) {
+			_, span := startDeliverSpan(msg.Header, msg.Subject)
+			defer span.End()
+
+			handler(ports.Event{Payload: msg.Data})
+		})
+		if err != nil {
+			subscription.Unsubscribe()
+			return nil, fmt.Errorf("failed to subscribe to subject %s: %w", subject, err)
+		}
+		subscription.subs = append(subscription.subs, sub)
+	}
+
+	// Make sure the server registered the interest before we report success
+	if err := s.conn.Flush(); err != nil {
+		subscription.Unsubscribe()
+		return nil, fmt.Errorf("failed to flush subscriptions: %w", err)
+	}
+
+	s.logger.Debug("Subscribed user to NATS", "user", userID, "subjects", subjects)
+	return subscription, nil
+}
+
+// natsSubscription groups the per-subject subscriptions of a user
+type natsSubscription struct {
+	subs []*nats.Subscription
+}
+
+func (s *natsSubscription) Unsubscribe() error {
+	var errs []error
+	for _, sub := range s.subs {
+		if err := sub.Unsubscribe(); err != nil && !errors.Is(err, nats.ErrConnectionClosed) {
+			errs = append(errs, err)
+		}
+	}
+	s.subs = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,46 @@
+package nats
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"messaging-app/internal/domain"
+)
+
+const tracerName = "messaging-app/internal/adapters/nats"
+
+// startPublishSpan starts the producer span of one published event
+// Spans are named after the event type, subjects name users and would make every name unique
+func startPublishSpan(ctx context.Context, eventType domain.MessageType, subject string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "publish "+string(eventType),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("nats"),
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingDestinationName(subject),
+			attribute.String("messaging.event_type", string(eventType)),
+		),
+	)
+}
+
+// startDeliverSpan starts the consumer span of a received event, continuing the trace in its headers
+func startDeliverSpan(header nats.Header, subject string) (context.Context, trace.Span) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+	topic, _, _ := strings.Cut(subject, ".")
+
+	return otel.Tracer(tracerName).Start(ctx, "deliver "+topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("nats"),
+			semconv.MessagingOperationTypeDeliver,
+			semconv.MessagingDestinationName(subject),
+		),
+	)
+}
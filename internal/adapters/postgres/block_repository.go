@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+type PostgreSQLBlockRepository struct {
+	db     *sql.DB
+	logger ports.Logger
+}
+
+func NewPostgreSQLBlockRepository(db *sql.DB, logger ports.Logger) *PostgreSQLBlockRepository {
+	return &PostgreSQLBlockRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// BlockUser implements ports.BlockRepository
+func (r *PostgreSQLBlockRepository) BlockUser(ctx context.Context, block domain.Block) error {
+	if err := block.Validate(); err != nil {
+		return fmt.Errorf("block validation failed: %w", err)
+	}
+
+	// Blocking again keeps the original time so the block list order is stable
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`, block.BlockerID, block.BlockedID, block.CreatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	r.logger.Debug("User blocked", "blocker_id", block.BlockerID, "blocked_id", block.BlockedID)
+	return nil
+}
+
+// UnblockUser implements ports.BlockRepository
+func (r *PostgreSQLBlockRepository) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM user_blocks
+		WHERE blocker_id = $1 AND blocked_id = $2
+	`, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+
+	r.logger.Debug("User unblocked", "blocker_id", blockerID, "blocked_id", blockedID)
+	return nil
+}
+
+// GetBlockedUsers implements ports.BlockRepository
+func (r *PostgreSQLBlockRepository) GetBlockedUsers(ctx context.Context, blockerID string) ([]domain.Block, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT blocker_id, blocked_id, created_at
+		FROM user_blocks
+		WHERE blocker_id = $1
+		ORDER BY created_at DESC, blocked_id
+	`, blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blocks: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := []domain.Block{}
+	for rows.Next() {
+		var block domain.Block
+		if err := rows.Scan(&block.BlockerID, &block.BlockedID, &block.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blocks: %w", err)
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,82 @@
+package postgres_test
+
+import (
+	"context"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/testdata"
+)
+
+func (s *TestSuite) TestBlockRepositoryIntegration() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	alice, bob, eve := testdata.Alice.UserID, testdata.Bob.UserID, testdata.Eve.UserID
+
+	// Bob and Eve both wrote to Alice before she blocked Eve
+	for i, sender := range []string{bob, eve} {
+		s.Require().NoError(s.repo.SaveMessage(ctx, domain.Message{
+			ID:         domain.NewMessageID(),
+			SenderID:   sender,
+			ReceiverID: alice,
+			CreatedAt:  now.Add(time.Duration(i) * time.Second),
+			Content:    "Hi Alice",
+			Status:     domain.MessageStatusSent,
+		}))
+	}
+
+	// Blocking twice keeps a single block
+	block := domain.Block{BlockerID: alice, BlockedID: eve, CreatedAt: now}
+	s.Require().NoError(s.blockRepo.BlockUser(ctx, block))
+	s.Require().NoError(s.blockRepo.BlockUser(ctx, domain.Block{BlockerID: alice, BlockedID: eve, CreatedAt: now.Add(time.Minute)}))
+
+	blocks, err := s.blockRepo.GetBlockedUsers(ctx, alice)
+	s.Require().NoError(err)
+	s.Equal([]domain.Block{block}, blocks)
+
+	err = s.blockRepo.BlockUser(ctx, domain.Block{BlockerID: alice, BlockedID: alice, CreatedAt: now})
+	s.ErrorIs(err, domain.ErrSelfBlock)
+
+	// Direct messages are refused in both directions
+	for _, message := range []domain.Message{
+		{SenderID: eve, ReceiverID: alice, CreatedAt: now.Add(time.Minute), Content: "Let me in", Status: domain.MessageStatusSent},
+		{SenderID: alice, ReceiverID: eve, CreatedAt: now.Add(time.Minute), Content: "Go away", Status: domain.MessageStatusSent},
+	} {
+		s.ErrorIs(s.repo.SaveMessage(ctx, message), domain.ErrUserBlocked)
+	}
+
+	var stored int
+	s.Require().NoError(s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM messages").Scan(&stored))
+	s.Equal(2, stored)
+
+	// The chat with Eve is hidden from Alice but not from Eve
+	sessions, err := s.repo.GetChatSessions(ctx, alice, domain.ChatCursor{}, 10)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 1)
+	s.Equal(bob, sessions[0].OtherParticipant)
+
+	sessions, err = s.repo.GetChatSessions(ctx, eve, domain.ChatCursor{}, 10)
+	s.Require().NoError(err)
+	s.Len(sessions, 1)
+
+	// Unblocking restores both
+	s.Require().NoError(s.blockRepo.UnblockUser(ctx, alice, eve))
+	s.Require().NoError(s.blockRepo.UnblockUser(ctx, alice, eve))
+
+	blocks, err = s.blockRepo.GetBlockedUsers(ctx, alice)
+	s.Require().NoError(err)
+	s.Empty(blocks)
+
+	s.NoError(s.repo.SaveMessage(ctx, domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   eve,
+		ReceiverID: alice,
+		CreatedAt:  now.Add(2 * time.Minute),
+		Content:    "Thanks",
+		Status:     domain.MessageStatusSent,
+	}))
+
+	sessions, err = s.repo.GetChatSessions(ctx, alice, domain.ChatCursor{}, 10)
+	s.Require().NoError(err)
+	s.Len(sessions, 2)
+}
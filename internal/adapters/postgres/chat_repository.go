@@ -0,0 +1,392 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+type PostgreSQLChatRepository struct {
+	db     *sql.DB
+	logger ports.Logger
+}
+
+func NewPostgreSQLChatRepository(db *sql.DB, logger ports.Logger) *PostgreSQLChatRepository {
+	return &PostgreSQLChatRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateGroupChat implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) CreateGroupChat(ctx context.Context, chat domain.Chat) error {
+	if err := chat.Validate(); err != nil {
+		return fmt.Errorf("chat validation failed: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO chats (id, name, created_by, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, chat.ID, chat.Name, chat.CreatedBy, chat.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert chat: %w", err)
+	}
+
+	for _, member := range chat.Members {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO chat_members (chat_id, user_id, role, joined_at)
+			VALUES ($1, $2, $3, $4)
+		`, chat.ID, member.UserID, member.Role, member.JoinedAt)
+		if err != nil {
+			return fmt.Errorf("insert member %s: %w", member.UserID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Group chat created", "chat_id", chat.ID, "members", len(chat.Members))
+	return nil
+}
+
+// GetChat implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) GetChat(ctx context.Context, chatID string) (*domain.Chat, error) {
+	var chat domain.Chat
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, name, created_by, created_at
+		FROM chats
+		WHERE id = $1
+	`, chatID).Scan(&chat.ID, &chat.Name, &chat.CreatedBy, &chat.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrChatNotFound
+		}
+		return nil, fmt.Errorf("failed to get chat: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT chat_id, user_id, role, joined_at
+		FROM chat_members
+		WHERE chat_id = $1
+		ORDER BY joined_at, user_id
+	`, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member domain.ChatMember
+		if err := rows.Scan(&member.ChatID, &member.UserID, &member.Role, &member.JoinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		chat.Members = append(chat.Members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating members: %w", err)
+	}
+
+	return &chat, nil
+}
+
+// AddMember implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) AddMember(ctx context.Context, member domain.ChatMember) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO chat_members (chat_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+	`, member.ChatID, member.UserID, member.Role, member.JoinedAt)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505": // unique_violation
+				return domain.ErrMemberExists
+			case "23503": // foreign_key_violation
+				return domain.ErrChatNotFound
+			}
+		}
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+
+	r.logger.Debug("Member added", "chat_id", member.ChatID, "user_id", member.UserID)
+	return nil
+}
+
+// RemoveMember implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) RemoveMember(ctx context.Context, chatID, userID string) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM chat_members
+		WHERE chat_id = $1 AND user_id = $2
+	`, chatID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotChatMember
+	}
+
+	r.logger.Debug("Member removed", "chat_id", chatID, "user_id", userID)
+	return nil
+}
+
+// IsMember implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) IsMember(ctx context.Context, chatID, userID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM chat_members WHERE chat_id = $1 AND user_id = $2
+		)
+	`, chatID, userID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check membership: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetMemberIDs implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) GetMemberIDs(ctx context.Context, chatID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT user_id
+		FROM chat_members
+		WHERE chat_id = $1
+		ORDER BY user_id
+	`, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query members: %w", err)
+	}
+	defer rows.Close()
+
+	var memberIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		memberIDs = append(memberIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating members: %w", err)
+	}
+
+	return memberIDs, nil
+}
+
+// MarkReadUpTo implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) MarkReadUpTo(ctx context.Context, chatID, userID string, upTo time.Time, events domain.OutboxEventsFunc) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var lastReadAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT last_read_at
+		FROM chat_members
+		WHERE chat_id = $1 AND user_id = $2
+		FOR UPDATE
+	`, chatID, userID).Scan(&lastReadAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrNotChatMember
+		}
+		return 0, fmt.Errorf("get read marker: %w", err)
+	}
+
+	if lastReadAt.Valid && !upTo.After(lastReadAt.Time) {
+		return 0, nil
+	}
+
+	affected, err := scanGroupMessageRange(tx.QueryRowContext(ctx, `
+		SELECT COUNT(*), MIN(created_at), MAX(created_at), ARRAY_AGG(DISTINCT sender_id)
+		FROM messages
+		WHERE receiver_id = $1
+		  AND sender_id != $2
+		  AND created_at <= $3
+		  AND ($4::timestamp IS NULL OR created_at > $4)
+	`, chatID, userID, upTo, lastReadAt))
+	if err != nil {
+		return 0, fmt.Errorf("count messages: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE chat_members
+		SET last_read_at = $3
+		WHERE chat_id = $1 AND user_id = $2
+	`, chatID, userID, upTo)
+	if err != nil {
+		return 0, fmt.Errorf("update read marker: %w", err)
+	}
+
+	if err := insertStatusEvents(ctx, tx, affected, events); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Marked group messages as read", "chat_id", chatID, "user_id", userID, "count", affected.Count)
+	return affected.Count, nil
+}
+
+// MarkDeliveredUpTo implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) MarkDeliveredUpTo(ctx context.Context, chatID, userID string, upTo time.Time, events domain.OutboxEventsFunc) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var lastDeliveredAt, lastReadAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT last_delivered_at, last_read_at
+		FROM chat_members
+		WHERE chat_id = $1 AND user_id = $2
+		FOR UPDATE
+	`, chatID, userID).Scan(&lastDeliveredAt, &lastReadAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrNotChatMember
+		}
+		return 0, fmt.Errorf("get delivery marker: %w", err)
+	}
+
+	// Read messages are delivered as well, so start from whichever marker is further
+	since := lastDeliveredAt
+	if lastReadAt.Valid && (!since.Valid || lastReadAt.Time.After(since.Time)) {
+		since = lastReadAt
+	}
+
+	if since.Valid && !upTo.After(since.Time) {
+		return 0, nil
+	}
+
+	affected, err := scanGroupMessageRange(tx.QueryRowContext(ctx, `
+		SELECT COUNT(*), MIN(created_at), MAX(created_at), ARRAY_AGG(DISTINCT sender_id)
+		FROM messages
+		WHERE receiver_id = $1
+		  AND sender_id != $2
+		  AND created_at <= $3
+		  AND ($4::timestamp IS NULL OR created_at > $4)
+	`, chatID, userID, upTo, since))
+	if err != nil {
+		return 0, fmt.Errorf("count messages: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE chat_members
+		SET last_delivered_at = $3
+		WHERE chat_id = $1 AND user_id = $2
+	`, chatID, userID, upTo)
+	if err != nil {
+		return 0, fmt.Errorf("update delivery marker: %w", err)
+	}
+
+	if err := insertStatusEvents(ctx, tx, affected, events); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Marked group messages as delivered", "chat_id", chatID, "user_id", userID, "count", affected.Count)
+	return affected.Count, nil
+}
+
+// MuteChat implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) MuteChat(ctx context.Context, mute domain.ChatMute) error {
+	if err := mute.Validate(); err != nil {
+		return fmt.Errorf("mute validation failed: %w", err)
+	}
+
+	var mutedUntil sql.NullTime
+	if mute.MutedUntil != nil {
+		mutedUntil = sql.NullTime{Time: mute.MutedUntil.UTC(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO chat_mutes (chat_id, user_id, muted_until, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (chat_id, user_id) DO UPDATE
+		SET muted_until = EXCLUDED.muted_until,
+		    created_at = EXCLUDED.created_at
+	`, mute.ChatID, mute.UserID, mutedUntil, mute.CreatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to mute chat: %w", err)
+	}
+
+	r.logger.Debug("Chat muted", "chat_id", mute.ChatID, "user_id", mute.UserID, "muted_until", mute.MutedUntil)
+	return nil
+}
+
+// UnmuteChat implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) UnmuteChat(ctx context.Context, chatID, userID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM chat_mutes
+		WHERE chat_id = $1 AND user_id = $2
+	`, chatID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unmute chat: %w", err)
+	}
+
+	r.logger.Debug("Chat unmuted", "chat_id", chatID, "user_id", userID)
+	return nil
+}
+
+// GetMutedUserIDs implements ports.ChatRepository
+func (r *PostgreSQLChatRepository) GetMutedUserIDs(ctx context.Context, chatID string, userIDs []string, at time.Time) ([]string, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT user_id
+		FROM chat_mutes
+		WHERE chat_id = $1
+		  AND user_id = ANY($2)
+		  AND (muted_until IS NULL OR muted_until > $3)
+		ORDER BY user_id
+	`, chatID, pq.Array(userIDs), at.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query muted users: %w", err)
+	}
+	defer rows.Close()
+
+	var mutedIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan muted user: %w", err)
+		}
+		mutedIDs = append(mutedIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating muted users: %w", err)
+	}
+
+	return mutedIDs, nil
+}
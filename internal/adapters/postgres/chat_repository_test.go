@@ -0,0 +1,173 @@
+package postgres_test
+
+import (
+	"context"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/testdata"
+)
+
+func (s *TestSuite) TestChatRepositoryIntegration() {
+	ctx := context.Background()
+
+	group := domain.NewGroupChat("Launch", testdata.Alice.UserID, []string{testdata.Bob.UserID, testdata.Charlie.UserID})
+
+	// CreateGroupChat
+	err := s.chatRepo.CreateGroupChat(ctx, group)
+	s.Require().NoError(err)
+
+	// GetChat
+	got, err := s.chatRepo.GetChat(ctx, group.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(group.Name, got.Name)
+	s.Require().Len(got.Members, 3)
+	s.Require().True(got.IsOwner(testdata.Alice.UserID))
+
+	// AddMember
+	err = s.chatRepo.AddMember(ctx, domain.ChatMember{
+		ChatID:   group.ID,
+		UserID:   testdata.Diana.UserID,
+		Role:     domain.ChatRoleMember,
+		JoinedAt: time.Now().UTC(),
+	})
+	s.Require().NoError(err)
+
+	err = s.chatRepo.AddMember(ctx, domain.ChatMember{
+		ChatID:   group.ID,
+		UserID:   testdata.Diana.UserID,
+		Role:     domain.ChatRoleMember,
+		JoinedAt: time.Now().UTC(),
+	})
+	s.Require().ErrorIs(err, domain.ErrMemberExists)
+
+	// SaveMessage to the group and read it back
+	msg := domain.Message{
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: group.ID,
+		CreatedAt:  time.Now().UTC().Truncate(time.Microsecond),
+		Content:    "Hello team!",
+		Status:     domain.MessageStatusSent,
+	}
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg))
+
+	page, err := s.repo.GetMessages(ctx, group.ID, domain.MessagePage{Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(page.Messages, 1)
+
+	// GetChatSessions includes the group but not a bogus 1:1 chat with it
+	sessions, err := s.repo.GetChatSessions(ctx, testdata.Alice.UserID, domain.ChatCursor{}, 50)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 1)
+	s.Require().Equal(domain.ChatTypeGroup, sessions[0].ChatType)
+	s.Require().Len(sessions[0].Participants, 4)
+
+	// Unread count for Bob, then mark as read
+	count, err := s.repo.GetUnreadCount(ctx, testdata.Bob.UserID, group.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(1, count)
+
+	affected, err := s.chatRepo.MarkReadUpTo(ctx, group.ID, testdata.Bob.UserID, msg.CreatedAt, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), affected)
+
+	count, err = s.repo.GetUnreadCount(ctx, testdata.Bob.UserID, group.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(0, count)
+
+	// RemoveMember
+	s.Require().NoError(s.chatRepo.RemoveMember(ctx, group.ID, testdata.Diana.UserID))
+	isMember, err := s.chatRepo.IsMember(ctx, group.ID, testdata.Diana.UserID)
+	s.Require().NoError(err)
+	s.Require().False(isMember)
+
+	memberIDs, err := s.chatRepo.GetMemberIDs(ctx, group.ID)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{testdata.Alice.UserID, testdata.Bob.UserID, testdata.Charlie.UserID}, memberIDs)
+}
+
+func (s *TestSuite) TestChatMutesIntegration() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	alice, bob := testdata.Alice.UserID, testdata.Bob.UserID
+	chatID := domain.ComputeChatID(alice, bob)
+
+	s.Require().NoError(s.repo.SaveMessage(ctx, domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   bob,
+		ReceiverID: alice,
+		CreatedAt:  now,
+		Content:    "Are you there?",
+		Status:     domain.MessageStatusSent,
+	}))
+
+	// A timed mute is only active until it ends
+	until := now.Add(time.Hour)
+	s.Require().NoError(s.chatRepo.MuteChat(ctx, domain.ChatMute{ChatID: chatID, UserID: alice, MutedUntil: &until, CreatedAt: now}))
+
+	muted, err := s.chatRepo.GetMutedUserIDs(ctx, chatID, []string{alice, bob}, now)
+	s.Require().NoError(err)
+	s.Equal([]string{alice}, muted)
+
+	muted, err = s.chatRepo.GetMutedUserIDs(ctx, chatID, []string{alice, bob}, until.Add(time.Second))
+	s.Require().NoError(err)
+	s.Empty(muted)
+
+	// Muted chats report no unread messages, while the message itself is kept
+	count, err := s.repo.GetUnreadCount(ctx, alice, chatID)
+	s.Require().NoError(err)
+	s.Equal(0, count)
+
+	sessions, err := s.repo.GetChatSessions(ctx, alice, domain.ChatCursor{}, 10)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 1)
+	s.True(sessions[0].Muted)
+	s.Require().NotNil(sessions[0].MutedUntil)
+	s.True(sessions[0].MutedUntil.Equal(until))
+	s.Equal(0, sessions[0].UnreadCount)
+	s.Equal("Are you there?", sessions[0].LastMessage)
+
+	// Muting again replaces the previous end
+	s.Require().NoError(s.chatRepo.MuteChat(ctx, domain.ChatMute{ChatID: chatID, UserID: alice, CreatedAt: now}))
+
+	muted, err = s.chatRepo.GetMutedUserIDs(ctx, chatID, []string{alice}, until.Add(time.Second))
+	s.Require().NoError(err)
+	s.Equal([]string{alice}, muted)
+
+	// Unmuting restores the unread count
+	s.Require().NoError(s.chatRepo.UnmuteChat(ctx, chatID, alice))
+	s.Require().NoError(s.chatRepo.UnmuteChat(ctx, chatID, alice))
+
+	count, err = s.repo.GetUnreadCount(ctx, alice, chatID)
+	s.Require().NoError(err)
+	s.Equal(1, count)
+
+	sessions, err = s.repo.GetChatSessions(ctx, alice, domain.ChatCursor{}, 10)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 1)
+	s.False(sessions[0].Muted)
+	s.Equal(1, sessions[0].UnreadCount)
+}
+
+func (s *TestSuite) TestGroupReadRangeSenders() {
+	ctx := context.Background()
+	group := domain.NewGroupChat("Receipts", testdata.Alice.UserID, []string{testdata.Bob.UserID, testdata.Charlie.UserID})
+	s.Require().NoError(s.chatRepo.CreateGroupChat(ctx, group))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	var last domain.Message
+	for i, sender := range []string{testdata.Alice.UserID, testdata.Charlie.UserID, testdata.Alice.UserID, testdata.Bob.UserID} {
+		last = domain.Message{SenderID: sender, ReceiverID: group.ID, CreatedAt: now.Add(time.Duration(i) * time.Second), Content: "Hi", Status: domain.MessageStatusSent}
+		s.Require().NoError(s.repo.SaveMessage(ctx, last))
+	}
+
+	// Every sender of a message Bob read gets a receipt, except Bob himself
+	var affected domain.MessageRange
+	count, err := s.chatRepo.MarkReadUpTo(ctx, group.ID, testdata.Bob.UserID, last.CreatedAt, func(r domain.MessageRange) ([]domain.OutboxEvent, error) {
+		affected = r
+		return nil, nil
+	})
+	s.Require().NoError(err)
+	s.Equal(int64(3), count)
+	s.ElementsMatch([]string{testdata.Alice.UserID, testdata.Charlie.UserID}, affected.Senders)
+}
@@ -68,3 +68,16 @@ func TestNewConnectionIntegration(t *testing.T) {
 		t.Fatalf("expected 1 from SELECT 1, got %d", one)
 	}
 }
+
+func TestDatabaseHealthCheckerIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	checker := postgres.NewDatabaseHealthChecker(db)
+	if err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("expected database to be healthy: %v", err)
+	}
+
+	db.Close()
+	if err := checker.Check(context.Background()); err == nil {
+		t.Fatalf("expected closed database to be unhealthy")
+	}
+}
@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DatabaseHealthChecker checks that the connection pool can reach Postgres
+type DatabaseHealthChecker struct {
+	db *sql.DB
+}
+
+func NewDatabaseHealthChecker(db *sql.DB) *DatabaseHealthChecker {
+	return &DatabaseHealthChecker{db: db}
+}
+
+// Name implements ports.HealthChecker
+func (c *DatabaseHealthChecker) Name() string {
+	return "database"
+}
+
+// Check implements ports.HealthChecker
+func (c *DatabaseHealthChecker) Check(ctx context.Context) error {
+	if err := c.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	return nil
+}
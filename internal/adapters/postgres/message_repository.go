@@ -4,99 +4,341 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"sort"
-	"strings"
 	"time"
 
 	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"messaging-app/internal/domain"
 	"messaging-app/internal/ports"
 )
 
+const tracerName = "messaging-app/internal/adapters/postgres"
+
 type PostgreSQLMessageRepository struct {
-	db     *sql.DB
-	logger ports.Logger
+	db      *sql.DB
+	metrics ports.Metrics
+	logger  ports.Logger
 }
 
-func NewPostgreSQLMessageRepository(db *sql.DB, logger ports.Logger) *PostgreSQLMessageRepository {
+func NewPostgreSQLMessageRepository(db *sql.DB, metrics ports.Metrics, logger ports.Logger) *PostgreSQLMessageRepository {
 	return &PostgreSQLMessageRepository{
-		db:     db,
-		logger: logger,
+		db:      db,
+		metrics: metrics,
+		logger:  logger,
 	}
 }
 
+// operation is a repository call in progress, measured for metrics and traced as a child span
+type operation struct {
+	name    string
+	start   time.Time
+	span    trace.Span
+	metrics ports.Metrics
+}
+
+// startOperation starts the span of a repository call; the returned context carries it to the queries
+func (r *PostgreSQLMessageRepository) startOperation(ctx context.Context, name string) (context.Context, *operation) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "MessageRepository "+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(name)),
+	)
+	return ctx, &operation{name: name, start: time.Now(), span: span, metrics: r.metrics}
+}
+
+// end records the operation once it returns, deferred with a pointer to its error
+func (o *operation) end(err *error) {
+	o.metrics.ObserveQuery(o.name, time.Since(o.start), *err)
+	if *err != nil {
+		o.span.RecordError(*err)
+		o.span.SetStatus(codes.Error, (*err).Error())
+	}
+	o.span.End()
+}
+
 // SaveMessage implements ports.MessageRepository
-func (r *PostgreSQLMessageRepository) SaveMessage(ctx context.Context, message domain.Message) error {
+func (r *PostgreSQLMessageRepository) SaveMessage(ctx context.Context, message domain.Message, events ...domain.OutboxEvent) (err error) {
+	ctx, op := r.startOperation(ctx, "save_message")
+	defer op.end(&err)
+
 	if err := message.Validate(); err != nil {
 		return fmt.Errorf("message validation failed: %w", err)
 	}
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Direct messages are refused once either user blocked the other
+	if !message.IsGroupMessage() {
+		if err := checkNotBlocked(ctx, tx, message.SenderID, message.ReceiverID); err != nil {
+			return err
+		}
+	}
+
+	// Messages saved without an ID get one derived from their timestamp
 	query := `
-        INSERT INTO messages (sender_id, receiver_id, created_at, content, status)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO messages (id, sender_id, receiver_id, created_at, content, status, client_message_id, reply_to_id, kind, attachment_id)
+        VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_v7($4)), $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, '')::uuid,
+                COALESCE(NULLIF($9, ''), 'text'), NULLIF($10, '')::uuid)
     `
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
+		message.ID,
 		message.SenderID,
 		message.ReceiverID,
 		message.CreatedAt,
 		message.Content,
 		message.Status,
+		message.ClientMessageID,
+		message.ReplyToID(),
+		string(message.Kind),
+		message.AttachmentID(),
 	)
 
 	if err != nil {
-		// Check for duplicate key error (PostgreSQL error code 23505)
-		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
-			return domain.ErrDuplicateMessage
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch {
+			case pqErr.Code == "23505" && pqErr.Constraint == "idx_messages_attachment":
+				return domain.ErrAttachmentInUse
+			case pqErr.Code == "23505": // Duplicate key
+				return domain.ErrDuplicateMessage
+			case pqErr.Code == "23503" && message.Attachment != nil: // The attachment was deleted meanwhile
+				return domain.ErrAttachmentNotFound
+			}
 		}
 		return fmt.Errorf("failed to save message: %w", err)
 	}
 
+	if err := insertOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
 	r.logger.Debug("Message saved", "sender", message.SenderID, "receiver", message.ReceiverID)
 	return nil
 }
 
+// Bounds standing in for the ID of a timestamp-only cursor, so that every message
+// created at that instant falls on the side of the history the page came from
+const (
+	minMessageID = "00000000-0000-0000-0000-000000000000"
+	maxMessageID = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+)
+
 // GetMessages implements ports.MessageRepository
-func (r *PostgreSQLMessageRepository) GetMessages(ctx context.Context, chatID string, cursor time.Time, limit int) ([]domain.Message, error) {
+func (r *PostgreSQLMessageRepository) GetMessages(ctx context.Context, chatID string, page domain.MessagePage) (_ ports.PaginationResult, err error) {
+	ctx, op := r.startOperation(ctx, "get_messages")
+	defer op.end(&err)
+
+	limit := page.Limit
 	if limit <= 0 || limit > 100 {
 		limit = 50 // Default limit
 	}
 
-	// Parse chat ID to get participants
-	participants := strings.Split(chatID, "---")
-	if len(participants) != 2 {
-		return nil, fmt.Errorf("invalid chat ID format: %s", chatID)
+	filter, args, err := messageChatFilter(chatID, page.UserID)
+	if err != nil {
+		return ports.PaginationResult{}, err
 	}
 
-	user1, user2 := participants[0], participants[1]
+	cursor := page.Cursor
+	if !cursor.IsZero() && cursor.ID == "" {
+		cursor.ID = minMessageID
+		if page.Direction == domain.PageAfter {
+			cursor.ID = maxMessageID
+		}
+	}
+
+	var older, newer []domain.Message
+	var result ports.PaginationResult
+
+	switch page.Direction {
+	case domain.PageAfter:
+		newer, err = r.queryMessages(ctx, filter, args, ">", cursor, limit+1)
+		if err != nil {
+			return ports.PaginationResult{}, err
+		}
+		result.HasNewer = len(newer) > limit
+		newer = truncateMessages(newer, limit)
+
+		// Anything up to the cursor is older than the page
+		result.HasMore, err = r.hasMessages(ctx, filter, args, "<=", cursor)
+		if err != nil {
+			return ports.PaginationResult{}, err
+		}
+
+	case domain.PageAround:
+		anchor, err := r.getChatMessage(ctx, filter, args, page.Cursor.ID)
+		if err != nil {
+			return ports.PaginationResult{}, err
+		}
+
+		// The anchor takes one slot, older messages get the smaller half of the rest
+		olderLimit := (limit - 1) / 2
+		newerLimit := limit - 1 - olderLimit
+
+		older, err = r.queryMessages(ctx, filter, args, "<", anchor.Cursor(), olderLimit+1)
+		if err != nil {
+			return ports.PaginationResult{}, err
+		}
+		result.HasMore = len(older) > olderLimit
+		older = truncateMessages(older, olderLimit)
+
+		newer, err = r.queryMessages(ctx, filter, args, ">", anchor.Cursor(), newerLimit+1)
+		if err != nil {
+			return ports.PaginationResult{}, err
+		}
+		result.HasNewer = len(newer) > newerLimit
+		newer = truncateMessages(newer, newerLimit)
+
+		older = append([]domain.Message{*anchor}, older...)
+
+	default:
+		older, err = r.queryMessages(ctx, filter, args, "<", cursor, limit+1)
+		if err != nil {
+			return ports.PaginationResult{}, err
+		}
+		result.HasMore = len(older) > limit
+		older = truncateMessages(older, limit)
+
+		// Anything from the cursor on is newer than the page
+		if !cursor.IsZero() {
+			result.HasNewer, err = r.hasMessages(ctx, filter, args, ">=", cursor)
+			if err != nil {
+				return ports.PaginationResult{}, err
+			}
+		}
+	}
+
+	// Newer messages come back oldest first and are flipped to keep the page newest first
+	result.Messages = make([]domain.Message, 0, len(newer)+len(older))
+	for i := len(newer) - 1; i >= 0; i-- {
+		result.Messages = append(result.Messages, newer[i])
+	}
+	result.Messages = append(result.Messages, older...)
+
+	if err := r.attachReactions(ctx, result.Messages); err != nil {
+		return ports.PaginationResult{}, err
+	}
 
-	var query string
+	if len(result.Messages) > 0 {
+		result.PrevCursor = result.Messages[0].Cursor().Encode()
+		if result.HasMore {
+			result.NextCursor = result.Messages[len(result.Messages)-1].Cursor().Encode()
+		}
+	}
+
+	r.logger.Debug("Retrieved messages", "chat_id", chatID, "direction", page.Direction, "count", len(result.Messages))
+	return result, nil
+}
+
+// attachReactions fills in the reaction summaries of messages with a single query
+// Emoji are ordered by their first use, users by when they reacted
+func (r *PostgreSQLMessageRepository) attachReactions(ctx context.Context, messages []domain.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT message_id, emoji, COUNT(*), ARRAY_AGG(user_id ORDER BY created_at, user_id)
+		FROM message_reactions
+		WHERE message_id = ANY($1::uuid[])
+		GROUP BY message_id, emoji
+		ORDER BY message_id, MIN(created_at), emoji
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get reactions: %w", err)
+	}
+	defer rows.Close()
+
+	reactions := make(map[string][]domain.ReactionSummary)
+	for rows.Next() {
+		var messageID string
+		var summary domain.ReactionSummary
+		var userIDs pq.StringArray
+		if err := rows.Scan(&messageID, &summary.Emoji, &summary.Count, &userIDs); err != nil {
+			return fmt.Errorf("failed to scan reaction: %w", err)
+		}
+		summary.UserIDs = userIDs
+		reactions[messageID] = append(reactions[messageID], summary)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate reactions: %w", err)
+	}
+
+	for i := range messages {
+		messages[i].Reactions = reactions[messages[i].ID]
+	}
+	return nil
+}
+
+// messageChatFilter returns the WHERE condition selecting a chat's messages and its arguments
+// Messages userID deleted for themselves are left out when userID is given
+func messageChatFilter(chatID, userID string) (string, []interface{}, error) {
+	var filter string
 	var args []interface{}
 
-	if cursor.IsZero() {
-		// First page - no cursor
-		query = `
-            SELECT sender_id, receiver_id, created_at, content, status
-            FROM messages
-            WHERE (sender_id = $1 AND receiver_id = $2) OR (sender_id = $2 AND receiver_id = $1)
-            ORDER BY created_at DESC
-            LIMIT $3
-        `
-		args = []interface{}{user1, user2, limit}
+	if domain.IsGroupChatID(chatID) {
+		// Group messages are stored with the group ID as receiver
+		filter = "receiver_id = $1"
+		args = []interface{}{chatID}
 	} else {
-		// Subsequent pages - use cursor
-		query = `
-            SELECT sender_id, receiver_id, created_at, content, status
-            FROM messages
-            WHERE ((sender_id = $1 AND receiver_id = $2) OR (sender_id = $2 AND receiver_id = $1))
-              AND created_at < $3
-            ORDER BY created_at DESC
-            LIMIT $4
-        `
-		args = []interface{}{user1, user2, cursor, limit}
+		// Parse chat ID to get participants
+		first, second, err := domain.DirectChatParticipants(chatID)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid chat ID format: %s: %w", chatID, err)
+		}
+
+		filter = "((sender_id = $1 AND receiver_id = $2) OR (sender_id = $2 AND receiver_id = $1))"
+		args = []interface{}{first, second}
+	}
+
+	if userID != "" {
+		filter += fmt.Sprintf(`
+          AND NOT EXISTS (SELECT 1 FROM hidden_messages h WHERE h.message_id = messages.id AND h.user_id = $%d)`, len(args)+1)
+		args = append(args, userID)
+	}
+
+	return filter, args, nil
+}
+
+// queryMessages returns up to limit messages of the chat on the op side of the cursor, closest first
+// A zero cursor matches the whole chat
+func (r *PostgreSQLMessageRepository) queryMessages(ctx context.Context, filter string, args []interface{}, op string, cursor domain.MessageCursor, limit int) ([]domain.Message, error) {
+	order := "DESC"
+	if op == ">" || op == ">=" {
+		order = "ASC"
 	}
 
+	args = append([]interface{}{}, args...)
+	query := `
+        SELECT ` + messageColumns + `
+        FROM ` + messageSource + `
+        WHERE ` + filter
+	if !cursor.IsZero() {
+		query += fmt.Sprintf(`
+          AND (created_at, id) %s ($%d::timestamp, $%d::uuid)`, op, len(args)+1, len(args)+2)
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	query += fmt.Sprintf(`
+        ORDER BY created_at %s, id %s
+        LIMIT $%d
+    `, order, order, len(args)+1)
+	args = append(args, limit)
+
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query messages: %w", err)
@@ -105,14 +347,7 @@ func (r *PostgreSQLMessageRepository) GetMessages(ctx context.Context, chatID st
 
 	var messages []domain.Message
 	for rows.Next() {
-		var msg domain.Message
-		err := rows.Scan(
-			&msg.SenderID,
-			&msg.ReceiverID,
-			&msg.CreatedAt,
-			&msg.Content,
-			&msg.Status,
-		)
+		msg, err := scanMessage(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
@@ -123,86 +358,348 @@ func (r *PostgreSQLMessageRepository) GetMessages(ctx context.Context, chatID st
 		return nil, fmt.Errorf("error iterating messages: %w", err)
 	}
 
-	r.logger.Debug("Retrieved messages", "chat_id", chatID, "count", len(messages))
 	return messages, nil
 }
 
-func (r *PostgreSQLMessageRepository) GetChatSessions(ctx context.Context, userID string) ([]domain.ChatSession, error) {
-	// Step 1: Get distinct participants
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT DISTINCT
-			CASE WHEN sender_id = $1 THEN receiver_id ELSE sender_id END as other_participant
-		FROM messages
-		WHERE sender_id = $1 OR receiver_id = $1
-	`, userID)
+// hasMessages reports whether the chat has any message on the op side of the cursor
+func (r *PostgreSQLMessageRepository) hasMessages(ctx context.Context, filter string, args []interface{}, op string, cursor domain.MessageCursor) (bool, error) {
+	args = append([]interface{}{}, args...)
+	query := fmt.Sprintf(`
+        SELECT EXISTS (
+            SELECT 1
+            FROM messages
+            WHERE %s
+              AND (created_at, id) %s ($%d::timestamp, $%d::uuid)
+        )
+    `, filter, op, len(args)+1, len(args)+2)
+	args = append(args, cursor.CreatedAt, cursor.ID)
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check messages: %w", err)
+	}
+	return exists, nil
+}
+
+// getChatMessage retrieves a message by ID, provided it belongs to the chat
+func (r *PostgreSQLMessageRepository) getChatMessage(ctx context.Context, filter string, args []interface{}, messageID string) (*domain.Message, error) {
+	args = append([]interface{}{}, args...)
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM %s
+        WHERE %s
+          AND id = $%d::uuid
+    `, messageColumns, messageSource, filter, len(args)+1)
+	args = append(args, messageID)
+
+	msg, err := scanMessage(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return &msg, nil
+}
+
+// truncateMessages drops the lookahead row fetched to detect further pages
+func truncateMessages(messages []domain.Message, limit int) []domain.Message {
+	if len(messages) > limit {
+		return messages[:limit]
+	}
+	return messages
+}
+
+// searchHeadlineOptions makes ts_headline mark matches with the domain markers, which survive HTML escaping
+var searchHeadlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=15, MinWords=5, FragmentDelimiter=" … "`,
+	domain.SearchMatchStart, domain.SearchMatchStop)
+
+// SearchMessages implements ports.MessageRepository
+// Matching and ranking run on the GIN-indexed content_tsv; snippets are only built for the returned page
+func (r *PostgreSQLMessageRepository) SearchMessages(ctx context.Context, search domain.MessageSearch) (_ ports.SearchResult, err error) {
+	ctx, op := r.startOperation(ctx, "search_messages")
+	defer op.end(&err)
+
+	limit := search.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	args := []interface{}{search.UserID, search.Text, searchHeadlineOptions}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// Direct chats the user sent or received in, and groups they currently belong to
+	filter := `messages.content_tsv @@ search_query.query
+              AND messages.deleted_at IS NULL
+              AND (
+                  messages.receiver_id IN (SELECT chat_id FROM chat_members WHERE user_id = $1)
+                  OR ((messages.sender_id = $1 OR messages.receiver_id = $1) AND messages.receiver_id NOT LIKE '` + domain.GroupChatIDPrefix + `%')
+              )
+              AND NOT EXISTS (SELECT 1 FROM hidden_messages h WHERE h.message_id = messages.id AND h.user_id = $1)`
+
+	if search.ChatID != "" {
+		if domain.IsGroupChatID(search.ChatID) {
+			filter += `
+              AND messages.receiver_id = ` + arg(search.ChatID)
+		} else {
+			first, second, err := domain.DirectChatParticipants(search.ChatID)
+			if err != nil {
+				return ports.SearchResult{}, err
+			}
+			a, b := arg(first), arg(second)
+			filter += fmt.Sprintf(`
+              AND ((messages.sender_id = %s AND messages.receiver_id = %s) OR (messages.sender_id = %s AND messages.receiver_id = %s))`, a, b, b, a)
+		}
+	}
+	if search.SenderID != "" {
+		filter += `
+              AND messages.sender_id = ` + arg(search.SenderID)
+	}
+	if !search.From.IsZero() {
+		filter += `
+              AND messages.created_at >= ` + arg(search.From) + `::timestamp`
+	}
+	if !search.To.IsZero() {
+		filter += `
+              AND messages.created_at < ` + arg(search.To) + `::timestamp`
+	}
+
+	keyset := "TRUE"
+	if !search.Cursor.IsZero() {
+		keyset = fmt.Sprintf("(hit_rank, hit_created_at, hit_id) < (%s::float8, %s::timestamp, %s::uuid)",
+			arg(search.Cursor.Rank), arg(search.Cursor.CreatedAt), arg(search.Cursor.ID))
+	}
+
+	query := `
+        WITH search_query AS (
+            SELECT websearch_to_tsquery('simple', $2) AS query
+        ),
+        hits AS (
+            SELECT messages.id AS hit_id, messages.created_at AS hit_created_at,
+                   ts_rank_cd(messages.content_tsv, search_query.query)::float8 AS hit_rank
+            FROM messages, search_query
+            WHERE ` + filter + `
+        ),
+        page AS (
+            SELECT hit_id, hit_created_at, hit_rank
+            FROM hits
+            WHERE ` + keyset + `
+            ORDER BY hit_rank DESC, hit_created_at DESC, hit_id DESC
+            LIMIT ` + arg(limit+1) + `
+        )
+        SELECT ` + messageColumns + `, page.hit_rank, ts_headline('simple', messages.content, search_query.query, $3)
+        FROM ` + messageSource + `
+        JOIN page ON page.hit_id = messages.id
+        CROSS JOIN search_query
+        ORDER BY page.hit_rank DESC, page.hit_created_at DESC, page.hit_id DESC
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("get participants: %w", err)
+		return ports.SearchResult{}, fmt.Errorf("failed to search messages: %w", err)
 	}
 	defer rows.Close()
 
-	var participants []string
+	result := ports.SearchResult{Hits: make([]domain.SearchHit, 0, limit)}
 	for rows.Next() {
-		var participant string
-		if err := rows.Scan(&participant); err != nil {
-			return nil, fmt.Errorf("scan participant: %w", err)
+		var hit domain.SearchHit
+		var headline string
+		msg, err := scanMessage(rowScannerFunc(func(dest ...interface{}) error {
+			return rows.Scan(append(dest, &hit.Rank, &headline)...)
+		}))
+		if err != nil {
+			return ports.SearchResult{}, fmt.Errorf("failed to scan search hit: %w", err)
 		}
-		participants = append(participants, participant)
+		hit.Message = msg
+		hit.ChatID = msg.GetChatID()
+		hit.Snippet = domain.HighlightSnippet(headline)
+		result.Hits = append(result.Hits, hit)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iter participants: %w", err)
+		return ports.SearchResult{}, fmt.Errorf("error iterating search hits: %w", err)
 	}
 
-	// Step 2: Loop over participants and fetch session info
-	sessions := make([]domain.ChatSession, 0, len(participants))
+	if len(result.Hits) > limit {
+		result.Hits = result.Hits[:limit]
+		result.HasMore = true
+		result.NextCursor = result.Hits[limit-1].Cursor().Encode()
+	}
 
-	for _, participant := range participants {
-		session := domain.ChatSession{
-			OtherParticipant: participant,
-			ChatID:           domain.ComputeChatID(userID, participant),
-		}
+	messages := make([]domain.Message, len(result.Hits))
+	for i := range result.Hits {
+		messages[i] = result.Hits[i].Message
+	}
+	if err := r.attachReactions(ctx, messages); err != nil {
+		return ports.SearchResult{}, err
+	}
+	for i := range result.Hits {
+		result.Hits[i].Message.Reactions = messages[i].Reactions
+	}
 
-		// Step 2a: Get unread count
-		if err := r.db.QueryRowContext(ctx, `
-			SELECT COUNT(*)
-			FROM messages
-			WHERE sender_id = $1 AND receiver_id = $2 AND status != 'read'
-		`, participant, userID).Scan(&session.UnreadCount); err != nil {
-			return nil, fmt.Errorf("get unread count for %s: %w", participant, err)
-		}
+	r.logger.Debug("Searched messages", "user_id", search.UserID, "chat_id", search.ChatID, "count", len(result.Hits))
+	return result, nil
+}
 
-		// Step 2b: Get last message
-		var lastMsg sql.NullString
-		var lastBy sql.NullString
-		var lastAt sql.NullTime
+// GetChatSessions implements ports.MessageRepository
+// Direct and group sessions are assembled, ordered and paginated in a single query
+func (r *PostgreSQLMessageRepository) GetChatSessions(ctx context.Context, userID string, cursor domain.ChatCursor, limit int) (_ []domain.ChatSession, err error) {
+	ctx, op := r.startOperation(ctx, "get_chat_sessions")
+	defer op.end(&err)
 
-		err := r.db.QueryRowContext(ctx, `
-			SELECT content, sender_id, created_at
+	if limit <= 0 || limit > 101 {
+		limit = 50 // Default limit
+	}
+
+	var cursorAt sql.NullTime
+	if !cursor.IsZero() {
+		cursorAt = sql.NullTime{Time: cursor.LastMessageAt, Valid: true}
+	}
+
+	// Chat IDs use byte ordering (COLLATE "C") to match domain.ComputeChatID and keep the keyset stable
+	query := `
+		WITH direct_messages AS (
+			SELECT
+				CASE WHEN sender_id = $1 THEN receiver_id ELSE sender_id END AS other_participant,
+				id, sender_id, receiver_id, created_at, content, status
 			FROM messages
-			WHERE (sender_id = $1 AND receiver_id = $2) OR (sender_id = $2 AND receiver_id = $1)
-			ORDER BY created_at DESC
-			LIMIT 1
-		`, userID, participant).Scan(&lastMsg, &lastBy, &lastAt)
-		if err != nil && err != sql.ErrNoRows {
-			return nil, fmt.Errorf("get last message for %s: %w", participant, err)
-		}
+			WHERE (sender_id = $1 OR receiver_id = $1)
+			  AND NOT EXISTS (SELECT 1 FROM chats c WHERE c.id = messages.receiver_id)
+		),
+		ranked_direct AS (
+			SELECT
+				other_participant, sender_id, created_at, content,
+				COUNT(*) FILTER (WHERE receiver_id = $1 AND status != 'read')
+					OVER (PARTITION BY other_participant) AS unread_count,
+				ROW_NUMBER()
+					OVER (PARTITION BY other_participant ORDER BY created_at DESC, id DESC) AS position
+			FROM direct_messages
+		),
+		sessions AS (
+			SELECT
+				LEAST($1 COLLATE "C", other_participant COLLATE "C") || '---' ||
+					GREATEST($1 COLLATE "C", other_participant COLLATE "C") AS chat_id,
+				'direct' AS chat_type,
+				'' AS name,
+				other_participant,
+				NULL::TEXT[] AS participants,
+				created_at AS last_message_at,
+				unread_count,
+				content AS last_message,
+				sender_id AS last_message_by
+			FROM ranked_direct
+			WHERE position = 1
+			  -- Chats with users the user blocked are hidden until they are unblocked
+			  AND NOT EXISTS (
+				SELECT 1 FROM user_blocks b
+				WHERE b.blocker_id = $1 AND b.blocked_id = ranked_direct.other_participant
+			  )
+
+			UNION ALL
 
-		session.LastMessage = lastMsg.String
-		session.LastMessageBy = lastBy.String
-		session.LastMessageAt = lastAt.Time
+			SELECT
+				c.id COLLATE "C",
+				'group',
+				c.name,
+				'',
+				members.user_ids,
+				COALESCE(last_message.created_at, c.created_at),
+				unread.count,
+				COALESCE(last_message.content, ''),
+				COALESCE(last_message.sender_id, '')
+			FROM chat_members cm
+			JOIN chats c ON c.id = cm.chat_id
+			LEFT JOIN LATERAL (
+				SELECT content, sender_id, created_at
+				FROM messages
+				WHERE receiver_id = c.id
+				ORDER BY created_at DESC, id DESC
+				LIMIT 1
+			) last_message ON TRUE
+			CROSS JOIN LATERAL (
+				-- Unread messages are those from other members after the read marker
+				SELECT COUNT(*) AS count
+				FROM messages
+				WHERE receiver_id = c.id
+				  AND sender_id != $1
+				  AND (cm.last_read_at IS NULL OR created_at > cm.last_read_at)
+			) unread
+			CROSS JOIN LATERAL (
+				SELECT ARRAY_AGG(user_id ORDER BY user_id) AS user_ids
+				FROM chat_members
+				WHERE chat_id = c.id
+			) members
+			WHERE cm.user_id = $1
+		)
+		SELECT chat_id, chat_type, name, other_participant, participants,
+		       last_message_at,
+		       CASE WHEN m.user_id IS NULL THEN unread_count ELSE 0 END,
+		       last_message, last_message_by,
+		       COALESCE(p.online, FALSE), p.last_seen,
+		       m.user_id IS NOT NULL, m.muted_until
+		FROM sessions
+		LEFT JOIN user_presence p ON p.user_id = sessions.other_participant
+		LEFT JOIN chat_mutes m ON m.chat_id = sessions.chat_id COLLATE "C"
+			AND m.user_id = $1
+			AND (m.muted_until IS NULL OR m.muted_until > $5)
+		WHERE $2::timestamp IS NULL OR (last_message_at, chat_id) < ($2::timestamp, $3)
+		ORDER BY last_message_at DESC, chat_id DESC
+		LIMIT $4
+	`
 
+	rows, err := r.db.QueryContext(ctx, query, userID, cursorAt, cursor.ChatID, limit, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chat sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]domain.ChatSession, 0, limit)
+	for rows.Next() {
+		var session domain.ChatSession
+		var participants pq.StringArray
+		var lastSeen, mutedUntil sql.NullTime
+		err := rows.Scan(
+			&session.ChatID,
+			&session.ChatType,
+			&session.Name,
+			&session.OtherParticipant,
+			&participants,
+			&session.LastMessageAt,
+			&session.UnreadCount,
+			&session.LastMessage,
+			&session.LastMessageBy,
+			&session.Online,
+			&lastSeen,
+			&session.Muted,
+			&mutedUntil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chat session: %w", err)
+		}
+		session.Participants = participants
+		if lastSeen.Valid {
+			session.LastSeen = &lastSeen.Time
+		}
+		if mutedUntil.Valid {
+			session.MutedUntil = &mutedUntil.Time
+		}
 		sessions = append(sessions, session)
 	}
 
-	// Step 3: Sort by last message timestamp descending
-	sort.Slice(sessions, func(i, j int) bool {
-		return sessions[i].LastMessageAt.After(sessions[j].LastMessageAt)
-	})
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating chat sessions: %w", err)
+	}
 
 	r.logger.Debug("Retrieved chat sessions", "user_id", userID, "count", len(sessions))
 	return sessions, nil
 }
 
-func (r *PostgreSQLMessageRepository) MarkMessagesUpToRead(ctx context.Context, msg domain.MessageID) (int64, error) {
+func (r *PostgreSQLMessageRepository) MarkMessagesUpToRead(ctx context.Context, msg domain.MessageID, events domain.OutboxEventsFunc) (_ int64, err error) {
+	ctx, op := r.startOperation(ctx, "mark_messages_up_to_read")
+	defer op.end(&err)
 
 	// Start transaction
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -212,49 +709,103 @@ func (r *PostgreSQLMessageRepository) MarkMessagesUpToRead(ctx context.Context,
 	defer tx.Rollback()
 
 	query := `
-		UPDATE messages
-		SET status = $4
-		WHERE receiver_id = $1
-		  AND sender_id = $2
-		  AND created_at <= $3
-		  AND status != $4
+		WITH updated AS (
+			UPDATE messages
+			SET status = $4
+			WHERE receiver_id = $1
+			  AND sender_id = $2
+			  AND created_at <= $3
+			  AND status != $4
+			RETURNING created_at
+		)
+		SELECT COUNT(*), MIN(created_at), MAX(created_at)
+		FROM updated
 	`
 
-	res, err := tx.ExecContext(ctx, query, msg.ReceiverID, msg.SenderID, msg.CreatedAt, domain.MessageStatusRead)
+	affected, err := scanMessageRange(tx.QueryRowContext(ctx, query, msg.ReceiverID, msg.SenderID, msg.CreatedAt, domain.MessageStatusRead))
 	if err != nil {
 		return 0, fmt.Errorf("update messages: %w", err)
 	}
 
-	affected, err := res.RowsAffected()
+	if err := insertStatusEvents(ctx, tx, affected, events); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Marked messages as read", "receiver", msg.ReceiverID, "sender", msg.SenderID, "count", affected.Count)
+	return affected.Count, nil
+}
+
+// MarkMessagesUpToDelivered implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) MarkMessagesUpToDelivered(ctx context.Context, msg domain.MessageID, events domain.OutboxEventsFunc) (_ int64, err error) {
+	ctx, op := r.startOperation(ctx, "mark_messages_up_to_delivered")
+	defer op.end(&err)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Only sent messages move forward, so read messages are never downgraded
+	query := `
+		WITH updated AS (
+			UPDATE messages
+			SET status = $4
+			WHERE receiver_id = $1
+			  AND sender_id = $2
+			  AND created_at <= $3
+			  AND status = $5
+			RETURNING created_at
+		)
+		SELECT COUNT(*), MIN(created_at), MAX(created_at)
+		FROM updated
+	`
+
+	affected, err := scanMessageRange(tx.QueryRowContext(ctx, query, msg.ReceiverID, msg.SenderID, msg.CreatedAt, domain.MessageStatusDelivered, domain.MessageStatusSent))
 	if err != nil {
-		return 0, fmt.Errorf("rows affected: %w", err)
+		return 0, fmt.Errorf("update messages: %w", err)
+	}
+
+	if err := insertStatusEvents(ctx, tx, affected, events); err != nil {
+		return 0, err
 	}
 
 	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("commit tx: %w", err)
 	}
 
-	r.logger.Debug("Marked messages as read", "receiver", msg.ReceiverID, "sender", msg.SenderID, "count", affected)
-	return affected, nil
+	r.logger.Debug("Marked messages as delivered", "receiver", msg.ReceiverID, "sender", msg.SenderID, "count", affected.Count)
+	return affected.Count, nil
 }
 
 // GetMessageByID implements ports.MessageRepository
-func (r *PostgreSQLMessageRepository) GetMessageByID(ctx context.Context, messageID domain.MessageID) (*domain.Message, error) {
-	query := `
-        SELECT sender_id, receiver_id, created_at, content, status
-        FROM messages
-        WHERE sender_id = $1 AND receiver_id = $2 AND created_at = $3
-    `
+func (r *PostgreSQLMessageRepository) GetMessageByID(ctx context.Context, messageID domain.MessageID) (_ *domain.Message, err error) {
+	ctx, op := r.startOperation(ctx, "get_message_by_id")
+	defer op.end(&err)
 
-	var msg domain.Message
-	err := r.db.QueryRowContext(ctx, query, messageID.SenderID, messageID.ReceiverID, messageID.CreatedAt).Scan(
-		&msg.SenderID,
-		&msg.ReceiverID,
-		&msg.CreatedAt,
-		&msg.Content,
-		&msg.Status,
-	)
+	var row *sql.Row
+	if messageID.ID != "" {
+		row = r.db.QueryRowContext(ctx, `
+            SELECT `+messageColumns+`
+            FROM `+messageSource+`
+            WHERE id = $1
+        `, messageID.ID)
+	} else {
+		// The composite form is kept for compatibility; it is no longer unique
+		row = r.db.QueryRowContext(ctx, `
+            SELECT `+messageColumns+`
+            FROM `+messageSource+`
+            WHERE sender_id = $1 AND receiver_id = $2 AND created_at = $3
+            ORDER BY id
+            LIMIT 1
+        `, messageID.SenderID, messageID.ReceiverID, messageID.CreatedAt)
+	}
 
+	msg, err := scanMessage(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, domain.ErrMessageNotFound
@@ -265,14 +816,395 @@ func (r *PostgreSQLMessageRepository) GetMessageByID(ctx context.Context, messag
 	return &msg, nil
 }
 
+// GetMessageByClientID implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) GetMessageByClientID(ctx context.Context, senderID, clientMessageID string) (_ *domain.Message, err error) {
+	ctx, op := r.startOperation(ctx, "get_message_by_client_id")
+	defer op.end(&err)
+
+	query := `
+        SELECT ` + messageColumns + `
+        FROM ` + messageSource + `
+        WHERE sender_id = $1 AND client_message_id = $2
+    `
+
+	msg, err := scanMessage(r.db.QueryRowContext(ctx, query, senderID, clientMessageID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get message by client ID: %w", err)
+	}
+
+	return &msg, nil
+}
+
+// EditMessage implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) EditMessage(ctx context.Context, message domain.Message, events ...domain.OutboxEvent) (err error) {
+	ctx, op := r.startOperation(ctx, "edit_message")
+	defer op.end(&err)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockLiveMessage(ctx, tx, message.ID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE messages
+		SET content = $2, edited_at = $3
+		WHERE id = $1
+	`, message.ID, message.Content, message.EditedAt)
+	if err != nil {
+		return fmt.Errorf("failed to edit message: %w", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Message edited", "message_id", message.ID, "sender", message.SenderID)
+	return nil
+}
+
+// DeleteMessage implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) DeleteMessage(ctx context.Context, messageID string, deletedAt time.Time, events ...domain.OutboxEvent) (err error) {
+	ctx, op := r.startOperation(ctx, "delete_message")
+	defer op.end(&err)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockLiveMessage(ctx, tx, messageID); err != nil {
+		return err
+	}
+
+	// The attachment goes with the content; the caller deletes its blob once this commits
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM attachments
+		WHERE id = (SELECT attachment_id FROM messages WHERE id = $1)
+	`, messageID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	// The row stays as a tombstone so history keeps its shape, the content is erased
+	_, err = tx.ExecContext(ctx, `
+		UPDATE messages
+		SET content = '', deleted_at = $2
+		WHERE id = $1
+	`, messageID, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	// Tombstones carry no reactions
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_reactions WHERE message_id = $1`, messageID); err != nil {
+		return fmt.Errorf("failed to delete reactions: %w", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Message deleted", "message_id", messageID)
+	return nil
+}
+
+// HideMessage implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) HideMessage(ctx context.Context, messageID, userID string, hiddenAt time.Time, events ...domain.OutboxEvent) (err error) {
+	ctx, op := r.startOperation(ctx, "hide_message")
+	defer op.end(&err)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO hidden_messages (message_id, user_id, hidden_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (message_id, user_id) DO NOTHING
+	`, messageID, userID, hiddenAt)
+	if err != nil {
+		// Check for foreign key violation (PostgreSQL error code 23503)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return domain.ErrMessageNotFound
+		}
+		return fmt.Errorf("failed to hide message: %w", err)
+	}
+
+	// Hiding twice is a no-op and announces nothing
+	hidden, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to hide message: %w", err)
+	}
+	if hidden > 0 {
+		if err := insertOutboxEvents(ctx, tx, events); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Message hidden", "message_id", messageID, "user_id", userID)
+	return nil
+}
+
+// AddReaction implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) AddReaction(ctx context.Context, reaction domain.Reaction, events ...domain.OutboxEvent) (err error) {
+	ctx, op := r.startOperation(ctx, "add_reaction")
+	defer op.end(&err)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Reacting again with the same emoji changes nothing
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (message_id, user_id) DO UPDATE
+		SET emoji = EXCLUDED.emoji, created_at = EXCLUDED.created_at
+		WHERE message_reactions.emoji <> EXCLUDED.emoji
+	`, reaction.MessageID, reaction.UserID, reaction.Emoji, reaction.CreatedAt)
+	if err != nil {
+		// Check for foreign key violation (PostgreSQL error code 23503)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return domain.ErrMessageNotFound
+		}
+		return fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	changed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to add reaction: %w", err)
+	}
+	if changed > 0 {
+		if err := insertOutboxEvents(ctx, tx, events); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Reaction added", "message_id", reaction.MessageID, "user_id", reaction.UserID, "changed", changed > 0)
+	return nil
+}
+
+// RemoveReaction implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) RemoveReaction(ctx context.Context, messageID, userID string, events ...domain.OutboxEvent) (err error) {
+	ctx, op := r.startOperation(ctx, "remove_reaction")
+	defer op.end(&err)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM message_reactions
+		WHERE message_id = $1 AND user_id = $2
+	`, messageID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	// Removing a reaction that is not there announces nothing
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
+	if removed > 0 {
+		if err := insertOutboxEvents(ctx, tx, events); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Reaction removed", "message_id", messageID, "user_id", userID, "removed", removed > 0)
+	return nil
+}
+
+// SaveAttachment implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) SaveAttachment(ctx context.Context, attachment domain.Attachment) (err error) {
+	ctx, op := r.startOperation(ctx, "save_attachment")
+	defer op.end(&err)
+
+	if err := attachment.Validate(); err != nil {
+		return fmt.Errorf("attachment validation failed: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Files cannot be uploaded to a direct chat either user blocked, as messages cannot be sent there
+	chat, err := domain.ParseChatID(attachment.ChatID)
+	if err != nil {
+		return fmt.Errorf("attachment validation failed: %w", err)
+	}
+	if len(chat.Participants) == 2 {
+		if err := checkNotBlocked(ctx, tx, chat.Participants[0], chat.Participants[1]); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO attachments (`+attachmentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, 0), NULLIF($9, 0), $10)
+	`,
+		attachment.ID,
+		attachment.UploaderID,
+		attachment.ChatID,
+		attachment.FileName,
+		attachment.MimeType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.Width,
+		attachment.Height,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Debug("Attachment saved", "attachment_id", attachment.ID, "uploader", attachment.UploaderID, "size", attachment.Size)
+	return nil
+}
+
+// GetAttachment implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) GetAttachment(ctx context.Context, attachmentID string) (_ *domain.Attachment, err error) {
+	ctx, op := r.startOperation(ctx, "get_attachment")
+	defer op.end(&err)
+
+	attachment, err := scanAttachment(r.db.QueryRowContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM attachments
+		WHERE id = $1
+	`, attachmentID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+// DeleteExpiredAttachments implements ports.MessageRepository
+func (r *PostgreSQLMessageRepository) DeleteExpiredAttachments(ctx context.Context, createdBefore time.Time, limit int) (_ []string, err error) {
+	ctx, op := r.startOperation(ctx, "delete_expired_attachments")
+	defer op.end(&err)
+
+	// Rows locked by a message being sent with them are skipped and looked at again next time
+	rows, err := r.db.QueryContext(ctx, `
+		DELETE FROM attachments
+		WHERE id IN (
+			SELECT a.id
+			FROM attachments a
+			WHERE a.created_at < $1
+			  AND NOT EXISTS (SELECT 1 FROM messages m WHERE m.attachment_id = a.id)
+			ORDER BY a.created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachmentIDs []string
+	for rows.Next() {
+		var attachmentID string
+		if err := rows.Scan(&attachmentID); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment ID: %w", err)
+		}
+		attachmentIDs = append(attachmentIDs, attachmentID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate expired attachments: %w", err)
+	}
+
+	return attachmentIDs, nil
+}
+
 // GetUnreadCount implements ports.MessageRepository
-func (r *PostgreSQLMessageRepository) GetUnreadCount(ctx context.Context, userID, chatID string) (int, error) {
-	participants := strings.Split(chatID, "---")
-	if len(participants) != 2 {
-		return 0, fmt.Errorf("invalid chat ID format: %s", chatID)
+func (r *PostgreSQLMessageRepository) GetUnreadCount(ctx context.Context, userID, chatID string) (_ int, err error) {
+	ctx, op := r.startOperation(ctx, "get_unread_count")
+	defer op.end(&err)
+
+	// Muted chats never count as unread
+	var muted bool
+	err = r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM chat_mutes
+			WHERE chat_id = $1 AND user_id = $2
+			  AND (muted_until IS NULL OR muted_until > $3)
+		)
+	`, chatID, userID, time.Now().UTC()).Scan(&muted)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check mute: %w", err)
+	}
+	if muted {
+		return 0, nil
+	}
+
+	if domain.IsGroupChatID(chatID) {
+		var count int
+		err := r.db.QueryRowContext(ctx, `
+			SELECT COUNT(*)
+			FROM messages m
+			JOIN chat_members cm ON cm.chat_id = m.receiver_id AND cm.user_id = $2
+			WHERE m.receiver_id = $1
+			  AND m.sender_id != $2
+			  AND (cm.last_read_at IS NULL OR m.created_at > cm.last_read_at)
+		`, chatID, userID).Scan(&count)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get unread count: %w", err)
+		}
+		return count, nil
+	}
+
+	user1, user2, err := domain.DirectChatParticipants(chatID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid chat ID format: %s: %w", chatID, err)
 	}
 
-	user1, user2 := participants[0], participants[1]
 	otherUser := user1
 	if user1 == userID {
 		otherUser = user2
@@ -285,7 +1217,7 @@ func (r *PostgreSQLMessageRepository) GetUnreadCount(ctx context.Context, userID
     `
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, otherUser, userID).Scan(&count)
+	err = r.db.QueryRowContext(ctx, query, otherUser, userID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get unread count: %w", err)
 	}
@@ -294,13 +1226,30 @@ func (r *PostgreSQLMessageRepository) GetUnreadCount(ctx context.Context, userID
 }
 
 // MarkChatAsRead implements ports.MessageRepository
-func (r *PostgreSQLMessageRepository) MarkChatAsRead(ctx context.Context, userID, chatID string) error {
-	participants := strings.Split(chatID, "---")
-	if len(participants) != 2 {
-		return fmt.Errorf("invalid chat ID format: %s", chatID)
+func (r *PostgreSQLMessageRepository) MarkChatAsRead(ctx context.Context, userID, chatID string) (err error) {
+	ctx, op := r.startOperation(ctx, "mark_chat_as_read")
+	defer op.end(&err)
+
+	if domain.IsGroupChatID(chatID) {
+		_, err := r.db.ExecContext(ctx, `
+			UPDATE chat_members
+			SET last_read_at = latest.created_at
+			FROM (SELECT MAX(created_at) AS created_at FROM messages WHERE receiver_id = $1) latest
+			WHERE chat_id = $1 AND user_id = $2 AND latest.created_at IS NOT NULL
+		`, chatID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to mark chat as read: %w", err)
+		}
+
+		r.logger.Debug("Marked chat as read", "user_id", userID, "chat_id", chatID)
+		return nil
+	}
+
+	user1, user2, err := domain.DirectChatParticipants(chatID)
+	if err != nil {
+		return fmt.Errorf("invalid chat ID format: %s: %w", chatID, err)
 	}
 
-	user1, user2 := participants[0], participants[1]
 	otherUser := user1
 	if user1 == userID {
 		otherUser = user2
@@ -312,7 +1261,7 @@ func (r *PostgreSQLMessageRepository) MarkChatAsRead(ctx context.Context, userID
         WHERE sender_id = $1 AND receiver_id = $2 AND status != 'read'
     `
 
-	_, err := r.db.ExecContext(ctx, query, otherUser, userID)
+	_, err = r.db.ExecContext(ctx, query, otherUser, userID)
 	if err != nil {
 		return fmt.Errorf("failed to mark chat as read: %w", err)
 	}
@@ -320,3 +1269,206 @@ func (r *PostgreSQLMessageRepository) MarkChatAsRead(ctx context.Context, userID
 	r.logger.Debug("Marked chat as read", "user_id", userID, "chat_id", chatID)
 	return nil
 }
+
+// messageColumns selects a message from messageSource, followed by the quote of the message it replies to
+// and the metadata of its attachment
+// The joined columns are prefixed so unqualified message columns stay unambiguous in filters
+const messageColumns = `id, sender_id, receiver_id, created_at, content, status, COALESCE(client_message_id, ''), edited_at, deleted_at,
+        quote_id, quote_sender_id, quote_created_at, quote_snippet, quote_deleted_at, kind,
+        file_id, file_uploader_id, file_chat_id, file_name, file_mime_type, file_size, file_checksum, file_width, file_height, file_created_at`
+
+// messageSource joins every message with the snippet of the message it replies to and its attachment
+var messageSource = fmt.Sprintf(`messages
+        LEFT JOIN LATERAL (
+            SELECT q.id AS quote_id, q.sender_id AS quote_sender_id, q.created_at AS quote_created_at,
+                   LEFT(q.content, %d) AS quote_snippet, q.deleted_at AS quote_deleted_at
+            FROM messages q
+            WHERE q.id = messages.reply_to_id
+        ) quote ON TRUE
+        LEFT JOIN LATERAL (
+            SELECT a.id AS file_id, a.uploader_id AS file_uploader_id, a.chat_id AS file_chat_id, a.file_name,
+                   a.mime_type AS file_mime_type, a.size AS file_size, a.checksum AS file_checksum,
+                   a.width AS file_width, a.height AS file_height, a.created_at AS file_created_at
+            FROM attachments a
+            WHERE a.id = messages.attachment_id
+        ) attached ON TRUE`, domain.QuoteSnippetLength)
+
+// attachmentColumns selects an attachment from the attachments table
+const attachmentColumns = `id, uploader_id, chat_id, file_name, mime_type, size, checksum, width, height, created_at`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// rowScannerFunc adapts a function to rowScanner, to scan extra columns after the ones a scanner reads
+type rowScannerFunc func(dest ...interface{}) error
+
+func (f rowScannerFunc) Scan(dest ...interface{}) error {
+	return f(dest...)
+}
+
+// scanMessage reads a row selected with messageColumns
+func scanMessage(row rowScanner) (domain.Message, error) {
+	var msg domain.Message
+	var quoteID, quoteSenderID, quoteSnippet sql.NullString
+	var quoteCreatedAt sql.NullTime
+	var quoteDeletedAt *time.Time
+	var kind string
+	var file nullableAttachment
+
+	err := row.Scan(
+		&msg.ID,
+		&msg.SenderID,
+		&msg.ReceiverID,
+		&msg.CreatedAt,
+		&msg.Content,
+		&msg.Status,
+		&msg.ClientMessageID,
+		&msg.EditedAt,
+		&msg.DeletedAt,
+		&quoteID,
+		&quoteSenderID,
+		&quoteCreatedAt,
+		&quoteSnippet,
+		&quoteDeletedAt,
+		&kind,
+		&file.id,
+		&file.uploaderID,
+		&file.chatID,
+		&file.fileName,
+		&file.mimeType,
+		&file.size,
+		&file.checksum,
+		&file.width,
+		&file.height,
+		&file.createdAt,
+	)
+	if err != nil {
+		return msg, err
+	}
+
+	msg.Kind = domain.MessageKind(kind)
+	msg.Attachment = file.attachment()
+
+	if quoteID.Valid {
+		msg.ReplyTo = &domain.Quote{
+			ID:        quoteID.String,
+			SenderID:  quoteSenderID.String,
+			CreatedAt: quoteCreatedAt.Time,
+			Snippet:   quoteSnippet.String,
+			Deleted:   quoteDeletedAt != nil,
+		}
+	}
+	return msg, nil
+}
+
+// scanAttachment reads a row selected with attachmentColumns
+func scanAttachment(row rowScanner) (domain.Attachment, error) {
+	var file nullableAttachment
+	err := row.Scan(
+		&file.id,
+		&file.uploaderID,
+		&file.chatID,
+		&file.fileName,
+		&file.mimeType,
+		&file.size,
+		&file.checksum,
+		&file.width,
+		&file.height,
+		&file.createdAt,
+	)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return *file.attachment(), nil
+}
+
+// nullableAttachment receives attachment columns, which are all NULL for messages without one
+type nullableAttachment struct {
+	id, uploaderID, chatID, fileName, mimeType, checksum sql.NullString
+	size, width, height                                  sql.NullInt64
+	createdAt                                            sql.NullTime
+}
+
+func (f nullableAttachment) attachment() *domain.Attachment {
+	if !f.id.Valid {
+		return nil
+	}
+	return &domain.Attachment{
+		ID:         f.id.String,
+		UploaderID: f.uploaderID.String,
+		ChatID:     f.chatID.String,
+		FileName:   f.fileName.String,
+		MimeType:   f.mimeType.String,
+		Size:       f.size.Int64,
+		Checksum:   f.checksum.String,
+		Width:      int(f.width.Int64),
+		Height:     int(f.height.Int64),
+		CreatedAt:  f.createdAt.Time,
+		URL:        domain.AttachmentURL(f.id.String),
+	}
+}
+
+// lockLiveMessage locks a message row for update, failing if it is missing or deleted
+func lockLiveMessage(ctx context.Context, tx *sql.Tx, messageID string) error {
+	var deletedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, `
+		SELECT deleted_at FROM messages WHERE id = $1 FOR UPDATE
+	`, messageID).Scan(&deletedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrMessageNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock message: %w", err)
+	}
+	if deletedAt.Valid {
+		return domain.ErrMessageDeleted
+	}
+	return nil
+}
+
+// scanMessageRange reads a COUNT, MIN(created_at), MAX(created_at) row into a MessageRange
+func scanMessageRange(row *sql.Row) (domain.MessageRange, error) {
+	var affected domain.MessageRange
+	var from, to sql.NullTime
+	if err := row.Scan(&affected.Count, &from, &to); err != nil {
+		return domain.MessageRange{}, err
+	}
+	affected.From = from.Time
+	affected.To = to.Time
+	return affected, nil
+}
+
+// checkNotBlocked returns ErrUserBlocked if either user blocked the other
+func checkNotBlocked(ctx context.Context, tx *sql.Tx, userID, otherID string) error {
+	var blocked bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2)
+			   OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`, userID, otherID).Scan(&blocked)
+	if err != nil {
+		return fmt.Errorf("failed to check blocks: %w", err)
+	}
+	if blocked {
+		return domain.ErrUserBlocked
+	}
+	return nil
+}
+
+// scanGroupMessageRange reads a range followed by the distinct senders of its messages
+func scanGroupMessageRange(row *sql.Row) (domain.MessageRange, error) {
+	var affected domain.MessageRange
+	var from, to sql.NullTime
+	var senders pq.StringArray
+	if err := row.Scan(&affected.Count, &from, &to, &senders); err != nil {
+		return domain.MessageRange{}, err
+	}
+	affected.From = from.Time
+	affected.To = to.Time
+	affected.Senders = senders
+	return affected, nil
+}
@@ -2,11 +2,19 @@ package postgres_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/mock"
 
+	"messaging-app/internal/adapters/postgres"
 	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/testutils"
 	"messaging-app/testdata"
 )
 
@@ -35,12 +43,12 @@ func (s *TestSuite) TestMessageRepositoryIntegration() {
 	s.Require().Equal(msg.Content, got.Content)
 
 	// GetMessages
-	messages, err := s.repo.GetMessages(ctx, domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID), time.Time{}, 10)
+	page, err := s.repo.GetMessages(ctx, domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID), domain.MessagePage{Limit: 10})
 	s.Require().NoError(err)
-	s.Require().Len(messages, 1)
+	s.Require().Len(page.Messages, 1)
 
 	// GetChatSessions
-	sessions, err := s.repo.GetChatSessions(ctx, testdata.Bob.UserID)
+	sessions, err := s.repo.GetChatSessions(ctx, testdata.Bob.UserID, domain.ChatCursor{}, 50)
 	s.Require().NoError(err)
 	s.Require().Len(sessions, 1)
 
@@ -54,7 +62,7 @@ func (s *TestSuite) TestMessageRepositoryIntegration() {
 		SenderID:   msg.SenderID,
 		ReceiverID: msg.ReceiverID,
 		CreatedAt:  msg.CreatedAt,
-	})
+	}, nil)
 	s.Require().NoError(err)
 
 	// Verify status update
@@ -70,3 +78,574 @@ func (s *TestSuite) TestMessageRepositoryIntegration() {
 	err = s.repo.MarkChatAsRead(ctx, testdata.Bob.UserID, domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID))
 	s.Require().NoError(err)
 }
+
+func (s *TestSuite) TestMarkMessagesUpToDelivered() {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Microsecond)
+
+	first := domain.Message{
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  base,
+		Content:    "First",
+		Status:     domain.MessageStatusSent,
+	}
+	second := first
+	second.CreatedAt = base.Add(time.Second)
+	second.Content = "Second"
+
+	s.Require().NoError(s.repo.SaveMessage(ctx, first))
+	s.Require().NoError(s.repo.SaveMessage(ctx, second))
+
+	// Read the first message, then acknowledge delivery of both
+	_, err := s.repo.MarkMessagesUpToRead(ctx, first.Key(), nil)
+	s.Require().NoError(err)
+
+	affected, err := s.repo.MarkMessagesUpToDelivered(ctx, second.Key(), nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), affected)
+
+	// Read messages are never downgraded
+	got, err := s.repo.GetMessageByID(ctx, first.Key())
+	s.Require().NoError(err)
+	s.Require().Equal(domain.MessageStatusRead, got.Status)
+
+	got, err = s.repo.GetMessageByID(ctx, second.Key())
+	s.Require().NoError(err)
+	s.Require().Equal(domain.MessageStatusDelivered, got.Status)
+}
+
+func (s *TestSuite) TestMessageIDs() {
+	ctx := context.Background()
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	first := domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  createdAt,
+		Content:    "First",
+		Status:     domain.MessageStatusSent,
+	}
+	second := first
+	second.ID = domain.NewMessageID()
+	second.Content = "Second"
+
+	// Messages created in the same microsecond no longer collide
+	s.Require().NoError(s.repo.SaveMessage(ctx, first))
+	s.Require().NoError(s.repo.SaveMessage(ctx, second))
+	s.Require().ErrorIs(s.repo.SaveMessage(ctx, second), domain.ErrDuplicateMessage)
+
+	got, err := s.repo.GetMessageByID(ctx, domain.MessageID{ID: second.ID})
+	s.Require().NoError(err)
+	s.Equal("Second", got.Content)
+	s.Equal(second.ID, got.ID)
+
+	// Messages saved without an ID are assigned one
+	legacy := first
+	legacy.ID = ""
+	legacy.CreatedAt = createdAt.Add(time.Second)
+	s.Require().NoError(s.repo.SaveMessage(ctx, legacy))
+
+	got, err = s.repo.GetMessageByID(ctx, legacy.Key())
+	s.Require().NoError(err)
+	s.NoError(domain.MessageID{ID: got.ID}.Validate())
+}
+
+func (s *TestSuite) TestQueryMetrics() {
+	ctx := context.Background()
+	metrics := mocks.NewMetrics(s.T())
+	repo := postgres.NewPostgreSQLMessageRepository(s.db, metrics, &testutils.TestLogger{T: s.T()})
+
+	metrics.On("ObserveQuery", "save_message", mock.AnythingOfType("time.Duration"), nil).Once()
+	metrics.On("ObserveQuery", "get_message_by_id", mock.AnythingOfType("time.Duration"), mock.MatchedBy(func(err error) bool {
+		return errors.Is(err, domain.ErrMessageNotFound)
+	})).Once()
+
+	s.Require().NoError(repo.SaveMessage(ctx, testdata.ValidMessages()[0]))
+	_, err := repo.GetMessageByID(ctx, domain.MessageID{ID: domain.NewMessageID()})
+	s.ErrorIs(err, domain.ErrMessageNotFound)
+}
+
+func (s *TestSuite) TestClientMessageIDs() {
+	ctx := context.Background()
+
+	msg := domain.Message{
+		ID:              domain.NewMessageID(),
+		SenderID:        testdata.Alice.UserID,
+		ReceiverID:      testdata.Bob.UserID,
+		CreatedAt:       time.Now().UTC().Truncate(time.Microsecond),
+		Content:         "Hello, Bob!",
+		Status:          domain.MessageStatusSent,
+		ClientMessageID: "client-1",
+	}
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg))
+
+	// A retry gets a new ID and timestamp but reuses the key
+	retry := msg
+	retry.ID = domain.NewMessageID()
+	retry.CreatedAt = msg.CreatedAt.Add(time.Second)
+	s.Require().ErrorIs(s.repo.SaveMessage(ctx, retry), domain.ErrDuplicateMessage)
+
+	got, err := s.repo.GetMessageByClientID(ctx, msg.SenderID, "client-1")
+	s.Require().NoError(err)
+	s.Equal(msg.ID, got.ID)
+	s.Equal("client-1", got.ClientMessageID)
+
+	// Keys are scoped to the sender
+	other := retry
+	other.SenderID = testdata.Charlie.UserID
+	s.Require().NoError(s.repo.SaveMessage(ctx, other))
+
+	_, err = s.repo.GetMessageByClientID(ctx, testdata.Bob.UserID, "client-1")
+	s.ErrorIs(err, domain.ErrMessageNotFound)
+}
+
+func (s *TestSuite) TestGetChatSessionsPagination() {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Microsecond)
+
+	// Alice talks to three people, the latest conversation first
+	others := []string{testdata.Bob.UserID, testdata.Charlie.UserID, testdata.Diana.UserID}
+	for i, other := range others {
+		s.Require().NoError(s.repo.SaveMessage(ctx, domain.Message{
+			SenderID:   other,
+			ReceiverID: testdata.Alice.UserID,
+			CreatedAt:  base.Add(-time.Duration(i) * time.Minute),
+			Content:    "Hi Alice",
+			Status:     domain.MessageStatusSent,
+		}))
+	}
+	s.Require().NoError(s.repo.SaveMessage(ctx, domain.Message{
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  base.Add(time.Second),
+		Content:    "Hi Bob",
+		Status:     domain.MessageStatusSent,
+	}))
+
+	first, err := s.repo.GetChatSessions(ctx, testdata.Alice.UserID, domain.ChatCursor{}, 2)
+	s.Require().NoError(err)
+	s.Require().Len(first, 2)
+	s.Equal(testdata.Bob.UserID, first[0].OtherParticipant)
+	s.Equal("Hi Bob", first[0].LastMessage)
+	s.Equal(testdata.Alice.UserID, first[0].LastMessageBy)
+	s.Equal(1, first[0].UnreadCount)
+	s.Equal(testdata.Charlie.UserID, first[1].OtherParticipant)
+
+	second, err := s.repo.GetChatSessions(ctx, testdata.Alice.UserID, first[1].Cursor(), 2)
+	s.Require().NoError(err)
+	s.Require().Len(second, 1)
+	s.Equal(testdata.Diana.UserID, second[0].OtherParticipant)
+	s.Equal(domain.ComputeChatID(testdata.Alice.UserID, testdata.Diana.UserID), second[0].ChatID)
+}
+
+func (s *TestSuite) TestGetMessagesPagination() {
+	ctx := context.Background()
+	chatID := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+	base := time.Now().UTC().Truncate(time.Second)
+
+	// Five messages, the first two sharing the same instant
+	var saved []domain.Message
+	for i, offset := range []time.Duration{0, 0, time.Millisecond, time.Second, 2 * time.Second} {
+		msg := domain.Message{
+			ID:         domain.NewMessageID(),
+			SenderID:   testdata.Alice.UserID,
+			ReceiverID: testdata.Bob.UserID,
+			CreatedAt:  base.Add(offset),
+			Content:    fmt.Sprintf("Message %d", i),
+			Status:     domain.MessageStatusSent,
+		}
+		s.Require().NoError(s.repo.SaveMessage(ctx, msg))
+		saved = append(saved, msg)
+	}
+	sort.Slice(saved, func(i, j int) bool {
+		if saved[i].CreatedAt.Equal(saved[j].CreatedAt) {
+			return saved[i].ID > saved[j].ID
+		}
+		return saved[i].CreatedAt.After(saved[j].CreatedAt)
+	})
+
+	// Paging backwards visits every message once, including those sharing an instant
+	first, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Limit: 3})
+	s.Require().NoError(err)
+	s.Require().Len(first.Messages, 3)
+	s.True(first.HasMore)
+	s.False(first.HasNewer)
+
+	cursor, err := domain.ParseMessageCursor(first.NextCursor)
+	s.Require().NoError(err)
+	second, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Direction: domain.PageBefore, Cursor: cursor, Limit: 3})
+	s.Require().NoError(err)
+	s.Require().Len(second.Messages, 2)
+	s.False(second.HasMore)
+	s.True(second.HasNewer)
+
+	var ids []string
+	for _, msg := range append(first.Messages, second.Messages...) {
+		ids = append(ids, msg.ID)
+	}
+	var expected []string
+	for _, msg := range saved {
+		expected = append(expected, msg.ID)
+	}
+	s.Equal(expected, ids)
+
+	// Paging forwards from the oldest message catches up on the rest, newest first
+	newer, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Direction: domain.PageAfter, Cursor: saved[4].Cursor(), Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(newer.Messages, 4)
+	s.Equal(saved[0].ID, newer.Messages[0].ID)
+	s.Equal(saved[3].ID, newer.Messages[3].ID)
+	s.True(newer.HasMore)
+	s.False(newer.HasNewer)
+
+	// Around centres the page on the anchor
+	around, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Direction: domain.PageAround, Cursor: domain.MessageCursor{ID: saved[2].ID}, Limit: 3})
+	s.Require().NoError(err)
+	s.Require().Len(around.Messages, 3)
+	s.Equal(saved[1].ID, around.Messages[0].ID)
+	s.Equal(saved[2].ID, around.Messages[1].ID)
+	s.Equal(saved[3].ID, around.Messages[2].ID)
+	s.True(around.HasMore)
+	s.True(around.HasNewer)
+
+	// The anchor must belong to the chat
+	_, err = s.repo.GetMessages(ctx, domain.ComputeChatID(testdata.Alice.UserID, testdata.Charlie.UserID), domain.MessagePage{Direction: domain.PageAround, Cursor: domain.MessageCursor{ID: saved[2].ID}, Limit: 3})
+	s.ErrorIs(err, domain.ErrMessageNotFound)
+}
+
+func (s *TestSuite) TestEditAndDeleteMessages() {
+	ctx := context.Background()
+	chatID := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	msg := domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  now,
+		Content:    "Helo",
+		Status:     domain.MessageStatusSent,
+	}
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg))
+
+	// Edits are stored with their events
+	edited, err := msg.Edit("Hello", now.Add(time.Second))
+	s.Require().NoError(err)
+	events, err := domain.NewMessageUpdateOutboxEvents(domain.MessageTypeMessageEdited, edited)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.EditMessage(ctx, edited, events...))
+
+	got, err := s.repo.GetMessageByID(ctx, domain.MessageID{ID: msg.ID})
+	s.Require().NoError(err)
+	s.Equal("Hello", got.Content)
+	s.Require().NotNil(got.EditedAt)
+	s.True(got.EditedAt.Equal(*edited.EditedAt))
+
+	claimed, err := s.outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Len(claimed, 2)
+
+	// Hiding only affects the user who hid the message and is idempotent
+	s.Require().NoError(s.repo.HideMessage(ctx, msg.ID, testdata.Bob.UserID, now.Add(2*time.Second)))
+	s.Require().NoError(s.repo.HideMessage(ctx, msg.ID, testdata.Bob.UserID, now.Add(3*time.Second)))
+	s.ErrorIs(s.repo.HideMessage(ctx, domain.NewMessageID(), testdata.Bob.UserID, now), domain.ErrMessageNotFound)
+
+	forBob, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Limit: 10, UserID: testdata.Bob.UserID})
+	s.Require().NoError(err)
+	s.Empty(forBob.Messages)
+
+	forAlice, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Limit: 10, UserID: testdata.Alice.UserID})
+	s.Require().NoError(err)
+	s.Require().Len(forAlice.Messages, 1)
+
+	// Deleting for everyone leaves a tombstone that can no longer change
+	deletedAt := now.Add(4 * time.Second)
+	s.Require().NoError(s.repo.DeleteMessage(ctx, msg.ID, deletedAt))
+
+	forAlice, err = s.repo.GetMessages(ctx, chatID, domain.MessagePage{Limit: 10, UserID: testdata.Alice.UserID})
+	s.Require().NoError(err)
+	s.Require().Len(forAlice.Messages, 1)
+	s.Empty(forAlice.Messages[0].Content)
+	s.True(forAlice.Messages[0].IsDeleted())
+
+	s.ErrorIs(s.repo.DeleteMessage(ctx, msg.ID, deletedAt), domain.ErrMessageDeleted)
+	s.ErrorIs(s.repo.EditMessage(ctx, edited), domain.ErrMessageDeleted)
+	s.ErrorIs(s.repo.DeleteMessage(ctx, domain.NewMessageID(), deletedAt), domain.ErrMessageNotFound)
+}
+
+func (s *TestSuite) TestReactions() {
+	ctx := context.Background()
+	chatID := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	msg := domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  now,
+		Content:    "Lunch?",
+		Status:     domain.MessageStatusSent,
+	}
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg))
+
+	event, err := domain.NewOutboxEvent(domain.MessageTypeReactionUpdated, testdata.Alice.UserID, domain.ReactionUpdate{MessageID: msg.ID})
+	s.Require().NoError(err)
+
+	react := func(userID, emoji string, at time.Duration) {
+		s.Require().NoError(s.repo.AddReaction(ctx, domain.Reaction{MessageID: msg.ID, UserID: userID, Emoji: emoji, CreatedAt: now.Add(at)}, event))
+	}
+	react(testdata.Bob.UserID, "👍", time.Second)
+	react(testdata.Alice.UserID, "👍", 2*time.Second)
+	react(testdata.Bob.UserID, "👍", 3*time.Second) // Same emoji again changes nothing
+
+	page, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(page.Messages, 1)
+	s.Equal([]domain.ReactionSummary{
+		{Emoji: "👍", Count: 2, UserIDs: []string{testdata.Bob.UserID, testdata.Alice.UserID}},
+	}, page.Messages[0].Reactions)
+
+	// Reacting with another emoji replaces the user's reaction
+	react(testdata.Bob.UserID, "🎉", 4*time.Second)
+	s.Require().NoError(s.repo.RemoveReaction(ctx, msg.ID, testdata.Alice.UserID, event))
+	s.Require().NoError(s.repo.RemoveReaction(ctx, msg.ID, testdata.Alice.UserID, event))
+
+	page, err = s.repo.GetMessages(ctx, chatID, domain.MessagePage{Limit: 10})
+	s.Require().NoError(err)
+	s.Equal([]domain.ReactionSummary{
+		{Emoji: "🎉", Count: 1, UserIDs: []string{testdata.Bob.UserID}},
+	}, page.Messages[0].Reactions)
+
+	// Only actual changes are announced
+	claimed, err := s.outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Len(claimed, 4)
+
+	s.ErrorIs(s.repo.AddReaction(ctx, domain.Reaction{MessageID: domain.NewMessageID(), UserID: testdata.Bob.UserID, Emoji: "👍", CreatedAt: now}), domain.ErrMessageNotFound)
+}
+
+func (s *TestSuite) TestReplies() {
+	ctx := context.Background()
+	chatID := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	quoted := domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  now,
+		Content:    "Dinner at eight?",
+		Status:     domain.MessageStatusSent,
+	}
+	s.Require().NoError(s.repo.SaveMessage(ctx, quoted))
+
+	reply := domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   testdata.Bob.UserID,
+		ReceiverID: testdata.Alice.UserID,
+		CreatedAt:  now.Add(time.Second),
+		Content:    "Sounds good",
+		Status:     domain.MessageStatusSent,
+		ReplyTo:    domain.NewQuote(quoted),
+	}
+	s.Require().NoError(s.repo.SaveMessage(ctx, reply))
+
+	got, err := s.repo.GetMessageByID(ctx, domain.MessageID{ID: reply.ID})
+	s.Require().NoError(err)
+	s.Require().NotNil(got.ReplyTo)
+	s.Equal(quoted.ID, got.ReplyTo.ID)
+	s.Equal("Dinner at eight?", got.ReplyTo.Snippet)
+
+	// The quote follows later changes to the quoted message
+	s.Require().NoError(s.repo.DeleteMessage(ctx, quoted.ID, now.Add(2*time.Second)))
+
+	page, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(page.Messages, 2)
+	s.Require().NotNil(page.Messages[0].ReplyTo)
+	s.True(page.Messages[0].ReplyTo.Deleted)
+	s.Empty(page.Messages[0].ReplyTo.Snippet)
+	s.Nil(page.Messages[1].ReplyTo)
+}
+
+func (s *TestSuite) TestAttachments() {
+	ctx := context.Background()
+	chatID := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	attachment := domain.NewAttachment(testdata.Alice.UserID, chatID, "photo.png")
+	attachment.MimeType = "image/png"
+	attachment.Size = 2048
+	attachment.Checksum = strings.Repeat("ab", 32)
+	attachment.Width = 640
+	attachment.Height = 480
+	s.Require().NoError(s.repo.SaveAttachment(ctx, attachment))
+
+	got, err := s.repo.GetAttachment(ctx, attachment.ID)
+	s.Require().NoError(err)
+	s.Equal(attachment.FileName, got.FileName)
+	s.Equal(attachment.Size, got.Size)
+	s.Equal(640, got.Width)
+	s.Equal(attachment.URL, got.URL)
+
+	msg := domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  now,
+		Status:     domain.MessageStatusSent,
+	}
+	msg.Attach(*got)
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg))
+
+	page, err := s.repo.GetMessages(ctx, chatID, domain.MessagePage{Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(page.Messages, 1)
+	s.Equal(domain.MessageKindImage, page.Messages[0].Kind)
+	s.Empty(page.Messages[0].Content)
+	s.Require().NotNil(page.Messages[0].Attachment)
+	s.Equal(attachment.Checksum, page.Messages[0].Attachment.Checksum)
+
+	// A file is sent at most once
+	again := msg
+	again.ID = domain.NewMessageID()
+	again.CreatedAt = now.Add(time.Second)
+	s.ErrorIs(s.repo.SaveMessage(ctx, again), domain.ErrAttachmentInUse)
+
+	// Deleting the message for everyone removes the file with it
+	s.Require().NoError(s.repo.DeleteMessage(ctx, msg.ID, now.Add(2*time.Second)))
+
+	_, err = s.repo.GetAttachment(ctx, attachment.ID)
+	s.ErrorIs(err, domain.ErrAttachmentNotFound)
+
+	deleted, err := s.repo.GetMessageByID(ctx, domain.MessageID{ID: msg.ID})
+	s.Require().NoError(err)
+	s.Nil(deleted.Attachment)
+}
+
+func (s *TestSuite) TestAttachmentsBlockedAndExpired() {
+	ctx := context.Background()
+	newAttachment := func(uploaderID, chatID string, createdAt time.Time) domain.Attachment {
+		attachment := domain.NewAttachment(uploaderID, chatID, "notes.txt")
+		attachment.MimeType = "text/plain"
+		attachment.Size = 5
+		attachment.Checksum = strings.Repeat("ab", 32)
+		attachment.CreatedAt = createdAt
+		return attachment
+	}
+
+	// Eve blocked Alice, so Alice cannot upload to their chat either
+	s.Require().NoError(s.blockRepo.BlockUser(ctx, domain.Block{BlockerID: testdata.Eve.UserID, BlockedID: testdata.Alice.UserID, CreatedAt: time.Now().UTC()}))
+	blocked := newAttachment(testdata.Alice.UserID, domain.ComputeChatID(testdata.Alice.UserID, testdata.Eve.UserID), time.Now().UTC())
+	s.ErrorIs(s.repo.SaveAttachment(ctx, blocked), domain.ErrUserBlocked)
+
+	chatID := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+	old := time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Microsecond)
+	unsent := newAttachment(testdata.Alice.UserID, chatID, old)
+	sent := newAttachment(testdata.Alice.UserID, chatID, old)
+	recent := newAttachment(testdata.Alice.UserID, chatID, time.Now().UTC())
+	for _, attachment := range []domain.Attachment{unsent, sent, recent} {
+		s.Require().NoError(s.repo.SaveAttachment(ctx, attachment))
+	}
+
+	msg := domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  time.Now().UTC(),
+		Status:     domain.MessageStatusSent,
+	}
+	msg.Attach(sent)
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg))
+
+	// Only the old upload that was never sent expires
+	deleted, err := s.repo.DeleteExpiredAttachments(ctx, time.Now().UTC().Add(-24*time.Hour), 10)
+	s.Require().NoError(err)
+	s.Equal([]string{unsent.ID}, deleted)
+
+	_, err = s.repo.GetAttachment(ctx, unsent.ID)
+	s.ErrorIs(err, domain.ErrAttachmentNotFound)
+	for _, kept := range []string{sent.ID, recent.ID} {
+		_, err = s.repo.GetAttachment(ctx, kept)
+		s.NoError(err)
+	}
+}
+
+func (s *TestSuite) TestSearchMessages() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	group := domain.NewGroupChat("Planning", testdata.Bob.UserID, []string{testdata.Charlie.UserID})
+	s.Require().NoError(s.chatRepo.CreateGroupChat(ctx, group))
+
+	save := func(senderID, receiverID, content string, at time.Duration) domain.Message {
+		msg := domain.Message{
+			ID:         domain.NewMessageID(),
+			SenderID:   senderID,
+			ReceiverID: receiverID,
+			CreatedAt:  now.Add(at),
+			Content:    content,
+			Status:     domain.MessageStatusSent,
+		}
+		s.Require().NoError(s.repo.SaveMessage(ctx, msg))
+		return msg
+	}
+	lunch := save(testdata.Alice.UserID, testdata.Bob.UserID, "Lunch tomorrow at Tom & Jerry's?", time.Second)
+	lunchLunch := save(testdata.Bob.UserID, testdata.Alice.UserID, "lunch lunch lunch", 2*time.Second)
+	save(testdata.Alice.UserID, testdata.Charlie.UserID, "Dinner instead", 3*time.Second)
+	save(testdata.Bob.UserID, group.ID, "Lunch with the team", 4*time.Second) // Alice is not a member
+	hidden := save(testdata.Charlie.UserID, testdata.Alice.UserID, "lunch is on me", 5*time.Second)
+	s.Require().NoError(s.repo.HideMessage(ctx, hidden.ID, testdata.Alice.UserID, now))
+
+	result, err := s.repo.SearchMessages(ctx, domain.MessageSearch{UserID: testdata.Alice.UserID, Text: "lunch", Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(result.Hits, 2)
+	s.False(result.HasMore)
+
+	// More occurrences rank higher; snippets are escaped with the matches marked
+	s.Equal(lunchLunch.ID, result.Hits[0].Message.ID)
+	s.Equal(lunch.ID, result.Hits[1].Message.ID)
+	s.Greater(result.Hits[0].Rank, result.Hits[1].Rank)
+	s.Equal(domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID), result.Hits[1].ChatID)
+	s.Contains(result.Hits[1].Snippet, "<mark>Lunch</mark>")
+	s.Contains(result.Hits[1].Snippet, "Tom &amp; Jerry")
+
+	// Keyset pagination continues after the last hit
+	first, err := s.repo.SearchMessages(ctx, domain.MessageSearch{UserID: testdata.Alice.UserID, Text: "lunch", Limit: 1})
+	s.Require().NoError(err)
+	s.Require().True(first.HasMore)
+	cursor, err := domain.ParseSearchCursor(first.NextCursor)
+	s.Require().NoError(err)
+
+	second, err := s.repo.SearchMessages(ctx, domain.MessageSearch{UserID: testdata.Alice.UserID, Text: "lunch", Limit: 1, Cursor: cursor})
+	s.Require().NoError(err)
+	s.Require().Len(second.Hits, 1)
+	s.Equal(lunch.ID, second.Hits[0].Message.ID)
+	s.False(second.HasMore)
+
+	// Filters narrow the results down
+	filtered, err := s.repo.SearchMessages(ctx, domain.MessageSearch{
+		UserID:   testdata.Alice.UserID,
+		Text:     "lunch",
+		ChatID:   domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID),
+		SenderID: testdata.Alice.UserID,
+		From:     now,
+		To:       now.Add(2 * time.Second),
+		Limit:    10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(filtered.Hits, 1)
+	s.Equal(lunch.ID, filtered.Hits[0].Message.ID)
+
+	// Group members find group messages; deleted messages are never found
+	forCharlie, err := s.repo.SearchMessages(ctx, domain.MessageSearch{UserID: testdata.Charlie.UserID, Text: "team", Limit: 10})
+	s.Require().NoError(err)
+	s.Len(forCharlie.Hits, 1)
+
+	s.Require().NoError(s.repo.DeleteMessage(ctx, lunchLunch.ID, now.Add(time.Minute)))
+	result, err = s.repo.SearchMessages(ctx, domain.MessageSearch{UserID: testdata.Alice.UserID, Text: "lunch", Limit: 10})
+	s.Require().NoError(err)
+	s.Len(result.Hits, 1)
+}
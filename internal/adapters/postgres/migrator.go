@@ -0,0 +1,390 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+
+	"messaging-app/internal/ports"
+)
+
+// migrationLockID is the advisory lock held while migrating, so concurrent runs apply each migration once
+const migrationLockID int64 = 4_873_512_096_337_201
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type migrationAction int
+
+const (
+	migrationUp migrationAction = iota
+	migrationDown
+	migrationBaseline // Records migrations as applied without running them
+)
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string // Empty when the migration cannot be rolled back
+}
+
+// MigrationStatus is a migration and when it was applied, if it was
+// Migrations recorded in the database but unknown to this binary have no SQL
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type appliedMigration struct {
+	name      string
+	appliedAt time.Time
+}
+
+// migrationExecer is satisfied by both connections and transactions
+type migrationExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Migrator applies migrations in version order and records them in a version table
+type Migrator struct {
+	Table      string // Version table, schema_migrations by default
+	db         *sql.DB
+	migrations []Migration
+	logger     ports.Logger
+}
+
+func NewMigrator(db *sql.DB, files fs.FS, logger ports.Logger) (*Migrator, error) {
+	migrations, err := LoadMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		Table:      "schema_migrations",
+		db:         db,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// LoadMigrations reads the NNN_name.up.sql and NNN_name.down.sql files in files, ordered by version
+func LoadMigrations(files fs.FS) ([]Migration, error) {
+	paths, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, path := range paths {
+		match := migrationFilePattern.FindStringSubmatch(path)
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", path)
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", path, err)
+		}
+
+		content, err := fs.ReadFile(files, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", path, err)
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files named %s and %s", version, migration.Name, match[2])
+		}
+
+		target := &migration.Up
+		if match[3] == "down" {
+			target = &migration.Down
+		}
+		if *target != "" {
+			return nil, fmt.Errorf("duplicate %s migration for version %d", match[3], version)
+		}
+		*target = string(content)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration and returns them
+// A dry run applies them in a transaction that is rolled back, so it also catches failing SQL
+func (m *Migrator) Up(ctx context.Context, dryRun bool) ([]Migration, error) {
+	return m.run(ctx, dryRun, migrationUp, func(applied map[int]appliedMigration) ([]Migration, error) {
+		return m.pending(applied, len(m.migrations)), nil
+	})
+}
+
+// Baseline records the pending migrations up to version as applied without running them,
+// adopting a database whose schema was created by other means
+func (m *Migrator) Baseline(ctx context.Context, version int) ([]Migration, error) {
+	last := 0
+	for last < len(m.migrations) && m.migrations[last].Version <= version {
+		last++
+	}
+	if last == 0 || m.migrations[last-1].Version != version {
+		return nil, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.run(ctx, false, migrationBaseline, func(applied map[int]appliedMigration) ([]Migration, error) {
+		return m.pending(applied, last), nil
+	})
+}
+
+// Down rolls back the latest steps applied migrations and returns them in the order they were rolled back
+func (m *Migrator) Down(ctx context.Context, steps int, dryRun bool) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("number of migrations to roll back must be positive, got %d", steps)
+	}
+
+	return m.run(ctx, dryRun, migrationDown, func(applied map[int]appliedMigration) ([]Migration, error) {
+		// Rolling back past a migration this binary does not know would leave it behind
+		for version, migration := range applied {
+			if m.find(version) == nil {
+				return nil, fmt.Errorf("database has migration %d_%s unknown to this binary", version, migration.name)
+			}
+		}
+
+		var rollback []Migration
+		for i := len(m.migrations) - 1; i >= 0 && len(rollback) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return nil, fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+			rollback = append(rollback, migration)
+		}
+		return rollback, nil
+	})
+}
+
+// Status lists every migration with the time it was applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var exists bool
+	if err := m.db.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, m.Table).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check migration table: %w", err)
+	}
+
+	applied := map[int]appliedMigration{}
+	if exists {
+		var err error
+		if applied, err = m.appliedMigrations(ctx, m.db); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	for version, record := range applied {
+		if m.find(version) == nil {
+			statuses = append(statuses, MigrationStatus{
+				Migration: Migration{Version: version, Name: record.name},
+				AppliedAt: &record.appliedAt,
+			})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// run holds the advisory lock on a dedicated connection while it applies the planned migrations
+// Each migration commits with its version record; a dry run shares one transaction and rolls it back
+func (m *Migrator) run(ctx context.Context, dryRun bool, action migrationAction, plan func(map[int]appliedMigration) ([]Migration, error)) ([]Migration, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			m.logger.Error("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	if dryRun {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		planned, err := m.plan(ctx, tx, plan)
+		if err != nil {
+			return nil, err
+		}
+		for _, migration := range planned {
+			if err := m.execute(ctx, tx, migration, action); err != nil {
+				return nil, err
+			}
+		}
+		return planned, nil
+	}
+
+	planned, err := m.plan(ctx, conn, plan)
+	if err != nil {
+		return nil, err
+	}
+	for _, migration := range planned {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		if err := m.execute(ctx, tx, migration, action); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		switch action {
+		case migrationUp:
+			m.logger.Info("Applied migration", "version", migration.Version, "name", migration.Name)
+		case migrationDown:
+			m.logger.Info("Rolled back migration", "version", migration.Version, "name", migration.Name)
+		case migrationBaseline:
+			m.logger.Info("Recorded migration as applied", "version", migration.Version, "name", migration.Name)
+		}
+	}
+
+	return planned, nil
+}
+
+// plan creates the version table if needed and picks the migrations to run
+func (m *Migrator) plan(ctx context.Context, db migrationExecer, plan func(map[int]appliedMigration) ([]Migration, error)) ([]Migration, error) {
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)
+	`, pq.QuoteIdentifier(m.Table)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration table: %w", err)
+	}
+
+	applied, err := m.appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan(applied)
+}
+
+// execute runs one migration and updates its version record in the same transaction
+func (m *Migrator) execute(ctx context.Context, tx *sql.Tx, migration Migration, action migrationAction) error {
+	table := pq.QuoteIdentifier(m.Table)
+
+	if action != migrationDown {
+		if action == migrationUp {
+			if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			INSERT INTO %s (version, name, applied_at)
+			VALUES ($1, $2, $3)
+		`, table), migration.Version, migration.Name, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("failed to record migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+		return fmt.Errorf("failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE version = $1`, table), migration.Version)
+	if err != nil {
+		return fmt.Errorf("failed to record rollback of migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func (m *Migrator) appliedMigrations(ctx context.Context, db migrationExecer) (map[int]appliedMigration, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT version, name, applied_at
+		FROM %s
+	`, pq.QuoteIdentifier(m.Table)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var version int
+		var record appliedMigration
+		if err := rows.Scan(&version, &record.name, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		applied[version] = record
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// pending returns the migrations among the first count that are not applied
+func (m *Migrator) pending(applied map[int]appliedMigration, count int) []Migration {
+	var pending []Migration
+	for _, migration := range m.migrations[:count] {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
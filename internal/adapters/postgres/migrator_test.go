@@ -0,0 +1,193 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"messaging-app/internal/adapters/postgres"
+	"messaging-app/internal/testutils"
+	"messaging-app/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	files := fstest.MapFS{
+		"002_add_widget_color.up.sql":   {Data: []byte("ALTER TABLE widgets ADD COLUMN color TEXT;")},
+		"002_add_widget_color.down.sql": {Data: []byte("ALTER TABLE widgets DROP COLUMN color;")},
+		"001_create_widgets.up.sql":     {Data: []byte("CREATE TABLE widgets (id INT);")},
+		"migrations.go":                 {Data: []byte("package migrations")},
+	}
+
+	loaded, err := postgres.LoadMigrations(files)
+	require.NoError(t, err)
+	assert.Equal(t, []postgres.Migration{
+		{Version: 1, Name: "create_widgets", Up: "CREATE TABLE widgets (id INT);"},
+		{Version: 2, Name: "add_widget_color", Up: "ALTER TABLE widgets ADD COLUMN color TEXT;", Down: "ALTER TABLE widgets DROP COLUMN color;"},
+	}, loaded)
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"bad name": {
+			"create_widgets.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"missing up": {
+			"001_create_widgets.down.sql": {Data: []byte("SELECT 1;")},
+		},
+		"mismatched names": {
+			"001_create_widgets.up.sql":   {Data: []byte("SELECT 1;")},
+			"001_create_gadgets.down.sql": {Data: []byte("SELECT 1;")},
+		},
+		"duplicate version": {
+			"1_create_widgets.up.sql":   {Data: []byte("SELECT 1;")},
+			"001_create_widgets.up.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+
+	for name, files := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := postgres.LoadMigrations(files)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	loaded, err := postgres.LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	// Versions are contiguous and every migration can be rolled back
+	for i, migration := range loaded {
+		assert.Equal(t, i+1, migration.Version)
+		assert.NotEmpty(t, migration.Down, "migration %d_%s has no down file", migration.Version, migration.Name)
+	}
+}
+
+func (s *TestSuite) TestMigratorIntegration() {
+	ctx := context.Background()
+	files := fstest.MapFS{
+		"001_create_widgets.up.sql":     {Data: []byte("CREATE TABLE migrator_test_widgets (id INT PRIMARY KEY);")},
+		"001_create_widgets.down.sql":   {Data: []byte("DROP TABLE migrator_test_widgets;")},
+		"002_add_widget_color.up.sql":   {Data: []byte("ALTER TABLE migrator_test_widgets ADD COLUMN color TEXT;")},
+		"002_add_widget_color.down.sql": {Data: []byte("ALTER TABLE migrator_test_widgets DROP COLUMN color;")},
+	}
+
+	migrator, err := postgres.NewMigrator(s.db, files, &testutils.TestLogger{T: s.T()})
+	s.Require().NoError(err)
+	migrator.Table = "migrator_test_versions"
+	defer s.db.Exec("DROP TABLE IF EXISTS migrator_test_widgets, migrator_test_versions")
+
+	statuses, err := migrator.Status(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(statuses, 2)
+	s.Nil(statuses[0].AppliedAt)
+
+	// A dry run changes nothing, not even the version table
+	planned, err := migrator.Up(ctx, true)
+	s.Require().NoError(err)
+	s.Len(planned, 2)
+	s.False(s.tableExists("migrator_test_widgets"))
+	s.False(s.tableExists("migrator_test_versions"))
+
+	applied, err := migrator.Up(ctx, false)
+	s.Require().NoError(err)
+	s.Len(applied, 2)
+	_, err = s.db.Exec("INSERT INTO migrator_test_widgets (id, color) VALUES (1, 'red')")
+	s.Require().NoError(err)
+
+	applied, err = migrator.Up(ctx, false)
+	s.Require().NoError(err)
+	s.Empty(applied)
+
+	statuses, err = migrator.Status(ctx)
+	s.Require().NoError(err)
+	for _, status := range statuses {
+		s.NotNil(status.AppliedAt)
+	}
+
+	// Rolling back goes newest first
+	rolledBack, err := migrator.Down(ctx, 1, false)
+	s.Require().NoError(err)
+	s.Require().Len(rolledBack, 1)
+	s.Equal(2, rolledBack[0].Version)
+	s.True(s.tableExists("migrator_test_widgets"))
+
+	rolledBack, err = migrator.Down(ctx, 5, false)
+	s.Require().NoError(err)
+	s.Len(rolledBack, 1)
+	s.False(s.tableExists("migrator_test_widgets"))
+
+	_, err = migrator.Down(ctx, 0, false)
+	s.Error(err)
+}
+
+func (s *TestSuite) TestMigratorBaseline() {
+	ctx := context.Background()
+	files := fstest.MapFS{
+		"001_create_widgets.up.sql":     {Data: []byte("CREATE TABLE migrator_test_widgets (id INT PRIMARY KEY);")},
+		"002_add_widget_color.up.sql":   {Data: []byte("ALTER TABLE migrator_test_widgets ADD COLUMN color TEXT;")},
+		"002_add_widget_color.down.sql": {Data: []byte("ALTER TABLE migrator_test_widgets DROP COLUMN color;")},
+	}
+
+	migrator, err := postgres.NewMigrator(s.db, files, &testutils.TestLogger{T: s.T()})
+	s.Require().NoError(err)
+	migrator.Table = "migrator_test_versions"
+	defer s.db.Exec("DROP TABLE IF EXISTS migrator_test_widgets, migrator_test_versions")
+
+	// The schema of the first migration already exists
+	_, err = s.db.Exec("CREATE TABLE migrator_test_widgets (id INT PRIMARY KEY)")
+	s.Require().NoError(err)
+
+	_, err = migrator.Baseline(ctx, 3)
+	s.Error(err)
+
+	recorded, err := migrator.Baseline(ctx, 1)
+	s.Require().NoError(err)
+	s.Require().Len(recorded, 1)
+	s.Equal(1, recorded[0].Version)
+
+	applied, err := migrator.Up(ctx, false)
+	s.Require().NoError(err)
+	s.Require().Len(applied, 1)
+	s.Equal(2, applied[0].Version)
+}
+
+func (s *TestSuite) TestMigratorConcurrentRuns() {
+	ctx := context.Background()
+	files := fstest.MapFS{
+		"001_create_widgets.up.sql":   {Data: []byte("CREATE TABLE migrator_test_widgets (id INT PRIMARY KEY);")},
+		"001_create_widgets.down.sql": {Data: []byte("DROP TABLE migrator_test_widgets;")},
+	}
+	defer s.db.Exec("DROP TABLE IF EXISTS migrator_test_widgets, migrator_test_versions")
+
+	// Without the lock the second run would fail creating the table again
+	results := make(chan int, 2)
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			migrator, err := postgres.NewMigrator(s.db, files, &testutils.TestLogger{T: s.T()})
+			if err != nil {
+				errs <- err
+				return
+			}
+			migrator.Table = "migrator_test_versions"
+			applied, err := migrator.Up(ctx, false)
+			errs <- err
+			results <- len(applied)
+		}()
+	}
+
+	s.Require().NoError(<-errs)
+	s.Require().NoError(<-errs)
+	s.Equal(1, <-results+<-results)
+}
+
+func (s *TestSuite) tableExists(name string) bool {
+	var exists bool
+	s.Require().NoError(s.db.QueryRow(`SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists))
+	return exists
+}
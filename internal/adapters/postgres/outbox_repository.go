@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+type PostgreSQLOutboxRepository struct {
+	db     *sql.DB
+	logger ports.Logger
+}
+
+func NewPostgreSQLOutboxRepository(db *sql.DB, logger ports.Logger) *PostgreSQLOutboxRepository {
+	return &PostgreSQLOutboxRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ClaimEvents implements ports.OutboxRepository
+func (r *PostgreSQLOutboxRepository) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxEvent, error) {
+	now := time.Now().UTC()
+
+	// SKIP LOCKED lets several relays poll concurrently without claiming the same rows
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE outbox
+		SET attempts = attempts + 1,
+		    next_attempt_at = $3
+		WHERE id IN (
+			SELECT id
+			FROM outbox
+			WHERE failed_at IS NULL AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, recipient, payload, attempts, next_attempt_at, created_at, trace_context, delivered_to
+	`, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.OutboxEvent
+	for rows.Next() {
+		var event domain.OutboxEvent
+		var payload, traceContext []byte
+		if err := rows.Scan(
+			&event.ID,
+			&event.EventType,
+			&event.Recipient,
+			&payload,
+			&event.Attempts,
+			&event.NextAttemptAt,
+			&event.CreatedAt,
+			&traceContext,
+			pq.Array(&event.DeliveredTo),
+		); err != nil {
+			return nil, fmt.Errorf("scan outbox event: %w", err)
+		}
+		event.Payload = payload
+		if traceContext != nil {
+			if err := json.Unmarshal(traceContext, &event.TraceContext); err != nil {
+				return nil, fmt.Errorf("decode trace context of outbox event %d: %w", event.ID, err)
+			}
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+// DeleteEvent implements ports.OutboxRepository
+func (r *PostgreSQLOutboxRepository) DeleteEvent(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM outbox WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("delete outbox event %d: %w", id, err)
+	}
+	return nil
+}
+
+// RetryEvent implements ports.OutboxRepository
+func (r *PostgreSQLOutboxRepository) RetryEvent(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string, deliveredTo []string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE outbox
+		SET next_attempt_at = $2, last_error = $3, delivered_to = COALESCE($4::text[], '{}')
+		WHERE id = $1
+	`, id, nextAttemptAt, lastError, pq.Array(deliveredTo))
+	if err != nil {
+		return fmt.Errorf("reschedule outbox event %d: %w", id, err)
+	}
+	return nil
+}
+
+// FailEvent implements ports.OutboxRepository
+func (r *PostgreSQLOutboxRepository) FailEvent(ctx context.Context, id int64, lastError string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE outbox
+		SET failed_at = $2, last_error = $3
+		WHERE id = $1
+	`, id, time.Now().UTC(), lastError)
+	if err != nil {
+		return fmt.Errorf("fail outbox event %d: %w", id, err)
+	}
+	return nil
+}
+
+// OldestPendingEventAt implements ports.OutboxRepository
+func (r *PostgreSQLOutboxRepository) OldestPendingEventAt(ctx context.Context) (*time.Time, error) {
+	var oldest *time.Time
+	err := r.db.QueryRowContext(ctx, `
+		SELECT MIN(created_at)
+		FROM outbox
+		WHERE failed_at IS NULL
+	`).Scan(&oldest)
+	if err != nil {
+		return nil, fmt.Errorf("query oldest outbox event: %w", err)
+	}
+	return oldest, nil
+}
+
+// insertOutboxEvents writes events as part of the caller's transaction
+// Events without a trace context take the one of ctx, so the relay continues the trace of the request
+func insertOutboxEvents(ctx context.Context, tx *sql.Tx, events []domain.OutboxEvent) error {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	for _, event := range events {
+		if len(event.TraceContext) == 0 && len(carrier) > 0 {
+			event.TraceContext = carrier
+		}
+
+		var traceContext interface{} // NULL when untraced
+		if len(event.TraceContext) > 0 {
+			encoded, err := json.Marshal(event.TraceContext)
+			if err != nil {
+				return fmt.Errorf("encode trace context: %w", err)
+			}
+			traceContext = encoded
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO outbox (event_type, recipient, payload, next_attempt_at, created_at, trace_context)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, event.EventType, event.Recipient, []byte(event.Payload), event.NextAttemptAt, event.CreatedAt, traceContext)
+		if err != nil {
+			return fmt.Errorf("insert outbox event: %w", err)
+		}
+	}
+	return nil
+}
+
+// insertStatusEvents builds the events for a status change and writes them as part of the caller's transaction
+func insertStatusEvents(ctx context.Context, tx *sql.Tx, affected domain.MessageRange, build domain.OutboxEventsFunc) error {
+	if affected.Count == 0 || build == nil {
+		return nil
+	}
+
+	events, err := build(affected)
+	if err != nil {
+		return fmt.Errorf("build outbox events: %w", err)
+	}
+	return insertOutboxEvents(ctx, tx, events)
+}
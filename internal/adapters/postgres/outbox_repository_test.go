@@ -0,0 +1,159 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+	"messaging-app/internal/testutils"
+	"messaging-app/testdata"
+)
+
+func (s *TestSuite) TestOutboxRepositoryIntegration() {
+	ctx := context.Background()
+
+	msg := testdata.ValidMessages()[0]
+	msg.ID = domain.NewMessageID()
+	event, err := domain.NewMessageOutboxEvent(msg)
+	s.Require().NoError(err)
+
+	// SaveMessage writes the event in the same transaction
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg, event))
+
+	// A duplicate message leaves no extra event behind
+	s.Require().ErrorIs(s.repo.SaveMessage(ctx, msg, event), domain.ErrDuplicateMessage)
+
+	// ClaimEvents hides claimed events for the lease
+	claimed, err := s.outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Require().Len(claimed, 1)
+	s.Equal(domain.MessageTypeNewMessage, claimed[0].EventType)
+	s.Equal(msg.ReceiverID, claimed[0].Recipient)
+	s.Equal(1, claimed[0].Attempts)
+
+	var payload domain.Message
+	s.Require().NoError(json.Unmarshal(claimed[0].Payload, &payload))
+	s.Equal(msg.Content, payload.Content)
+
+	again, err := s.outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Empty(again)
+
+	s.Empty(claimed[0].DeliveredTo)
+
+	// RetryEvent makes the event due again and remembers who it reached
+	s.Require().NoError(s.outboxRepo.RetryEvent(ctx, claimed[0].ID, time.Now().UTC().Add(-time.Second), "boom", []string{testdata.Bob.UserID}))
+	retried, err := s.outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Require().Len(retried, 1)
+	s.Equal(2, retried[0].Attempts)
+	s.Equal([]string{testdata.Bob.UserID}, retried[0].DeliveredTo)
+
+	// FailEvent parks it for good
+	s.Require().NoError(s.outboxRepo.FailEvent(ctx, retried[0].ID, "boom"))
+	s.Require().NoError(s.outboxRepo.RetryEvent(ctx, retried[0].ID, time.Now().UTC().Add(-time.Second), "boom", nil))
+	parked, err := s.outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Empty(parked)
+
+	// MarkMessagesUpToRead only builds the event when something changed
+	var received []domain.MessageRange
+	events := func(affected domain.MessageRange) ([]domain.OutboxEvent, error) {
+		received = append(received, affected)
+		event, err := ports.NewStatusOutboxEvent(msg.SenderID, ports.StatusUpdate{
+			MessageID: msg.Key(),
+			Status:    domain.MessageStatusRead,
+			UpdatedBy: msg.ReceiverID,
+			UpdatedAt: time.Now().UTC(),
+			Range:     &affected,
+		})
+		return []domain.OutboxEvent{event}, err
+	}
+
+	affected, err := s.repo.MarkMessagesUpToRead(ctx, msg.Key(), events)
+	s.Require().NoError(err)
+	s.Equal(int64(1), affected)
+
+	affected, err = s.repo.MarkMessagesUpToRead(ctx, msg.Key(), events)
+	s.Require().NoError(err)
+	s.Equal(int64(0), affected)
+
+	s.Require().Len(received, 1)
+	s.Equal(int64(1), received[0].Count)
+	s.True(received[0].From.Equal(msg.CreatedAt))
+	s.True(received[0].To.Equal(msg.CreatedAt))
+
+	statusEvents, err := s.outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Require().Len(statusEvents, 1)
+	s.Equal(domain.MessageTypeStatusUpdate, statusEvents[0].EventType)
+
+	// DeleteEvent removes relayed events
+	s.Require().NoError(s.outboxRepo.DeleteEvent(ctx, statusEvents[0].ID))
+}
+
+func (s *TestSuite) TestOutboxOldestPendingEvent() {
+	ctx := context.Background()
+
+	oldest, err := s.outboxRepo.OldestPendingEventAt(ctx)
+	s.Require().NoError(err)
+	s.Nil(oldest)
+
+	msg := testdata.ValidMessages()[0]
+	msg.ID = domain.NewMessageID()
+	event, err := domain.NewMessageOutboxEvent(msg)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg, event))
+
+	// Events being retried are still pending
+	claimed, err := s.outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Require().Len(claimed, 1)
+
+	oldest, err = s.outboxRepo.OldestPendingEventAt(ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(oldest)
+	s.WithinDuration(event.CreatedAt, *oldest, time.Millisecond)
+
+	// Failed events no longer count
+	s.Require().NoError(s.outboxRepo.FailEvent(ctx, claimed[0].ID, "boom"))
+	oldest, err = s.outboxRepo.OldestPendingEventAt(ctx)
+	s.Require().NoError(err)
+	s.Nil(oldest)
+}
+
+func (s *TestSuite) TestOutboxTraceContext() {
+	spans := testutils.NewTestTracer(s.T())
+	ctx, request := otel.Tracer("test").Start(context.Background(), "request")
+	traceID := request.SpanContext().TraceID().String()
+
+	msg := testdata.ValidMessages()[0]
+	msg.ID = domain.NewMessageID()
+	event, err := domain.NewMessageOutboxEvent(msg)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.SaveMessage(ctx, msg, event))
+	request.End()
+
+	// The repository call is a child of the request
+	ended := spans.GetSpans()
+	s.Require().Len(ended, 2)
+	s.Equal("MessageRepository save_message", ended[0].Name)
+	s.Equal(request.SpanContext().SpanID(), ended[0].Parent.SpanID())
+
+	// Events saved without a trace have none to continue
+	untraced := testdata.ValidMessages()[1]
+	untraced.ID = domain.NewMessageID()
+	untracedEvent, err := domain.NewMessageOutboxEvent(untraced)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.SaveMessage(context.Background(), untraced, untracedEvent))
+
+	claimed, err := s.outboxRepo.ClaimEvents(context.Background(), 10, time.Minute)
+	s.Require().NoError(err)
+	s.Require().Len(claimed, 2)
+	s.Contains(claimed[0].TraceContext["traceparent"], traceID)
+	s.Nil(claimed[1].TraceContext)
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+type PostgreSQLPresenceRepository struct {
+	db     *sql.DB
+	logger ports.Logger
+}
+
+func NewPostgreSQLPresenceRepository(db *sql.DB, logger ports.Logger) *PostgreSQLPresenceRepository {
+	return &PostgreSQLPresenceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// TouchPresence implements ports.PresenceRepository
+func (r *PostgreSQLPresenceRepository) TouchPresence(ctx context.Context, userID string, seenAt time.Time) (bool, error) {
+	// The previous row is locked so concurrent heartbeats announce the user once
+	var cameOnline bool
+	err := r.db.QueryRowContext(ctx, `
+		WITH previous AS (
+			SELECT online
+			FROM user_presence
+			WHERE user_id = $1
+			FOR UPDATE
+		)
+		INSERT INTO user_presence (user_id, last_seen, online)
+		VALUES ($1, $2, TRUE)
+		ON CONFLICT (user_id) DO UPDATE
+		SET last_seen = GREATEST(user_presence.last_seen, EXCLUDED.last_seen),
+		    online = TRUE
+		RETURNING COALESCE((SELECT NOT online FROM previous), TRUE)
+	`, userID, seenAt.UTC()).Scan(&cameOnline)
+	if err != nil {
+		return false, fmt.Errorf("failed to touch presence: %w", err)
+	}
+
+	return cameOnline, nil
+}
+
+// ExpirePresence implements ports.PresenceRepository
+func (r *PostgreSQLPresenceRepository) ExpirePresence(ctx context.Context, cutoff time.Time) ([]domain.Presence, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE user_presence
+		SET online = FALSE
+		WHERE online AND last_seen < $1
+		RETURNING user_id, last_seen
+	`, cutoff.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire presence: %w", err)
+	}
+	defer rows.Close()
+
+	var expired []domain.Presence
+	for rows.Next() {
+		presence := domain.Presence{Status: domain.StatusOffline}
+		if err := rows.Scan(&presence.UserID, &presence.LastSeen); err != nil {
+			return nil, fmt.Errorf("failed to scan presence: %w", err)
+		}
+		expired = append(expired, presence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating presence: %w", err)
+	}
+
+	return expired, nil
+}
+
+// GetContactIDs implements ports.PresenceRepository
+func (r *PostgreSQLPresenceRepository) GetContactIDs(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT CASE WHEN sender_id = $1 THEN receiver_id ELSE sender_id END
+		FROM messages
+		WHERE (sender_id = $1 OR receiver_id = $1)
+		  AND NOT EXISTS (SELECT 1 FROM chats c WHERE c.id = messages.receiver_id)
+
+		UNION
+
+		SELECT others.user_id
+		FROM chat_members mine
+		JOIN chat_members others ON others.chat_id = mine.chat_id
+		WHERE mine.user_id = $1 AND others.user_id != $1
+
+		ORDER BY 1
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contacts: %w", err)
+	}
+	defer rows.Close()
+
+	var contactIDs []string
+	for rows.Next() {
+		var contactID string
+		if err := rows.Scan(&contactID); err != nil {
+			return nil, fmt.Errorf("failed to scan contact: %w", err)
+		}
+		contactIDs = append(contactIDs, contactID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contacts: %w", err)
+	}
+
+	return contactIDs, nil
+}
@@ -0,0 +1,82 @@
+package postgres_test
+
+import (
+	"context"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/testdata"
+)
+
+func (s *TestSuite) TestPresenceRepositoryIntegration() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	// The first heartbeat brings the user online, later ones keep them there
+	cameOnline, err := s.presenceRepo.TouchPresence(ctx, testdata.Alice.UserID, now.Add(-2*time.Minute))
+	s.Require().NoError(err)
+	s.True(cameOnline)
+
+	cameOnline, err = s.presenceRepo.TouchPresence(ctx, testdata.Alice.UserID, now.Add(-90*time.Second))
+	s.Require().NoError(err)
+	s.False(cameOnline)
+
+	_, err = s.presenceRepo.TouchPresence(ctx, testdata.Bob.UserID, now)
+	s.Require().NoError(err)
+
+	// Only users silent since the cutoff expire, and only once
+	expired, err := s.presenceRepo.ExpirePresence(ctx, now.Add(-time.Minute))
+	s.Require().NoError(err)
+	s.Equal([]domain.Presence{{UserID: testdata.Alice.UserID, Status: domain.StatusOffline, LastSeen: now.Add(-90 * time.Second)}}, expired)
+
+	expired, err = s.presenceRepo.ExpirePresence(ctx, now.Add(-time.Minute))
+	s.Require().NoError(err)
+	s.Empty(expired)
+
+	cameOnline, err = s.presenceRepo.TouchPresence(ctx, testdata.Alice.UserID, now)
+	s.Require().NoError(err)
+	s.True(cameOnline)
+}
+
+func (s *TestSuite) TestPresenceContactsAndChatSessions() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	s.Require().NoError(s.repo.SaveMessage(ctx, domain.Message{
+		ID:         domain.NewMessageID(),
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: testdata.Bob.UserID,
+		CreatedAt:  now,
+		Content:    "Hi Bob",
+		Status:     domain.MessageStatusSent,
+	}))
+
+	group := domain.NewGroupChat("Launch", testdata.Alice.UserID, []string{testdata.Charlie.UserID})
+	s.Require().NoError(s.chatRepo.CreateGroupChat(ctx, group))
+
+	contacts, err := s.presenceRepo.GetContactIDs(ctx, testdata.Alice.UserID)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{testdata.Bob.UserID, testdata.Charlie.UserID}, contacts)
+
+	contacts, err = s.presenceRepo.GetContactIDs(ctx, testdata.Charlie.UserID)
+	s.Require().NoError(err)
+	s.Equal([]string{testdata.Alice.UserID}, contacts)
+
+	// Chat sessions expose the presence of the other participant
+	_, err = s.presenceRepo.TouchPresence(ctx, testdata.Bob.UserID, now)
+	s.Require().NoError(err)
+
+	sessions, err := s.repo.GetChatSessions(ctx, testdata.Alice.UserID, domain.ChatCursor{}, 10)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 2)
+	for _, session := range sessions {
+		if session.IsGroup() {
+			s.False(session.Online)
+			s.Nil(session.LastSeen)
+			continue
+		}
+		s.True(session.Online)
+		s.Require().NotNil(session.LastSeen)
+		s.True(now.Equal(*session.LastSeen))
+	}
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// rateLimitSweepInterval is how often each instance drops buckets that have refilled completely
+const rateLimitSweepInterval = time.Minute
+
+// PostgreSQLRateLimiter keeps token buckets in the database so limits hold across instances
+type PostgreSQLRateLimiter struct {
+	db     *sql.DB
+	logger ports.Logger
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgreSQLRateLimiter(db *sql.DB, logger ports.Logger) *PostgreSQLRateLimiter {
+	return &PostgreSQLRateLimiter{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Allow implements ports.RateLimiter
+func (l *PostgreSQLRateLimiter) Allow(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	now := time.Now().UTC()
+	l.sweep(ctx, now)
+
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.RateLimitDecision{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Missing buckets are created full, then locked so concurrent requests take tokens one at a time
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rate_limit_buckets (key, tokens, updated_at, full_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (key) DO NOTHING
+	`, key, float64(limit.Capacity()), now)
+	if err != nil {
+		return domain.RateLimitDecision{}, fmt.Errorf("failed to create rate limit bucket: %w", err)
+	}
+
+	var bucket domain.TokenBucket
+	err = tx.QueryRowContext(ctx, `
+		SELECT tokens, updated_at
+		FROM rate_limit_buckets
+		WHERE key = $1
+		FOR UPDATE
+	`, key).Scan(&bucket.Tokens, &bucket.UpdatedAt)
+	if err != nil {
+		return domain.RateLimitDecision{}, fmt.Errorf("failed to get rate limit bucket: %w", err)
+	}
+
+	bucket, decision := bucket.Take(limit, now)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE rate_limit_buckets
+		SET tokens = $2, updated_at = $3, full_at = $4
+		WHERE key = $1
+	`, key, bucket.Tokens, bucket.UpdatedAt, now.Add(decision.ResetAfter))
+	if err != nil {
+		return domain.RateLimitDecision{}, fmt.Errorf("failed to update rate limit bucket: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.RateLimitDecision{}, fmt.Errorf("failed to commit rate limit bucket: %w", err)
+	}
+
+	return decision, nil
+}
+
+// sweep deletes full buckets, which behave exactly like missing ones
+// Failures only delay the cleanup, so they are logged rather than returned
+func (l *PostgreSQLRateLimiter) sweep(ctx context.Context, now time.Time) {
+	l.mu.Lock()
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		l.mu.Unlock()
+		return
+	}
+	l.lastSweep = now
+	l.mu.Unlock()
+
+	result, err := l.db.ExecContext(ctx, `
+		DELETE FROM rate_limit_buckets
+		WHERE full_at <= $1
+	`, now)
+	if err != nil {
+		l.logger.Error("Failed to sweep rate limit buckets", "error", err)
+		return
+	}
+
+	if swept, err := result.RowsAffected(); err == nil && swept > 0 {
+		l.logger.Debug("Swept rate limit buckets", "count", swept)
+	}
+}
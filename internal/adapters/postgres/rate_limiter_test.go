@@ -0,0 +1,63 @@
+package postgres_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"messaging-app/internal/domain"
+)
+
+func (s *TestSuite) TestRateLimiterIntegration() {
+	ctx := context.Background()
+	limit := domain.RateLimit{Requests: 1, Window: time.Hour, Burst: 2}
+
+	decision, err := s.rateLimiter.Allow(ctx, "route|user:alice", limit)
+	s.Require().NoError(err)
+	s.True(decision.Allowed)
+	s.Equal(2, decision.Limit)
+	s.Equal(1, decision.Remaining)
+
+	decision, err = s.rateLimiter.Allow(ctx, "route|user:alice", limit)
+	s.Require().NoError(err)
+	s.True(decision.Allowed)
+	s.Equal(0, decision.Remaining)
+
+	decision, err = s.rateLimiter.Allow(ctx, "route|user:alice", limit)
+	s.Require().NoError(err)
+	s.False(decision.Allowed)
+	s.InDelta(time.Hour.Seconds(), decision.RetryAfter.Seconds(), 1)
+
+	// Keys are limited independently
+	decision, err = s.rateLimiter.Allow(ctx, "route|user:bob", limit)
+	s.Require().NoError(err)
+	s.True(decision.Allowed)
+}
+
+func (s *TestSuite) TestRateLimiterConcurrentRequests() {
+	ctx := context.Background()
+	limit := domain.RateLimit{Requests: 5, Window: time.Hour}
+
+	// Concurrent requests, as from several instances, never take more than the burst
+	var wg sync.WaitGroup
+	allowed := make(chan bool, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			decision, err := s.rateLimiter.Allow(ctx, "route|ip:203.0.113.7", limit)
+			s.NoError(err)
+			allowed <- decision.Allowed
+		}()
+	}
+	wg.Wait()
+	close(allowed)
+
+	count := 0
+	for ok := range allowed {
+		if ok {
+			count++
+		}
+	}
+	s.Equal(5, count)
+}
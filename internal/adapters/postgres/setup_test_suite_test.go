@@ -2,6 +2,7 @@ package postgres_test
 
 import (
 	"database/sql"
+	"messaging-app/internal/adapters/metrics"
 	"messaging-app/internal/adapters/postgres"
 	"messaging-app/internal/testutils"
 	"testing"
@@ -11,19 +12,31 @@ import (
 
 type TestSuite struct {
 	suite.Suite
-	db   *sql.DB
-	repo *postgres.PostgreSQLMessageRepository
+	db           *sql.DB
+	repo         *postgres.PostgreSQLMessageRepository
+	chatRepo     *postgres.PostgreSQLChatRepository
+	outboxRepo   *postgres.PostgreSQLOutboxRepository
+	presenceRepo *postgres.PostgreSQLPresenceRepository
+	blockRepo    *postgres.PostgreSQLBlockRepository
+	rateLimiter  *postgres.PostgreSQLRateLimiter
+	userDataRepo *postgres.PostgreSQLUserDataRepository
 }
 
 func (s *TestSuite) TearDownTest() {
-	_, err := s.db.Exec("TRUNCATE messages")
+	_, err := s.db.Exec("TRUNCATE messages, chats, chat_members, outbox, hidden_messages, message_reactions, user_presence, attachments, user_blocks, chat_mutes, rate_limit_buckets")
 	s.Require().NoError(err)
 }
 
 func (s *TestSuite) SetupSuite() {
 	db := setupTestDB(s.T())
 	s.db = db
-	s.repo = postgres.NewPostgreSQLMessageRepository(s.db, &testutils.TestLogger{T: s.T()})
+	s.repo = postgres.NewPostgreSQLMessageRepository(s.db, metrics.NewNopMetrics(), &testutils.TestLogger{T: s.T()})
+	s.chatRepo = postgres.NewPostgreSQLChatRepository(s.db, &testutils.TestLogger{T: s.T()})
+	s.outboxRepo = postgres.NewPostgreSQLOutboxRepository(s.db, &testutils.TestLogger{T: s.T()})
+	s.presenceRepo = postgres.NewPostgreSQLPresenceRepository(s.db, &testutils.TestLogger{T: s.T()})
+	s.blockRepo = postgres.NewPostgreSQLBlockRepository(s.db, &testutils.TestLogger{T: s.T()})
+	s.rateLimiter = postgres.NewPostgreSQLRateLimiter(s.db, &testutils.TestLogger{T: s.T()})
+	s.userDataRepo = postgres.NewPostgreSQLUserDataRepository(s.db, &testutils.TestLogger{T: s.T()})
 
 }
 
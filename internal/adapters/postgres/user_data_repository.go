@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+type PostgreSQLUserDataRepository struct {
+	db     *sql.DB
+	logger ports.Logger
+}
+
+func NewPostgreSQLUserDataRepository(db *sql.DB, logger ports.Logger) *PostgreSQLUserDataRepository {
+	return &PostgreSQLUserDataRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ExportUserData implements ports.UserDataRepository
+func (r *PostgreSQLUserDataRepository) ExportUserData(ctx context.Context, userID string) (domain.UserDataExport, error) {
+	export := domain.UserDataExport{
+		UserID:      userID,
+		ExportedAt:  time.Now().UTC(),
+		Messages:    []domain.Message{},
+		Memberships: []domain.ChatMember{},
+		Reactions:   []domain.Reaction{},
+		Blocks:      []domain.Block{},
+		Mutes:       []domain.ChatMute{},
+		Attachments: []domain.Attachment{},
+	}
+	if err := domain.ValidateUserID(userID); err != nil {
+		return export, err
+	}
+
+	// A single snapshot keeps the sections consistent with each other
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return export, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = queryEach(ctx, tx, `
+		SELECT `+messageColumns+`
+		FROM `+messageSource+`
+		WHERE sender_id = $1 OR receiver_id = $1
+		ORDER BY created_at, id
+	`, userID, func(rows *sql.Rows) error {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return err
+		}
+		export.Messages = append(export.Messages, msg)
+		return nil
+	})
+	if err != nil {
+		return export, fmt.Errorf("failed to export messages: %w", err)
+	}
+
+	err = queryEach(ctx, tx, `
+		SELECT chat_id, user_id, role, joined_at
+		FROM chat_members
+		WHERE user_id = $1
+		ORDER BY joined_at, chat_id
+	`, userID, func(rows *sql.Rows) error {
+		var member domain.ChatMember
+		err := rows.Scan(&member.ChatID, &member.UserID, &member.Role, &member.JoinedAt)
+		if err != nil {
+			return err
+		}
+		export.Memberships = append(export.Memberships, member)
+		return nil
+	})
+	if err != nil {
+		return export, fmt.Errorf("failed to export memberships: %w", err)
+	}
+
+	err = queryEach(ctx, tx, `
+		SELECT message_id, user_id, emoji, created_at
+		FROM message_reactions
+		WHERE user_id = $1
+		ORDER BY created_at, message_id
+	`, userID, func(rows *sql.Rows) error {
+		var reaction domain.Reaction
+		err := rows.Scan(&reaction.MessageID, &reaction.UserID, &reaction.Emoji, &reaction.CreatedAt)
+		if err != nil {
+			return err
+		}
+		export.Reactions = append(export.Reactions, reaction)
+		return nil
+	})
+	if err != nil {
+		return export, fmt.Errorf("failed to export reactions: %w", err)
+	}
+
+	err = queryEach(ctx, tx, `
+		SELECT blocker_id, blocked_id, created_at
+		FROM user_blocks
+		WHERE blocker_id = $1
+		ORDER BY created_at, blocked_id
+	`, userID, func(rows *sql.Rows) error {
+		var block domain.Block
+		err := rows.Scan(&block.BlockerID, &block.BlockedID, &block.CreatedAt)
+		if err != nil {
+			return err
+		}
+		export.Blocks = append(export.Blocks, block)
+		return nil
+	})
+	if err != nil {
+		return export, fmt.Errorf("failed to export blocks: %w", err)
+	}
+
+	err = queryEach(ctx, tx, `
+		SELECT chat_id, user_id, muted_until, created_at
+		FROM chat_mutes
+		WHERE user_id = $1
+		ORDER BY created_at, chat_id
+	`, userID, func(rows *sql.Rows) error {
+		var mute domain.ChatMute
+		err := rows.Scan(&mute.ChatID, &mute.UserID, &mute.MutedUntil, &mute.CreatedAt)
+		if err != nil {
+			return err
+		}
+		export.Mutes = append(export.Mutes, mute)
+		return nil
+	})
+	if err != nil {
+		return export, fmt.Errorf("failed to export mutes: %w", err)
+	}
+
+	err = queryEach(ctx, tx, `
+		SELECT `+attachmentColumns+`
+		FROM attachments
+		WHERE uploader_id = $1
+		ORDER BY created_at, id
+	`, userID, func(rows *sql.Rows) error {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return err
+		}
+		export.Attachments = append(export.Attachments, attachment)
+		return nil
+	})
+	if err != nil {
+		return export, fmt.Errorf("failed to export attachments: %w", err)
+	}
+
+	return export, nil
+}
+
+// PurgeUserData implements ports.UserDataRepository
+func (r *PostgreSQLUserDataRepository) PurgeUserData(ctx context.Context, userID string) (domain.UserDataPurge, error) {
+	purge := domain.UserDataPurge{AttachmentIDs: []string{}}
+	if err := domain.ValidateUserID(userID); err != nil {
+		return purge, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return purge, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Reactions and hidden markers on the deleted messages go with them; replies to them lose their quote
+	deletions := []struct {
+		what  string
+		query string
+		count *int64
+	}{
+		{"messages", `DELETE FROM messages WHERE sender_id = $1`, &purge.Messages},
+		{"reactions", `DELETE FROM message_reactions WHERE user_id = $1`, &purge.Reactions},
+		{"hidden messages", `DELETE FROM hidden_messages WHERE user_id = $1`, nil},
+		{"memberships", `DELETE FROM chat_members WHERE user_id = $1`, &purge.Memberships},
+		{"mutes", `DELETE FROM chat_mutes WHERE user_id = $1`, &purge.Mutes},
+		{"blocks", `DELETE FROM user_blocks WHERE blocker_id = $1 OR blocked_id = $1`, &purge.Blocks},
+		{"presence", `DELETE FROM user_presence WHERE user_id = $1`, nil},
+		{"pending events", `DELETE FROM outbox WHERE recipient = $1`, nil},
+	}
+	for _, deletion := range deletions {
+		result, err := tx.ExecContext(ctx, deletion.query, userID)
+		if err != nil {
+			return purge, fmt.Errorf("failed to delete %s: %w", deletion.what, err)
+		}
+		if deletion.count == nil {
+			continue
+		}
+		if *deletion.count, err = result.RowsAffected(); err != nil {
+			return purge, fmt.Errorf("rows affected: %w", err)
+		}
+	}
+
+	// Attachments go last, once the messages that sent them are gone
+	err = queryEach(ctx, tx, `
+		DELETE FROM attachments
+		WHERE uploader_id = $1
+		RETURNING id
+	`, userID, func(rows *sql.Rows) error {
+		var id string
+		err := rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+		purge.AttachmentIDs = append(purge.AttachmentIDs, id)
+		return nil
+	})
+	if err != nil {
+		return purge, fmt.Errorf("failed to delete attachments: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return purge, fmt.Errorf("commit tx: %w", err)
+	}
+
+	r.logger.Info("User data purged", "user_id", userID, "messages", purge.Messages, "attachments", len(purge.AttachmentIDs))
+	return purge, nil
+}
+
+// queryEach runs a query taking a single argument and calls scan for every row
+func queryEach(ctx context.Context, tx *sql.Tx, query string, arg interface{}, scan func(*sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
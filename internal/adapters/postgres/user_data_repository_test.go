@@ -0,0 +1,121 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/testdata"
+)
+
+// seedUserData gives Alice a little of everything, plus messages from Bob that outlive her purge
+func (s *TestSuite) seedUserData(now time.Time) (aliceMessage, bobMessage domain.Message, attachment domain.Attachment) {
+	ctx := context.Background()
+	alice, bob, eve := testdata.Alice.UserID, testdata.Bob.UserID, testdata.Eve.UserID
+
+	chat := domain.NewGroupChat("Project", bob, []string{alice})
+	s.Require().NoError(s.chatRepo.CreateGroupChat(ctx, chat))
+
+	attachment = domain.NewAttachment(alice, domain.ComputeChatID(alice, bob), "notes.txt")
+	attachment.MimeType = "text/plain"
+	attachment.Size = 12
+	attachment.Checksum = strings.Repeat("ab", 32)
+	s.Require().NoError(s.repo.SaveAttachment(ctx, attachment))
+
+	aliceMessage = domain.Message{ID: domain.NewMessageID(), SenderID: alice, ReceiverID: bob, CreatedAt: now, Status: domain.MessageStatusSent}
+	aliceMessage.Attach(attachment)
+	s.Require().NoError(s.repo.SaveMessage(ctx, aliceMessage))
+
+	bobMessage = domain.Message{ID: domain.NewMessageID(), SenderID: bob, ReceiverID: alice, CreatedAt: now.Add(time.Second), Content: "Thanks!", Status: domain.MessageStatusSent}
+	s.Require().NoError(s.repo.SaveMessage(ctx, bobMessage))
+	s.Require().NoError(s.repo.SaveMessage(ctx, domain.Message{SenderID: bob, ReceiverID: chat.ID, CreatedAt: now, Content: "Welcome", Status: domain.MessageStatusSent}))
+
+	s.Require().NoError(s.repo.AddReaction(ctx, domain.Reaction{MessageID: bobMessage.ID, UserID: alice, Emoji: "👍", CreatedAt: now}))
+	s.Require().NoError(s.repo.AddReaction(ctx, domain.Reaction{MessageID: aliceMessage.ID, UserID: bob, Emoji: "🎉", CreatedAt: now}))
+	s.Require().NoError(s.blockRepo.BlockUser(ctx, domain.Block{BlockerID: alice, BlockedID: eve, CreatedAt: now}))
+	s.Require().NoError(s.chatRepo.MuteChat(ctx, domain.ChatMute{ChatID: chat.ID, UserID: alice, CreatedAt: now}))
+	_, err := s.presenceRepo.TouchPresence(ctx, alice, now)
+	s.Require().NoError(err)
+
+	return aliceMessage, bobMessage, attachment
+}
+
+func (s *TestSuite) TestExportUserData() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	aliceMessage, bobMessage, attachment := s.seedUserData(now)
+
+	export, err := s.userDataRepo.ExportUserData(ctx, testdata.Alice.UserID)
+	s.Require().NoError(err)
+
+	// Her direct messages in both directions, but not the group message she received
+	s.Require().Len(export.Messages, 2)
+	s.Equal(aliceMessage.ID, export.Messages[0].ID)
+	s.Require().NotNil(export.Messages[0].Attachment)
+	s.Equal(attachment.ID, export.Messages[0].Attachment.ID)
+	s.Equal(bobMessage.ID, export.Messages[1].ID)
+
+	s.Require().Len(export.Memberships, 1)
+	s.Equal(domain.ChatRoleMember, export.Memberships[0].Role)
+	s.Require().Len(export.Reactions, 1)
+	s.Equal(bobMessage.ID, export.Reactions[0].MessageID)
+	s.Len(export.Blocks, 1)
+	s.Require().Len(export.Mutes, 1)
+	s.Nil(export.Mutes[0].MutedUntil)
+	s.Require().Len(export.Attachments, 1)
+	s.Equal(attachment.ID, export.Attachments[0].ID)
+
+	// Users without data get empty lists rather than nulls
+	export, err = s.userDataRepo.ExportUserData(ctx, "nobody")
+	s.Require().NoError(err)
+	s.NotNil(export.Messages)
+	s.Empty(export.Messages)
+
+	_, err = s.userDataRepo.ExportUserData(ctx, "")
+	s.ErrorIs(err, domain.ErrMissingUserID)
+}
+
+func (s *TestSuite) TestPurgeUserData() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	_, bobMessage, attachment := s.seedUserData(now)
+	alice := testdata.Alice.UserID
+
+	// Eve blocking Alice is removed too
+	s.Require().NoError(s.blockRepo.BlockUser(ctx, domain.Block{BlockerID: testdata.Eve.UserID, BlockedID: alice, CreatedAt: now}))
+
+	purge, err := s.userDataRepo.PurgeUserData(ctx, alice)
+	s.Require().NoError(err)
+	s.Equal(domain.UserDataPurge{
+		Messages:      1,
+		Reactions:     1,
+		Memberships:   1,
+		Blocks:        2,
+		Mutes:         1,
+		AttachmentIDs: []string{attachment.ID},
+	}, purge)
+
+	export, err := s.userDataRepo.ExportUserData(ctx, alice)
+	s.Require().NoError(err)
+	s.Empty(export.Memberships)
+	s.Empty(export.Reactions)
+	s.Empty(export.Blocks)
+	s.Empty(export.Mutes)
+	s.Empty(export.Attachments)
+
+	// Bob keeps the message he sent her
+	s.Require().Len(export.Messages, 1)
+	s.Equal(bobMessage.ID, export.Messages[0].ID)
+
+	var remaining int
+	s.Require().NoError(s.db.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(*) FROM user_presence) + (SELECT COUNT(*) FROM message_reactions) + (SELECT COUNT(*) FROM user_blocks)
+	`).Scan(&remaining))
+	s.Zero(remaining)
+
+	// Purging again finds nothing
+	purge, err = s.userDataRepo.PurgeUserData(ctx, alice)
+	s.Require().NoError(err)
+	s.Equal(domain.UserDataPurge{AttachmentIDs: []string{}}, purge)
+}
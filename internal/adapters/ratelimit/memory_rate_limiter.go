@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"messaging-app/internal/domain"
+)
+
+// sweepInterval is how often buckets that have refilled completely are dropped
+const sweepInterval = time.Minute
+
+type memoryBucket struct {
+	bucket domain.TokenBucket
+	fullAt time.Time
+}
+
+// MemoryRateLimiter keeps token buckets in process memory, so each instance enforces its own limits
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		buckets: make(map[string]memoryBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow implements ports.RateLimiter
+func (l *MemoryRateLimiter) Allow(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, decision := l.buckets[key].bucket.Take(limit, now)
+	l.buckets[key] = memoryBucket{bucket: bucket, fullAt: now.Add(decision.ResetAfter)}
+
+	return decision, nil
+}
+
+// sweep forgets full buckets, which behave exactly like missing ones
+func (l *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, entry := range l.buckets {
+		if !now.Before(entry.fullAt) {
+			delete(l.buckets, key)
+		}
+	}
+}
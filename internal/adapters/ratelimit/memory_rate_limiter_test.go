@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(now *time.Time) *MemoryRateLimiter {
+	limiter := NewMemoryRateLimiter()
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func TestMemoryRateLimiter_Burst(t *testing.T) {
+	ctx := context.Background()
+	now := testdata.BaseTime
+	limiter := newTestLimiter(&now)
+	limit := domain.RateLimit{Requests: 60, Window: time.Minute, Burst: 3}
+
+	// The bucket starts full
+	for remaining := 2; remaining >= 0; remaining-- {
+		decision, err := limiter.Allow(ctx, "alice", limit)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 3, decision.Limit)
+		assert.Equal(t, remaining, decision.Remaining)
+	}
+
+	decision, err := limiter.Allow(ctx, "alice", limit)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	assert.Equal(t, time.Second, decision.RetryAfter)
+	assert.Equal(t, 3*time.Second, decision.ResetAfter)
+
+	// Other keys have their own bucket
+	decision, err = limiter.Allow(ctx, "bob", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestMemoryRateLimiter_Refill(t *testing.T) {
+	ctx := context.Background()
+	now := testdata.BaseTime
+	limiter := newTestLimiter(&now)
+	limit := domain.RateLimit{Requests: 2, Window: time.Second}
+
+	for i := 0; i < 2; i++ {
+		decision, err := limiter.Allow(ctx, "alice", limit)
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+	}
+
+	decision, err := limiter.Allow(ctx, "alice", limit)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+
+	// Half a window later one token is back
+	now = now.Add(500 * time.Millisecond)
+	decision, err = limiter.Allow(ctx, "alice", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+
+	// Refills never exceed the burst
+	now = now.Add(time.Hour)
+	decision, err = limiter.Allow(ctx, "alice", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 1, decision.Remaining)
+}
+
+func TestMemoryRateLimiter_SweepsFullBuckets(t *testing.T) {
+	ctx := context.Background()
+	now := testdata.BaseTime
+	limiter := newTestLimiter(&now)
+	limit := domain.RateLimit{Requests: 10, Window: time.Second}
+
+	_, err := limiter.Allow(ctx, "alice", limit)
+	require.NoError(t, err)
+	_, err = limiter.Allow(ctx, "bob", domain.RateLimit{Requests: 1, Window: time.Hour})
+	require.NoError(t, err)
+
+	now = now.Add(2 * sweepInterval)
+	_, err = limiter.Allow(ctx, "eve", limit)
+	require.NoError(t, err)
+
+	// Alice's bucket refilled and was dropped, Bob's is still recovering
+	assert.NotContains(t, limiter.buckets, "alice")
+	assert.Contains(t, limiter.buckets, "bob")
+	assert.Contains(t, limiter.buckets, "eve")
+}
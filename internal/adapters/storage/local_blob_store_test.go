@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	store, err := NewLocalBlobStore(root, testutils.NewTestLogger(t))
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "blob-1", strings.NewReader("first")))
+	require.NoError(t, store.Put(ctx, "blob-1", strings.NewReader("second")))
+
+	blob, err := store.Get(ctx, "blob-1")
+	require.NoError(t, err)
+	content, err := io.ReadAll(blob)
+	require.NoError(t, err)
+	require.NoError(t, blob.Close())
+	assert.Equal(t, "second", string(content))
+
+	require.NoError(t, store.Delete(ctx, "blob-1"))
+	require.NoError(t, store.Delete(ctx, "blob-1"))
+
+	_, err = store.Get(ctx, "blob-1")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+	// Temporary files are cleaned up
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocalBlobStore_FailedPutLeavesNothing(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	store, err := NewLocalBlobStore(root, testutils.NewTestLogger(t))
+	require.NoError(t, err)
+
+	err = store.Put(ctx, "blob-1", io.MultiReader(strings.NewReader("partial"), failingReader{}))
+	assert.Error(t, err)
+
+	_, err = store.Get(ctx, "blob-1")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocalBlobStore_RejectsPathKeys(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewLocalBlobStore(t.TempDir(), testutils.NewTestLogger(t))
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../escape", "nested/key", ".hidden", ".."} {
+		assert.Error(t, store.Put(ctx, key, strings.NewReader("content")), "key %q", key)
+		_, err := store.Get(ctx, key)
+		assert.Error(t, err, "key %q", key)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// restoreGlobals puts back the tracer provider and propagator Init replaces
+func restoreGlobals(t *testing.T) {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+}
+
+func TestInit_Stdout(t *testing.T) {
+	restoreGlobals(t)
+
+	var output bytes.Buffer
+	shutdown, err := Init(context.Background(), Config{Exporter: ExporterStdout, ServiceName: "messaging-test", SampleRatio: 1, Output: &output})
+	require.NoError(t, err)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "send message")
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	span.End()
+
+	// Spans are written when the batch is flushed
+	require.NoError(t, shutdown(context.Background()))
+	assert.Contains(t, output.String(), `"Name":"send message"`)
+	assert.Contains(t, output.String(), "messaging-test")
+	assert.Contains(t, carrier.Get("traceparent"), span.SpanContext().TraceID().String())
+}
+
+func TestInit_None(t *testing.T) {
+	restoreGlobals(t)
+
+	shutdown, err := Init(context.Background(), Config{Exporter: ExporterNone})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	// Without an exporter the trace of the caller is still passed along
+	parent := propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), parent)
+	ctx, span := otel.Tracer("test").Start(ctx, "send message")
+	defer span.End()
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	assert.Contains(t, carrier.Get("traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736")
+}
+
+func TestInit_UnknownExporter(t *testing.T) {
+	restoreGlobals(t)
+
+	_, err := Init(context.Background(), Config{Exporter: "zipkin"})
+	assert.ErrorContains(t, err, `unknown tracing exporter "zipkin"`)
+}
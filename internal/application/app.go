@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -13,9 +14,15 @@ import (
 )
 
 type Application struct {
-	config     Config
-	logger     ports.Logger
-	httpServer *httpAdapter.Server
+	config      Config
+	logger      ports.Logger
+	httpServer  *httpAdapter.Server
+	outboxRelay *OutboxRelay
+	presence    *PresenceTracker
+	cleaner     *AttachmentCleaner
+
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
 }
 
 type Config struct {
@@ -27,34 +34,85 @@ type Config struct {
 	} `mapstructure:"server"`
 
 	Environment string `mapstructure:"environment"`
+
+	Outbox            OutboxRelayConfig             `mapstructure:"-"`
+	Messages          httphandlers.MessageConfig    `mapstructure:"-"`
+	Presence          PresenceConfig                `mapstructure:"-"`
+	Attachments       httphandlers.AttachmentConfig `mapstructure:"-"`
+	AttachmentCleanup AttachmentCleanupConfig       `mapstructure:"-"`
 }
 
 func NewApplication(
 	config Config,
 	logger ports.Logger,
 	messageRepo ports.MessageRepository,
+	chatRepo ports.ChatRepository,
+	outboxRepo ports.OutboxRepository,
+	presenceRepo ports.PresenceRepository,
+	blockRepo ports.BlockRepository,
+	blobStore ports.BlobStore,
 	publisher ports.MessagePublisher,
+	subscriber ports.MessageSubscriber,
+	authenticator ports.Authenticator,
+	rateLimiter ports.RateLimiter,
+	healthCheckers []ports.HealthChecker,
+	metrics ports.Metrics,
 	httpConfig httpAdapter.Config,
 ) *Application {
 	// Create HTTP server adapter with full configuration
-	httpServer := httpAdapter.NewServer(httpConfig, logger)
+	httpServer := httpAdapter.NewServer(httpConfig, authenticator, rateLimiter, metrics, logger)
+
+	// Presence is tracked by the application and reported by clients over both transports
+	presence := NewPresenceTracker(config.Presence, presenceRepo, chatRepo, publisher, logger)
 
 	// Initialize route providers
-	messageRoutes := httphandlers.NewMessageRoutes(messageRepo, publisher, logger)
+	messageRoutes := httphandlers.NewMessageRoutes(config.Messages, messageRepo, chatRepo, blobStore, metrics, logger)
 	chatRoutes := httphandlers.NewChatRoutes(messageRepo, logger)
+	groupRoutes := httphandlers.NewGroupRoutes(chatRepo, logger)
+	presenceRoutes := httphandlers.NewPresenceRoutes(presence, chatRepo, logger)
+	attachmentRoutes := httphandlers.NewAttachmentRoutes(config.Attachments, messageRepo, chatRepo, blobStore, logger)
+	searchRoutes := httphandlers.NewSearchRoutes(messageRepo, chatRepo, logger)
+	blockRoutes := httphandlers.NewBlockRoutes(blockRepo, logger)
+	muteRoutes := httphandlers.NewMuteRoutes(chatRepo, logger)
+
+	// Real-time gateway shares the message handler so both transports behave the same
+	gateway := httpAdapter.NewWebSocketGateway(httpConfig.WebSocket, httpConfig.CORS, httpConfig.RateLimit, subscriber, rateLimiter, logger)
+	httphandlers.NewMessageHandler(config.Messages, messageRepo, chatRepo, blobStore, metrics, logger).RegisterCommands(gateway)
+	httphandlers.NewPresenceHandler(presence, chatRepo, logger).RegisterCommands(gateway)
+	httpServer.RegisterOnShutdown(gateway.Close)
 
 	// Collect all routes
 	var allRoutes []httpAdapter.Route
 	allRoutes = append(allRoutes, messageRoutes.GetRoutes()...)
 	allRoutes = append(allRoutes, chatRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, groupRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, presenceRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, attachmentRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, searchRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, blockRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, muteRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, gateway.Route())
 
 	// Register routes with the server
 	httpServer.RegisterRoutes(allRoutes)
 
+	// Real-time events are written to the outbox by the handlers and published by the relay
+	outboxRelay := NewOutboxRelay(config.Outbox, outboxRepo, chatRepo, publisher, logger)
+
+	// Readiness covers the infrastructure dependencies and the lag of the relay
+	httpServer.RegisterHealthCheckers(healthCheckers...)
+	httpServer.RegisterHealthCheckers(outboxRelay)
+
+	// Uploads that are never sent are deleted along with their content
+	cleaner := NewAttachmentCleaner(config.AttachmentCleanup, messageRepo, blobStore, logger)
+
 	return &Application{
-		config:     config,
-		logger:     logger,
-		httpServer: httpServer,
+		config:      config,
+		logger:      logger,
+		httpServer:  httpServer,
+		outboxRelay: outboxRelay,
+		presence:    presence,
+		cleaner:     cleaner,
 	}
 }
 
@@ -76,6 +134,23 @@ func (app *Application) Start() error {
 		"environment", app.config.Environment,
 	)
 
+	// Start outbox relay, presence sweeper and attachment cleaner in goroutines
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	app.stopWorkers = stopWorkers
+	app.workers.Add(3)
+	go func() {
+		defer app.workers.Done()
+		app.outboxRelay.Run(workersCtx)
+	}()
+	go func() {
+		defer app.workers.Done()
+		app.presence.Run(workersCtx)
+	}()
+	go func() {
+		defer app.workers.Done()
+		app.cleaner.Run(workersCtx)
+	}()
+
 	// Start HTTP server in goroutine
 	go func() {
 		if err := app.httpServer.Start(); err != nil {
@@ -110,6 +185,23 @@ func (app *Application) Shutdown() error {
 		app.logger.Error("Failed to shutdown HTTP server", "error", err)
 	}
 
+	// Stop the workers after the server so events from in-flight requests are still published
+	if app.stopWorkers != nil {
+		app.stopWorkers()
+
+		workersDone := make(chan struct{})
+		go func() {
+			app.workers.Wait()
+			close(workersDone)
+		}()
+
+		select {
+		case <-workersDone:
+		case <-ctx.Done():
+			app.logger.Error("Timed out waiting for background workers to stop")
+		}
+	}
+
 	app.logger.Info("Application shutdown completed")
 	return nil
-}
\ No newline at end of file
+}
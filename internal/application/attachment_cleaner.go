@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"messaging-app/internal/ports"
+)
+
+type AttachmentCleanupConfig struct {
+	UnattachedTTL time.Duration // Uploads not sent with a message are deleted after this long
+	SweepInterval time.Duration
+	BatchSize     int
+}
+
+func DefaultAttachmentCleanupConfig() AttachmentCleanupConfig {
+	return AttachmentCleanupConfig{
+		UnattachedTTL: 24 * time.Hour,
+		SweepInterval: 10 * time.Minute,
+		BatchSize:     100,
+	}
+}
+
+// AttachmentCleaner deletes uploads that were never sent with a message, along with their content
+type AttachmentCleaner struct {
+	config      AttachmentCleanupConfig
+	messageRepo ports.MessageRepository
+	blobs       ports.BlobStore
+	logger      ports.Logger
+}
+
+func NewAttachmentCleaner(config AttachmentCleanupConfig, messageRepo ports.MessageRepository, blobs ports.BlobStore, logger ports.Logger) *AttachmentCleaner {
+	defaults := DefaultAttachmentCleanupConfig()
+	if config.UnattachedTTL <= 0 {
+		config.UnattachedTTL = defaults.UnattachedTTL
+	}
+	if config.SweepInterval <= 0 {
+		config.SweepInterval = defaults.SweepInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+
+	return &AttachmentCleaner{
+		config:      config,
+		messageRepo: messageRepo,
+		blobs:       blobs,
+		logger:      logger,
+	}
+}
+
+// Run deletes expired uploads until ctx is cancelled
+func (c *AttachmentCleaner) Run(ctx context.Context) {
+	c.logger.Info("Attachment cleaner started", "unattached_ttl", c.config.UnattachedTTL, "sweep_interval", c.config.SweepInterval)
+
+	ticker := time.NewTicker(c.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("Attachment cleaner stopped")
+			return
+		case <-ticker.C:
+			// Keep sweeping while full batches come back
+			for {
+				deleted, err := c.Sweep(ctx)
+				if err != nil {
+					c.logger.Error("Failed to delete expired attachments", "error", err)
+					break
+				}
+				if deleted < c.config.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Sweep deletes one batch of uploads older than the TTL that no message refers to
+// Returns the number of attachments deleted
+func (c *AttachmentCleaner) Sweep(ctx context.Context) (int, error) {
+	attachmentIDs, err := c.messageRepo.DeleteExpiredAttachments(ctx, time.Now().UTC().Add(-c.config.UnattachedTTL), c.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// The rows are gone, so content that fails to delete here is only left behind on disk
+	for _, attachmentID := range attachmentIDs {
+		if err := c.blobs.Delete(ctx, attachmentID); err != nil {
+			c.logger.Error("Failed to delete attachment content", "error", err, "attachment_id", attachmentID)
+		}
+	}
+
+	return len(attachmentIDs), nil
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/testutils"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentCleanerTestSuite struct {
+	suite.Suite
+	cleaner   *AttachmentCleaner
+	mockRepo  *mocks.MessageRepository
+	mockBlobs *mocks.BlobStore
+}
+
+func (s *AttachmentCleanerTestSuite) SetupTest() {
+	s.mockRepo = &mocks.MessageRepository{}
+	s.mockBlobs = &mocks.BlobStore{}
+
+	s.cleaner = NewAttachmentCleaner(DefaultAttachmentCleanupConfig(), s.mockRepo, s.mockBlobs, testutils.NewTestLogger(s.T()))
+}
+
+func (s *AttachmentCleanerTestSuite) TearDownTest() {
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockBlobs.AssertExpectations(s.T())
+}
+
+func (s *AttachmentCleanerTestSuite) TestSweep_DeletesExpiredUploads() {
+	expired := []string{domain.NewMessageID(), domain.NewMessageID()}
+
+	// Only uploads older than the TTL are considered
+	s.mockRepo.On("DeleteExpiredAttachments", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		cutoff := time.Now().UTC().Add(-s.cleaner.config.UnattachedTTL)
+		return !before.After(cutoff) && before.After(cutoff.Add(-time.Minute))
+	}), s.cleaner.config.BatchSize).Return(expired, nil)
+	s.mockBlobs.On("Delete", mock.Anything, expired[0]).Return(errors.New("disk error"))
+	s.mockBlobs.On("Delete", mock.Anything, expired[1]).Return(nil)
+
+	deleted, err := s.cleaner.Sweep(context.Background())
+
+	// A blob that cannot be removed does not stop the others
+	s.NoError(err)
+	s.Equal(2, deleted)
+}
+
+func (s *AttachmentCleanerTestSuite) TestSweep_RepositoryError() {
+	s.mockRepo.On("DeleteExpiredAttachments", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database down"))
+
+	deleted, err := s.cleaner.Sweep(context.Background())
+
+	s.Error(err)
+	s.Zero(deleted)
+	s.mockBlobs.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAttachmentCleanerSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentCleanerTestSuite))
+}
@@ -6,9 +6,28 @@ import (
 
 	"github.com/spf13/viper"
 
+	"messaging-app/internal/adapters/auth"
 	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/adapters/tracing"
+	"messaging-app/internal/domain"
+	httphandlers "messaging-app/internal/handlers/http"
 )
 
+// RateLimitSettings configures one rate limit, allowing Requests per Window in bursts of up to Burst
+type RateLimitSettings struct {
+	Requests int           `mapstructure:"requests"`
+	Window   time.Duration `mapstructure:"window"`
+	Burst    int           `mapstructure:"burst"`
+}
+
+func (s RateLimitSettings) toDomain() domain.RateLimit {
+	return domain.RateLimit{
+		Requests: s.Requests,
+		Window:   s.Window,
+		Burst:    s.Burst,
+	}
+}
+
 type FullConfig struct {
 	Server struct {
 		Port         int           `mapstructure:"port"`
@@ -18,9 +37,22 @@ type FullConfig struct {
 	} `mapstructure:"server"`
 
 	Auth struct {
+		Mode          string `mapstructure:"mode"`
 		UserIDHeader  string `mapstructure:"user_id_header"`
 		EmailHeader   string `mapstructure:"email_header"`
 		HandlerHeader string `mapstructure:"handler_header"`
+
+		JWT struct {
+			Algorithm    string        `mapstructure:"algorithm"`
+			Secret       string        `mapstructure:"secret"`
+			JWKSFile     string        `mapstructure:"jwks_file"`
+			Issuer       string        `mapstructure:"issuer"`
+			Audience     string        `mapstructure:"audience"`
+			Leeway       time.Duration `mapstructure:"leeway"`
+			UserIDClaim  string        `mapstructure:"user_id_claim"`
+			EmailClaim   string        `mapstructure:"email_claim"`
+			HandlerClaim string        `mapstructure:"handler_claim"`
+		} `mapstructure:"jwt"`
 	} `mapstructure:"auth"`
 
 	CORS struct {
@@ -29,6 +61,21 @@ type FullConfig struct {
 		AllowedHeaders []string `mapstructure:"allowed_headers"`
 	} `mapstructure:"cors"`
 
+	WebSocket struct {
+		PingInterval   time.Duration `mapstructure:"ping_interval"`
+		PongTimeout    time.Duration `mapstructure:"pong_timeout"`
+		WriteTimeout   time.Duration `mapstructure:"write_timeout"`
+		MaxMessageSize int64         `mapstructure:"max_message_size"`
+		SendBufferSize int           `mapstructure:"send_buffer_size"`
+	} `mapstructure:"websocket"`
+
+	RateLimit struct {
+		Enabled           bool              `mapstructure:"enabled"`
+		Store             string            `mapstructure:"store"`
+		TrustForwardedFor bool              `mapstructure:"trust_forwarded_for"`
+		Default           RateLimitSettings `mapstructure:"default"`
+	} `mapstructure:"rate_limit"`
+
 	Database struct {
 		Host            string        `mapstructure:"host"`
 		Port            int           `mapstructure:"port"`
@@ -39,6 +86,7 @@ type FullConfig struct {
 		MaxConnections  int           `mapstructure:"max_connections"`
 		MaxIdleTime     time.Duration `mapstructure:"max_idle_time"`
 		ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+		MigrateOnStart  bool          `mapstructure:"migrate_on_start"`
 	} `mapstructure:"database"`
 
 	NATS struct {
@@ -49,8 +97,63 @@ type FullConfig struct {
 		RequestTimeout  time.Duration `mapstructure:"request_timeout"`
 		EnableJetStream bool          `mapstructure:"enable_jetstream"`
 		ClusterName     string        `mapstructure:"cluster_name"`
+		StreamName      string        `mapstructure:"stream_name"`
+		StreamMaxAge    time.Duration `mapstructure:"stream_max_age"`
+		DuplicateWindow time.Duration `mapstructure:"duplicate_window"`
 	} `mapstructure:"nats"`
 
+	Outbox struct {
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size"`
+		Lease        time.Duration `mapstructure:"lease"`
+		BaseBackoff  time.Duration `mapstructure:"base_backoff"`
+		MaxBackoff   time.Duration `mapstructure:"max_backoff"`
+		MaxAttempts  int           `mapstructure:"max_attempts"`
+		MaxLag       time.Duration `mapstructure:"max_lag"`
+	} `mapstructure:"outbox"`
+
+	Messages struct {
+		EditWindow    time.Duration     `mapstructure:"edit_window"`
+		DeleteWindow  time.Duration     `mapstructure:"delete_window"`
+		SendRateLimit RateLimitSettings `mapstructure:"send_rate_limit"`
+	} `mapstructure:"messages"`
+
+	Health struct {
+		CheckTimeout  time.Duration `mapstructure:"check_timeout"`
+		ShutdownDelay time.Duration `mapstructure:"shutdown_delay"`
+	} `mapstructure:"health"`
+
+	Metrics struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Path    string `mapstructure:"path"`
+	} `mapstructure:"metrics"`
+
+	Tracing struct {
+		Exporter    string  `mapstructure:"exporter"`
+		ServiceName string  `mapstructure:"service_name"`
+		Endpoint    string  `mapstructure:"endpoint"`
+		Insecure    bool    `mapstructure:"insecure"`
+		SampleRatio float64 `mapstructure:"sample_ratio"`
+	} `mapstructure:"tracing"`
+
+	Presence struct {
+		TTL           time.Duration `mapstructure:"ttl"`
+		SweepInterval time.Duration `mapstructure:"sweep_interval"`
+	} `mapstructure:"presence"`
+
+	Attachments struct {
+		MaxSize         int64             `mapstructure:"max_size"`
+		AllowedTypes    []string          `mapstructure:"allowed_types"`
+		UploadRateLimit RateLimitSettings `mapstructure:"upload_rate_limit"`
+		UnattachedTTL   time.Duration     `mapstructure:"unattached_ttl"`
+		CleanupInterval time.Duration     `mapstructure:"cleanup_interval"`
+
+		Storage struct {
+			Type string `mapstructure:"type"`
+			Path string `mapstructure:"path"`
+		} `mapstructure:"storage"`
+	} `mapstructure:"attachments"`
+
 	Logging struct {
 		Level string `mapstructure:"level"`
 	} `mapstructure:"logging"`
@@ -58,7 +161,9 @@ type FullConfig struct {
 	Environment string `mapstructure:"environment"`
 }
 
-func LoadConfig() (FullConfig, error) {
+// LoadConfig reads the configuration from path, or when path is empty from a config.yaml
+// in the working directory or ./config if there is one
+func LoadConfig(path string) (FullConfig, error) {
 	var config FullConfig
 
 	// Set defaults
@@ -67,14 +172,33 @@ func LoadConfig() (FullConfig, error) {
 	viper.SetDefault("server.read_timeout", "15s")
 	viper.SetDefault("server.write_timeout", "15s")
 
+	viper.SetDefault("auth.mode", "headers")
 	viper.SetDefault("auth.user_id_header", "x-interface-user-id")
 	viper.SetDefault("auth.email_header", "x-interface-user-email")
 	viper.SetDefault("auth.handler_header", "x-interface-user-handler")
+	viper.SetDefault("auth.jwt.algorithm", "RS256")
+	viper.SetDefault("auth.jwt.leeway", "30s")
+	viper.SetDefault("auth.jwt.user_id_claim", "sub")
+	viper.SetDefault("auth.jwt.email_claim", "email")
+	viper.SetDefault("auth.jwt.handler_claim", "handler")
 
 	viper.SetDefault("cors.allowed_origins", []string{"*"})
 	viper.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
 	viper.SetDefault("cors.allowed_headers", []string{})
 
+	viper.SetDefault("websocket.ping_interval", "30s")
+	viper.SetDefault("websocket.pong_timeout", "45s")
+	viper.SetDefault("websocket.write_timeout", "10s")
+	viper.SetDefault("websocket.max_message_size", 65536)
+	viper.SetDefault("websocket.send_buffer_size", 64)
+
+	viper.SetDefault("rate_limit.enabled", true)
+	viper.SetDefault("rate_limit.store", "memory")
+	viper.SetDefault("rate_limit.trust_forwarded_for", false)
+	viper.SetDefault("rate_limit.default.requests", 600)
+	viper.SetDefault("rate_limit.default.window", "1m")
+	viper.SetDefault("rate_limit.default.burst", 100)
+
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", 5432)
 	viper.SetDefault("database.user", "postgres")
@@ -84,6 +208,7 @@ func LoadConfig() (FullConfig, error) {
 	viper.SetDefault("database.max_connections", 25)
 	viper.SetDefault("database.max_idle_time", "15m")
 	viper.SetDefault("database.conn_max_lifetime", "1h")
+	viper.SetDefault("database.migrate_on_start", false)
 
 	viper.SetDefault("nats.url", "nats://localhost:4222")
 	viper.SetDefault("nats.max_reconnects", 10)
@@ -91,6 +216,48 @@ func LoadConfig() (FullConfig, error) {
 	viper.SetDefault("nats.connect_timeout", "5s")
 	viper.SetDefault("nats.request_timeout", "10s")
 	viper.SetDefault("nats.enable_jetstream", false)
+	viper.SetDefault("nats.stream_name", "MESSAGING")
+	viper.SetDefault("nats.stream_max_age", "168h")
+	viper.SetDefault("nats.duplicate_window", "2m")
+
+	viper.SetDefault("outbox.poll_interval", "500ms")
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("outbox.lease", "30s")
+	viper.SetDefault("outbox.base_backoff", "1s")
+	viper.SetDefault("outbox.max_backoff", "5m")
+	viper.SetDefault("outbox.max_attempts", 10)
+	viper.SetDefault("outbox.max_lag", "1m")
+
+	viper.SetDefault("health.check_timeout", "2s")
+	viper.SetDefault("health.shutdown_delay", "0s")
+
+	viper.SetDefault("metrics.enabled", true)
+	viper.SetDefault("metrics.path", "/metrics")
+
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.service_name", "messaging-app")
+	viper.SetDefault("tracing.endpoint", "")
+	viper.SetDefault("tracing.insecure", false)
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+
+	viper.SetDefault("messages.edit_window", "15m")
+	viper.SetDefault("messages.delete_window", "1h")
+	viper.SetDefault("messages.send_rate_limit.requests", 60)
+	viper.SetDefault("messages.send_rate_limit.window", "1m")
+	viper.SetDefault("messages.send_rate_limit.burst", 20)
+
+	viper.SetDefault("presence.ttl", "60s")
+	viper.SetDefault("presence.sweep_interval", "10s")
+
+	viper.SetDefault("attachments.max_size", 10485760)
+	viper.SetDefault("attachments.allowed_types", []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf", "text/plain"})
+	viper.SetDefault("attachments.upload_rate_limit.requests", 20)
+	viper.SetDefault("attachments.upload_rate_limit.window", "1m")
+	viper.SetDefault("attachments.upload_rate_limit.burst", 5)
+	viper.SetDefault("attachments.unattached_ttl", "24h")
+	viper.SetDefault("attachments.cleanup_interval", "10m")
+	viper.SetDefault("attachments.storage.type", "local")
+	viper.SetDefault("attachments.storage.path", "./data/attachments")
 
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("environment", "development")
@@ -99,14 +266,18 @@ func LoadConfig() (FullConfig, error) {
 	viper.SetEnvPrefix("MESSAGING_APP")
 	viper.AutomaticEnv()
 
-	// Read from config file if it exists
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("./config")
+	// Read from config file if it exists; a file given explicitly must exist
+	if path != "" {
+		viper.SetConfigFile(path)
+	} else {
+		viper.SetConfigName("config")
+		viper.SetConfigType("yaml")
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("./config")
+	}
 
 	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok || path != "" {
 			return config, fmt.Errorf("failed to read config file: %w", err)
 		}
 		// Config file not found, use defaults and environment variables
@@ -124,6 +295,65 @@ func (fc FullConfig) GetApplicationConfig() Config {
 	return Config{
 		Server:      fc.Server,
 		Environment: fc.Environment,
+		Outbox: OutboxRelayConfig{
+			PollInterval: fc.Outbox.PollInterval,
+			BatchSize:    fc.Outbox.BatchSize,
+			Lease:        fc.Outbox.Lease,
+			BaseBackoff:  fc.Outbox.BaseBackoff,
+			MaxBackoff:   fc.Outbox.MaxBackoff,
+			MaxAttempts:  fc.Outbox.MaxAttempts,
+			MaxLag:       fc.Outbox.MaxLag,
+		},
+		Messages: httphandlers.MessageConfig{
+			EditWindow:    fc.Messages.EditWindow,
+			DeleteWindow:  fc.Messages.DeleteWindow,
+			SendRateLimit: fc.Messages.SendRateLimit.toDomain(),
+		},
+		Presence: PresenceConfig{
+			TTL:           fc.Presence.TTL,
+			SweepInterval: fc.Presence.SweepInterval,
+		},
+		Attachments: httphandlers.AttachmentConfig{
+			MaxSize:         fc.Attachments.MaxSize,
+			AllowedTypes:    fc.Attachments.AllowedTypes,
+			UploadRateLimit: fc.Attachments.UploadRateLimit.toDomain(),
+		},
+		AttachmentCleanup: AttachmentCleanupConfig{
+			UnattachedTTL: fc.Attachments.UnattachedTTL,
+			SweepInterval: fc.Attachments.CleanupInterval,
+		},
+	}
+}
+
+// GetAuthConfig extracts the authenticator configuration
+func (fc FullConfig) GetAuthConfig() auth.Config {
+	return auth.Config{
+		Mode:          fc.Auth.Mode,
+		UserIDHeader:  fc.Auth.UserIDHeader,
+		EmailHeader:   fc.Auth.EmailHeader,
+		HandlerHeader: fc.Auth.HandlerHeader,
+		JWT: auth.JWTConfig{
+			Algorithm:    fc.Auth.JWT.Algorithm,
+			Secret:       fc.Auth.JWT.Secret,
+			JWKSFile:     fc.Auth.JWT.JWKSFile,
+			Issuer:       fc.Auth.JWT.Issuer,
+			Audience:     fc.Auth.JWT.Audience,
+			Leeway:       fc.Auth.JWT.Leeway,
+			UserIDClaim:  fc.Auth.JWT.UserIDClaim,
+			EmailClaim:   fc.Auth.JWT.EmailClaim,
+			HandlerClaim: fc.Auth.JWT.HandlerClaim,
+		},
+	}
+}
+
+// GetTracingConfig extracts the tracing configuration
+func (fc FullConfig) GetTracingConfig() tracing.Config {
+	return tracing.Config{
+		Exporter:    fc.Tracing.Exporter,
+		ServiceName: fc.Tracing.ServiceName,
+		Endpoint:    fc.Tracing.Endpoint,
+		Insecure:    fc.Tracing.Insecure,
+		SampleRatio: fc.Tracing.SampleRatio,
 	}
 }
 
@@ -144,5 +374,25 @@ func (fc FullConfig) GetHTTPConfig() httpAdapter.Config {
 			AllowedMethods: fc.CORS.AllowedMethods,
 			AllowedHeaders: fc.CORS.AllowedHeaders,
 		},
+		WebSocket: httpAdapter.WebSocketConfig{
+			PingInterval:   fc.WebSocket.PingInterval,
+			PongTimeout:    fc.WebSocket.PongTimeout,
+			WriteTimeout:   fc.WebSocket.WriteTimeout,
+			MaxMessageSize: fc.WebSocket.MaxMessageSize,
+			SendBufferSize: fc.WebSocket.SendBufferSize,
+		},
+		RateLimit: httpAdapter.RateLimitConfig{
+			Enabled:           fc.RateLimit.Enabled,
+			Default:           fc.RateLimit.Default.toDomain(),
+			TrustForwardedFor: fc.RateLimit.TrustForwardedFor,
+		},
+		Health: httpAdapter.HealthConfig{
+			CheckTimeout:  fc.Health.CheckTimeout,
+			ShutdownDelay: fc.Health.ShutdownDelay,
+		},
+		Metrics: httpAdapter.MetricsConfig{
+			Enabled: fc.Metrics.Enabled,
+			Path:    fc.Metrics.Path,
+		},
 	}
 }
\ No newline at end of file
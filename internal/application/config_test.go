@@ -0,0 +1,24 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_ExplicitPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messaging.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  host: db.internal\n"), 0o600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal", config.Database.Host)
+	assert.Equal(t, 5432, config.Database.Port)
+
+	// Unlike the default locations, a file asked for explicitly must exist
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,317 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+const tracerName = "messaging-app/internal/application"
+
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	MaxAttempts  int
+	MaxLag       time.Duration // Health checks report the outbox degraded past this lag
+}
+
+func DefaultOutboxRelayConfig() OutboxRelayConfig {
+	return OutboxRelayConfig{
+		PollInterval: 500 * time.Millisecond,
+		BatchSize:    100,
+		Lease:        30 * time.Second,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   5 * time.Minute,
+		MaxAttempts:  10,
+		MaxLag:       time.Minute,
+	}
+}
+
+// OutboxRelay drains committed outbox events to the real-time publisher
+type OutboxRelay struct {
+	config    OutboxRelayConfig
+	outbox    ports.OutboxRepository
+	chatRepo  ports.ChatRepository
+	publisher ports.MessagePublisher
+	logger    ports.Logger
+}
+
+func NewOutboxRelay(config OutboxRelayConfig, outbox ports.OutboxRepository, chatRepo ports.ChatRepository, publisher ports.MessagePublisher, logger ports.Logger) *OutboxRelay {
+	defaults := DefaultOutboxRelayConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaults.Lease
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = defaults.BaseBackoff
+	}
+	if config.MaxBackoff < config.BaseBackoff {
+		config.MaxBackoff = config.BaseBackoff
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.MaxLag <= 0 {
+		config.MaxLag = defaults.MaxLag
+	}
+
+	return &OutboxRelay{
+		config:    config,
+		outbox:    outbox,
+		chatRepo:  chatRepo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	r.logger.Info("Outbox relay started", "poll_interval", r.config.PollInterval, "batch_size", r.config.BatchSize)
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep draining while full batches come back, then wait for the next tick
+		for {
+			processed, err := r.RelayBatch(ctx)
+			if err != nil {
+				r.logger.Error("Failed to relay outbox events", "error", err)
+				break
+			}
+			if processed < r.config.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch claims one batch of due events and publishes them
+// Returns the number of events claimed
+func (r *OutboxRelay) RelayBatch(ctx context.Context) (int, error) {
+	events, err := r.outbox.ClaimEvents(ctx, r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		publishErr := r.relay(ctx, &event)
+		if publishErr == nil {
+			if err := r.outbox.DeleteEvent(ctx, event.ID); err != nil {
+				r.logger.Error("Failed to delete relayed outbox event", "error", err, "event_id", event.ID)
+			}
+			continue
+		}
+
+		if event.Attempts >= r.config.MaxAttempts {
+			r.logger.Error("Giving up on outbox event", "error", publishErr, "event_id", event.ID, "type", event.EventType, "attempts", event.Attempts)
+			if err := r.outbox.FailEvent(ctx, event.ID, publishErr.Error()); err != nil {
+				r.logger.Error("Failed to park outbox event", "error", err, "event_id", event.ID)
+			}
+			continue
+		}
+
+		nextAttemptAt := time.Now().UTC().Add(r.backoff(event.Attempts))
+		r.logger.Warn("Failed to relay outbox event, will retry", "error", publishErr, "event_id", event.ID, "attempts", event.Attempts, "next_attempt_at", nextAttemptAt)
+		if err := r.outbox.RetryEvent(ctx, event.ID, nextAttemptAt, publishErr.Error(), event.DeliveredTo); err != nil {
+			r.logger.Error("Failed to reschedule outbox event", "error", err, "event_id", event.ID)
+		}
+	}
+
+	return len(events), nil
+}
+
+// Name implements ports.HealthChecker
+func (r *OutboxRelay) Name() string {
+	return "outbox"
+}
+
+// Check implements ports.HealthChecker
+// Messages are still saved while real-time delivery lags behind, so lag only degrades the outbox
+func (r *OutboxRelay) Check(ctx context.Context) error {
+	oldest, err := r.outbox.OldestPendingEventAt(ctx)
+	if err != nil {
+		return err
+	}
+	if oldest == nil {
+		return nil
+	}
+
+	if lag := time.Since(*oldest); lag > r.config.MaxLag {
+		return fmt.Errorf("%w: oldest pending event is %s old", domain.ErrHealthDegraded, lag.Round(time.Second))
+	}
+	return nil
+}
+
+// relay publishes an event in a span continuing the trace of the request that produced it
+func (r *OutboxRelay) relay(ctx context.Context, event *domain.OutboxEvent) error {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(event.TraceContext))
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "outbox relay "+string(event.EventType),
+		trace.WithAttributes(
+			attribute.Int64("outbox.event_id", event.ID),
+			attribute.Int("outbox.attempt", event.Attempts),
+		),
+	)
+	defer span.End()
+
+	err := r.publish(ctx, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// publish delivers an event; group events record the members they reached in event.DeliveredTo
+func (r *OutboxRelay) publish(ctx context.Context, event *domain.OutboxEvent) error {
+	switch event.EventType {
+	case domain.MessageTypeNewMessage:
+		var message domain.Message
+		if err := json.Unmarshal(event.Payload, &message); err != nil {
+			return fmt.Errorf("decode message event: %w", err)
+		}
+
+		if !domain.IsGroupChatID(event.Recipient) {
+			recipients, err := r.unmuted(ctx, message.GetChatID(), []string{event.Recipient})
+			if err != nil || len(recipients) == 0 {
+				return err
+			}
+			return r.publisher.PublishMessage(ctx, message)
+		}
+
+		// Members are resolved at delivery time so late joiners and leavers are respected
+		memberIDs, err := r.chatRepo.GetMemberIDs(ctx, event.Recipient)
+		if err != nil {
+			return fmt.Errorf("get group members: %w", err)
+		}
+		memberIDs, err = r.unmuted(ctx, event.Recipient, memberIDs)
+		if err != nil {
+			return err
+		}
+		return r.fanOut(event, memberIDs, func(userIDs []string) error {
+			return r.publisher.PublishGroupMessage(ctx, message, userIDs)
+		})
+
+	case domain.MessageTypeMessageEdited, domain.MessageTypeMessageDeleted:
+		var message domain.Message
+		if err := json.Unmarshal(event.Payload, &message); err != nil {
+			return fmt.Errorf("decode message update event: %w", err)
+		}
+
+		if !domain.IsGroupChatID(event.Recipient) {
+			return r.publisher.PublishMessageUpdate(ctx, event.EventType, message, []string{event.Recipient})
+		}
+
+		// Every member follows the change, including the sender's other devices
+		memberIDs, err := r.chatRepo.GetMemberIDs(ctx, event.Recipient)
+		if err != nil {
+			return fmt.Errorf("get group members: %w", err)
+		}
+		return r.fanOut(event, memberIDs, func(userIDs []string) error {
+			return r.publisher.PublishMessageUpdate(ctx, event.EventType, message, userIDs)
+		})
+
+	case domain.MessageTypeReactionUpdated:
+		var update domain.ReactionUpdate
+		if err := json.Unmarshal(event.Payload, &update); err != nil {
+			return fmt.Errorf("decode reaction event: %w", err)
+		}
+
+		if !domain.IsGroupChatID(event.Recipient) {
+			return r.publisher.PublishReactionUpdate(ctx, update, []string{event.Recipient})
+		}
+
+		memberIDs, err := r.chatRepo.GetMemberIDs(ctx, event.Recipient)
+		if err != nil {
+			return fmt.Errorf("get group members: %w", err)
+		}
+		return r.fanOut(event, memberIDs, func(userIDs []string) error {
+			return r.publisher.PublishReactionUpdate(ctx, update, userIDs)
+		})
+
+	case domain.MessageTypeStatusUpdate:
+		var statusUpdate ports.StatusUpdate
+		if err := json.Unmarshal(event.Payload, &statusUpdate); err != nil {
+			return fmt.Errorf("decode status event: %w", err)
+		}
+		return r.publisher.PublishStatusUpdate(ctx, event.Recipient, statusUpdate)
+
+	default:
+		return fmt.Errorf("unknown outbox event type %q", event.EventType)
+	}
+}
+
+// fanOut publishes to the members the event has not reached yet, one at a time, so that a retry
+// only re-sends to the members that failed rather than duplicating the event for everyone else
+func (r *OutboxRelay) fanOut(event *domain.OutboxEvent, memberIDs []string, publish func(userIDs []string) error) error {
+	var errs []error
+	for _, memberID := range memberIDs {
+		if slices.Contains(event.DeliveredTo, memberID) {
+			continue
+		}
+		if err := publish([]string{memberID}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		event.DeliveredTo = append(event.DeliveredTo, memberID)
+	}
+	return errors.Join(errs...)
+}
+
+// unmuted drops the users who muted the chat; new messages are stored for them but not published
+func (r *OutboxRelay) unmuted(ctx context.Context, chatID string, userIDs []string) ([]string, error) {
+	mutedIDs, err := r.chatRepo.GetMutedUserIDs(ctx, chatID, userIDs, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("get muted users: %w", err)
+	}
+	if len(mutedIDs) == 0 {
+		return userIDs, nil
+	}
+
+	recipients := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !slices.Contains(mutedIDs, userID) {
+			recipients = append(recipients, userID)
+		}
+	}
+	return recipients, nil
+}
+
+// backoff doubles the delay after every failed attempt, capped at MaxBackoff
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := r.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= r.config.MaxBackoff {
+			return r.config.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,363 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/ports"
+	"messaging-app/internal/testutils"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type OutboxRelayTestSuite struct {
+	suite.Suite
+	relay         *OutboxRelay
+	mockOutbox    *mocks.OutboxRepository
+	mockChatRepo  *mocks.ChatRepository
+	mockPublisher *mocks.MessagePublisher
+}
+
+func (s *OutboxRelayTestSuite) SetupTest() {
+	s.mockOutbox = &mocks.OutboxRepository{}
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.mockPublisher = &mocks.MessagePublisher{}
+
+	config := DefaultOutboxRelayConfig()
+	config.MaxAttempts = 3
+	s.relay = NewOutboxRelay(config, s.mockOutbox, s.mockChatRepo, s.mockPublisher, testutils.NewTestLogger(s.T()))
+}
+
+func (s *OutboxRelayTestSuite) TearDownTest() {
+	s.mockOutbox.AssertExpectations(s.T())
+	s.mockChatRepo.AssertExpectations(s.T())
+	s.mockPublisher.AssertExpectations(s.T())
+}
+
+func (s *OutboxRelayTestSuite) claim(events ...domain.OutboxEvent) {
+	s.mockOutbox.On("ClaimEvents", mock.Anything, s.relay.config.BatchSize, s.relay.config.Lease).Return(events, nil).Once()
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_PublishesMessage() {
+	message := testdata.ValidMessages()[0]
+	event, err := domain.NewMessageOutboxEvent(message)
+	s.Require().NoError(err)
+	event.ID = 1
+	event.Attempts = 1
+
+	s.claim(event)
+	s.mockChatRepo.On("GetMutedUserIDs", mock.Anything, message.GetChatID(), []string{message.ReceiverID}, mock.Anything).Return(nil, nil)
+	s.mockPublisher.On("PublishMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+		return msg.SenderID == message.SenderID && msg.Content == message.Content
+	})).Return(nil)
+	s.mockOutbox.On("DeleteEvent", mock.Anything, int64(1)).Return(nil)
+
+	processed, err := s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+	s.Equal(1, processed)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_FansOutGroupMessage() {
+	chatID := domain.NewGroupChatID()
+	members := []string{testdata.Alice.UserID, testdata.Bob.UserID}
+	message := domain.Message{
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: chatID,
+		CreatedAt:  testdata.BaseTime,
+		Content:    "Hello team!",
+		Status:     domain.MessageStatusSent,
+	}
+	event, err := domain.NewMessageOutboxEvent(message)
+	s.Require().NoError(err)
+	event.ID = 2
+	event.Attempts = 1
+
+	s.claim(event)
+	s.mockChatRepo.On("GetMemberIDs", mock.Anything, chatID).Return(members, nil)
+	s.mockChatRepo.On("GetMutedUserIDs", mock.Anything, chatID, members, mock.Anything).Return(nil, nil)
+	for _, member := range members {
+		s.mockPublisher.On("PublishGroupMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+			return msg.ReceiverID == chatID
+		}), []string{member}).Return(nil).Once()
+	}
+	s.mockOutbox.On("DeleteEvent", mock.Anything, int64(2)).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_SkipsMutedRecipient() {
+	message := testdata.ValidMessages()[0]
+	event, err := domain.NewMessageOutboxEvent(message)
+	s.Require().NoError(err)
+	event.ID = 6
+	event.Attempts = 1
+
+	s.claim(event)
+	s.mockChatRepo.On("GetMutedUserIDs", mock.Anything, message.GetChatID(), []string{message.ReceiverID}, mock.Anything).Return([]string{message.ReceiverID}, nil)
+	s.mockOutbox.On("DeleteEvent", mock.Anything, int64(6)).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+	s.mockPublisher.AssertNotCalled(s.T(), "PublishMessage", mock.Anything, mock.Anything)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_DropsMutedGroupMembers() {
+	chatID := domain.NewGroupChatID()
+	members := []string{testdata.Alice.UserID, testdata.Bob.UserID, testdata.Charlie.UserID}
+	message := domain.Message{
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: chatID,
+		CreatedAt:  testdata.BaseTime,
+		Content:    "Hello team!",
+		Status:     domain.MessageStatusSent,
+	}
+	event, err := domain.NewMessageOutboxEvent(message)
+	s.Require().NoError(err)
+	event.ID = 7
+	event.Attempts = 1
+
+	s.claim(event)
+	s.mockChatRepo.On("GetMemberIDs", mock.Anything, chatID).Return(members, nil)
+	s.mockChatRepo.On("GetMutedUserIDs", mock.Anything, chatID, members, mock.Anything).Return([]string{testdata.Bob.UserID}, nil)
+	s.mockPublisher.On("PublishGroupMessage", mock.Anything, mock.Anything, []string{testdata.Alice.UserID}).Return(nil).Once()
+	s.mockPublisher.On("PublishGroupMessage", mock.Anything, mock.Anything, []string{testdata.Charlie.UserID}).Return(nil).Once()
+	s.mockOutbox.On("DeleteEvent", mock.Anything, int64(7)).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_PublishesMessageUpdates() {
+	chatID := domain.NewGroupChatID()
+	members := []string{testdata.Alice.UserID, testdata.Bob.UserID}
+	message := testdata.ValidMessages()[0]
+	tombstone := message.Tombstone(testdata.BaseTime.Add(time.Minute))
+	tombstone.ReceiverID = chatID
+
+	edited, err := domain.NewMessageUpdateOutboxEvents(domain.MessageTypeMessageEdited, message)
+	s.Require().NoError(err)
+	deleted, err := domain.NewMessageUpdateOutboxEvents(domain.MessageTypeMessageDeleted, tombstone)
+	s.Require().NoError(err)
+	s.Require().Len(edited, 2)
+	s.Require().Len(deleted, 1)
+	edited[0].ID, edited[1].ID, deleted[0].ID = 3, 4, 5
+
+	s.claim(edited[0], edited[1], deleted[0])
+	s.mockPublisher.On("PublishMessageUpdate", mock.Anything, domain.MessageTypeMessageEdited, mock.Anything, []string{message.ReceiverID}).Return(nil)
+	s.mockPublisher.On("PublishMessageUpdate", mock.Anything, domain.MessageTypeMessageEdited, mock.Anything, []string{message.SenderID}).Return(nil)
+	s.mockChatRepo.On("GetMemberIDs", mock.Anything, chatID).Return(members, nil)
+	for _, member := range members {
+		s.mockPublisher.On("PublishMessageUpdate", mock.Anything, domain.MessageTypeMessageDeleted, mock.MatchedBy(func(msg domain.Message) bool {
+			return msg.IsDeleted() && msg.Content == ""
+		}), []string{member}).Return(nil).Once()
+	}
+	s.mockOutbox.On("DeleteEvent", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Times(3)
+
+	processed, err := s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+	s.Equal(3, processed)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_PublishesReactionUpdate() {
+	message := testdata.ValidMessages()[0]
+	update := domain.ReactionUpdate{
+		MessageID: domain.NewMessageID(),
+		ChatID:    message.GetChatID(),
+		UserID:    message.ReceiverID,
+		Emoji:     "👍",
+		Action:    domain.ReactionAdded,
+		UpdatedAt: testdata.BaseTime,
+	}
+	events, err := domain.NewReactionOutboxEvents(message, update)
+	s.Require().NoError(err)
+	s.Require().Len(events, 2)
+	events[0].ID = 6
+
+	s.claim(events[0])
+	s.mockPublisher.On("PublishReactionUpdate", mock.Anything, mock.MatchedBy(func(got domain.ReactionUpdate) bool {
+		return got.MessageID == update.MessageID && got.Emoji == "👍"
+	}), []string{message.ReceiverID}).Return(nil)
+	s.mockOutbox.On("DeleteEvent", mock.Anything, int64(6)).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_PublishesStatusUpdate() {
+	statusUpdate := ports.StatusUpdate{
+		MessageID: testdata.ValidMessages()[0].Key(),
+		Status:    domain.MessageStatusRead,
+		UpdatedBy: testdata.Bob.UserID,
+		UpdatedAt: testdata.BaseTime,
+	}
+	event, err := ports.NewStatusOutboxEvent(testdata.Alice.UserID, statusUpdate)
+	s.Require().NoError(err)
+	event.ID = 3
+	event.Attempts = 1
+
+	s.claim(event)
+	s.mockPublisher.On("PublishStatusUpdate", mock.Anything, testdata.Alice.UserID, mock.MatchedBy(func(update ports.StatusUpdate) bool {
+		return update.MessageID.SenderID == statusUpdate.MessageID.SenderID &&
+			update.Status == domain.MessageStatusRead &&
+			update.UpdatedBy == testdata.Bob.UserID
+	})).Return(nil)
+	s.mockOutbox.On("DeleteEvent", mock.Anything, int64(3)).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_RetriesWithBackoff() {
+	event, err := domain.NewMessageOutboxEvent(testdata.ValidMessages()[0])
+	s.Require().NoError(err)
+	event.ID = 4
+	event.Attempts = 2
+
+	s.claim(event)
+	s.mockChatRepo.On("GetMutedUserIDs", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	s.mockPublisher.On("PublishMessage", mock.Anything, mock.Anything).Return(assert.AnError)
+	s.mockOutbox.On("RetryEvent", mock.Anything, int64(4), mock.MatchedBy(func(next time.Time) bool {
+		// Second attempt waits twice the base backoff
+		return next.After(time.Now().Add(time.Second)) && next.Before(time.Now().Add(3*time.Second))
+	}), assert.AnError.Error(), []string(nil)).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_RetriesOnlyFailedGroupMembers() {
+	chatID := domain.NewGroupChatID()
+	members := []string{testdata.Alice.UserID, testdata.Bob.UserID, testdata.Charlie.UserID, testdata.Diana.UserID}
+	message := domain.Message{
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: chatID,
+		CreatedAt:  testdata.BaseTime,
+		Content:    "Hello team!",
+		Status:     domain.MessageStatusSent,
+	}
+	event, err := domain.NewMessageOutboxEvent(message)
+	s.Require().NoError(err)
+	event.ID = 8
+	event.Attempts = 2
+	event.DeliveredTo = []string{testdata.Alice.UserID, testdata.Bob.UserID}
+
+	// Members reached by the previous attempt are skipped, the one that fails again is kept for the next
+	s.claim(event)
+	s.mockChatRepo.On("GetMemberIDs", mock.Anything, chatID).Return(members, nil)
+	s.mockChatRepo.On("GetMutedUserIDs", mock.Anything, chatID, members, mock.Anything).Return(nil, nil)
+	s.mockPublisher.On("PublishGroupMessage", mock.Anything, mock.Anything, []string{testdata.Charlie.UserID}).Return(assert.AnError).Once()
+	s.mockPublisher.On("PublishGroupMessage", mock.Anything, mock.Anything, []string{testdata.Diana.UserID}).Return(nil).Once()
+	s.mockOutbox.On("RetryEvent", mock.Anything, int64(8), mock.Anything, assert.AnError.Error(),
+		[]string{testdata.Alice.UserID, testdata.Bob.UserID, testdata.Diana.UserID}).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_ParksAfterMaxAttempts() {
+	event, err := domain.NewMessageOutboxEvent(testdata.ValidMessages()[0])
+	s.Require().NoError(err)
+	event.ID = 5
+	event.Attempts = 3
+
+	s.claim(event)
+	s.mockChatRepo.On("GetMutedUserIDs", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	s.mockPublisher.On("PublishMessage", mock.Anything, mock.Anything).Return(assert.AnError)
+	s.mockOutbox.On("FailEvent", mock.Anything, int64(5), assert.AnError.Error()).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+
+	s.NoError(err)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_ClaimError() {
+	s.mockOutbox.On("ClaimEvents", mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	processed, err := s.relay.RelayBatch(context.Background())
+
+	s.ErrorIs(err, assert.AnError)
+	s.Equal(0, processed)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayBatch_ContinuesTrace() {
+	spans := testutils.NewTestTracer(s.T())
+
+	event, err := ports.NewStatusOutboxEvent(testdata.Alice.UserID, ports.StatusUpdate{
+		MessageID: testdata.ValidMessages()[0].Key(),
+		Status:    domain.MessageStatusRead,
+		UpdatedBy: testdata.Bob.UserID,
+	})
+	s.Require().NoError(err)
+	event.ID = 7
+	event.Attempts = 1
+	event.TraceContext = map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+
+	var published trace.SpanContext
+	s.claim(event)
+	s.mockPublisher.On("PublishStatusUpdate", mock.Anything, testdata.Alice.UserID, mock.Anything).Run(func(args mock.Arguments) {
+		published = trace.SpanContextFromContext(args.Get(0).(context.Context))
+	}).Return(nil)
+	s.mockOutbox.On("DeleteEvent", mock.Anything, int64(7)).Return(nil)
+
+	_, err = s.relay.RelayBatch(context.Background())
+	s.Require().NoError(err)
+
+	// The publisher runs inside a span of the trace the request started
+	ended := spans.GetSpans()
+	s.Require().Len(ended, 1)
+	s.Equal("outbox relay status_update", ended[0].Name)
+	s.Equal("4bf92f3577b34da6a3ce929d0e0e4736", ended[0].SpanContext.TraceID().String())
+	s.Equal("00f067aa0ba902b7", ended[0].Parent.SpanID().String())
+	s.Equal(ended[0].SpanContext.SpanID(), published.SpanID())
+}
+
+func (s *OutboxRelayTestSuite) TestCheck_Lag() {
+	recent := time.Now().UTC().Add(-time.Second)
+	stale := time.Now().UTC().Add(-2 * s.relay.config.MaxLag)
+
+	s.mockOutbox.On("OldestPendingEventAt", mock.Anything).Return(nil, nil).Once()
+	s.NoError(s.relay.Check(context.Background()))
+
+	s.mockOutbox.On("OldestPendingEventAt", mock.Anything).Return(&recent, nil).Once()
+	s.NoError(s.relay.Check(context.Background()))
+
+	// A lagging outbox is degraded rather than down
+	s.mockOutbox.On("OldestPendingEventAt", mock.Anything).Return(&stale, nil).Once()
+	s.ErrorIs(s.relay.Check(context.Background()), domain.ErrHealthDegraded)
+
+	s.mockOutbox.On("OldestPendingEventAt", mock.Anything).Return(nil, assert.AnError).Once()
+	err := s.relay.Check(context.Background())
+	s.ErrorIs(err, assert.AnError)
+	s.NotErrorIs(err, domain.ErrHealthDegraded)
+}
+
+func TestOutboxRelaySuite(t *testing.T) {
+	suite.Run(t, new(OutboxRelayTestSuite))
+}
+
+func TestOutboxRelay_Backoff(t *testing.T) {
+	relay := NewOutboxRelay(OutboxRelayConfig{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}, nil, nil, nil, nil)
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 4*time.Second, relay.backoff(3))
+	assert.Equal(t, 5*time.Second, relay.backoff(4))
+	assert.Equal(t, 5*time.Second, relay.backoff(20))
+}
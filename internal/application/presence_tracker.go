@@ -0,0 +1,188 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+type PresenceConfig struct {
+	TTL           time.Duration
+	SweepInterval time.Duration
+}
+
+func DefaultPresenceConfig() PresenceConfig {
+	return PresenceConfig{
+		TTL:           60 * time.Second,
+		SweepInterval: 10 * time.Second,
+	}
+}
+
+// PresenceTracker keeps users online while they send heartbeats and announces changes to their contacts
+// Presence is ephemeral, so announcements are published directly instead of going through the outbox
+type PresenceTracker struct {
+	config    PresenceConfig
+	repo      ports.PresenceRepository
+	chatRepo  ports.ChatRepository
+	publisher ports.MessagePublisher
+	logger    ports.Logger
+}
+
+func NewPresenceTracker(config PresenceConfig, repo ports.PresenceRepository, chatRepo ports.ChatRepository, publisher ports.MessagePublisher, logger ports.Logger) *PresenceTracker {
+	defaults := DefaultPresenceConfig()
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+	if config.SweepInterval <= 0 {
+		config.SweepInterval = defaults.SweepInterval
+	}
+
+	return &PresenceTracker{
+		config:    config,
+		repo:      repo,
+		chatRepo:  chatRepo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+// Heartbeat implements ports.PresenceTracker
+func (t *PresenceTracker) Heartbeat(ctx context.Context, userID string) error {
+	_, err := t.touch(ctx, userID)
+	return err
+}
+
+// Typing implements ports.PresenceTracker
+func (t *PresenceTracker) Typing(ctx context.Context, userID, chatID string) error {
+	seenAt, err := t.touch(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := t.chatPartners(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
+
+	t.announce(ctx, domain.Presence{
+		UserID:   userID,
+		Status:   domain.StatusTyping,
+		ChatID:   chatID,
+		LastSeen: seenAt,
+	}, recipients)
+	return nil
+}
+
+// Run expires silent users until ctx is cancelled
+func (t *PresenceTracker) Run(ctx context.Context) {
+	t.logger.Info("Presence tracker started", "ttl", t.config.TTL, "sweep_interval", t.config.SweepInterval)
+
+	ticker := time.NewTicker(t.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.logger.Info("Presence tracker stopped")
+			return
+		case <-ticker.C:
+			if _, err := t.Sweep(ctx); err != nil {
+				t.logger.Error("Failed to expire presence", "error", err)
+			}
+		}
+	}
+}
+
+// Sweep marks users whose last heartbeat is older than the TTL as offline and announces it
+// Returns the number of users that went offline
+func (t *PresenceTracker) Sweep(ctx context.Context) (int, error) {
+	expired, err := t.repo.ExpirePresence(ctx, time.Now().UTC().Add(-t.config.TTL))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, presence := range expired {
+		contactIDs, err := t.repo.GetContactIDs(ctx, presence.UserID)
+		if err != nil {
+			t.logger.Error("Failed to get contacts", "error", err, "user", presence.UserID)
+			continue
+		}
+		t.announce(ctx, presence, contactIDs)
+	}
+
+	return len(expired), nil
+}
+
+// touch records the user's activity and announces them to their contacts if they just came online
+func (t *PresenceTracker) touch(ctx context.Context, userID string) (time.Time, error) {
+	seenAt := time.Now().UTC()
+
+	cameOnline, err := t.repo.TouchPresence(ctx, userID, seenAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !cameOnline {
+		return seenAt, nil
+	}
+
+	// The user is online either way; a retried heartbeat would not announce them again
+	contactIDs, err := t.repo.GetContactIDs(ctx, userID)
+	if err != nil {
+		t.logger.Error("Failed to get contacts", "error", err, "user", userID)
+		return seenAt, nil
+	}
+
+	t.announce(ctx, domain.Presence{
+		UserID:   userID,
+		Status:   domain.StatusOnline,
+		LastSeen: seenAt,
+	}, contactIDs)
+	return seenAt, nil
+}
+
+// chatPartners returns the participants of chatID other than userID
+func (t *PresenceTracker) chatPartners(ctx context.Context, userID, chatID string) ([]string, error) {
+	if !domain.IsGroupChatID(chatID) {
+		first, second, err := domain.DirectChatParticipants(chatID)
+		if err != nil {
+			return nil, err
+		}
+		if first == userID {
+			return []string{second}, nil
+		}
+		return []string{first}, nil
+	}
+
+	memberIDs, err := t.chatRepo.GetMemberIDs(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("get group members: %w", err)
+	}
+
+	partners := make([]string, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if memberID != userID {
+			partners = append(partners, memberID)
+		}
+	}
+	return partners, nil
+}
+
+// announce publishes a presence change; failures are logged since the next change supersedes it
+func (t *PresenceTracker) announce(ctx context.Context, presence domain.Presence, userIDs []string) {
+	recipients := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != presence.UserID {
+			recipients = append(recipients, userID)
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	if err := t.publisher.PublishPresence(ctx, presence, recipients); err != nil {
+		t.logger.Error("Failed to publish presence", "error", err, "user", presence.UserID, "status", presence.Status)
+	}
+}
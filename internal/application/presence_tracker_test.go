@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/testutils"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PresenceTrackerTestSuite struct {
+	suite.Suite
+	tracker       *PresenceTracker
+	mockRepo      *mocks.PresenceRepository
+	mockChatRepo  *mocks.ChatRepository
+	mockPublisher *mocks.MessagePublisher
+}
+
+func (s *PresenceTrackerTestSuite) SetupTest() {
+	s.mockRepo = &mocks.PresenceRepository{}
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.mockPublisher = &mocks.MessagePublisher{}
+
+	s.tracker = NewPresenceTracker(DefaultPresenceConfig(), s.mockRepo, s.mockChatRepo, s.mockPublisher, testutils.NewTestLogger(s.T()))
+}
+
+func (s *PresenceTrackerTestSuite) TearDownTest() {
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockChatRepo.AssertExpectations(s.T())
+	s.mockPublisher.AssertExpectations(s.T())
+}
+
+func (s *PresenceTrackerTestSuite) TestHeartbeat_AnnouncesComingOnline() {
+	alice := testdata.Alice.UserID
+	contacts := []string{testdata.Bob.UserID, testdata.Charlie.UserID}
+
+	s.mockRepo.On("TouchPresence", mock.Anything, alice, mock.AnythingOfType("time.Time")).Return(true, nil)
+	s.mockRepo.On("GetContactIDs", mock.Anything, alice).Return(contacts, nil)
+	s.mockPublisher.On("PublishPresence", mock.Anything, mock.MatchedBy(func(presence domain.Presence) bool {
+		return presence.UserID == alice && presence.Status == domain.StatusOnline && presence.ChatID == ""
+	}), contacts).Return(nil)
+
+	err := s.tracker.Heartbeat(context.Background(), alice)
+
+	s.NoError(err)
+}
+
+func (s *PresenceTrackerTestSuite) TestHeartbeat_AlreadyOnline() {
+	alice := testdata.Alice.UserID
+
+	s.mockRepo.On("TouchPresence", mock.Anything, alice, mock.AnythingOfType("time.Time")).Return(false, nil)
+
+	err := s.tracker.Heartbeat(context.Background(), alice)
+
+	s.NoError(err)
+	s.mockPublisher.AssertNotCalled(s.T(), "PublishPresence", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *PresenceTrackerTestSuite) TestHeartbeat_RepositoryError() {
+	alice := testdata.Alice.UserID
+
+	s.mockRepo.On("TouchPresence", mock.Anything, alice, mock.AnythingOfType("time.Time")).Return(false, errors.New("database down"))
+
+	err := s.tracker.Heartbeat(context.Background(), alice)
+
+	s.Error(err)
+}
+
+func (s *PresenceTrackerTestSuite) TestTyping_DirectChat() {
+	alice := testdata.Alice.UserID
+	bob := testdata.Bob.UserID
+	chatID := domain.ComputeChatID(alice, bob)
+
+	s.mockRepo.On("TouchPresence", mock.Anything, alice, mock.AnythingOfType("time.Time")).Return(false, nil)
+	s.mockPublisher.On("PublishPresence", mock.Anything, mock.MatchedBy(func(presence domain.Presence) bool {
+		return presence.UserID == alice && presence.Status == domain.StatusTyping && presence.ChatID == chatID
+	}), []string{bob}).Return(nil)
+
+	err := s.tracker.Typing(context.Background(), alice, chatID)
+
+	s.NoError(err)
+}
+
+func (s *PresenceTrackerTestSuite) TestTyping_GroupChatSkipsTypist() {
+	alice := testdata.Alice.UserID
+	chatID := domain.NewGroupChatID()
+
+	s.mockRepo.On("TouchPresence", mock.Anything, alice, mock.AnythingOfType("time.Time")).Return(false, nil)
+	s.mockChatRepo.On("GetMemberIDs", mock.Anything, chatID).Return([]string{alice, testdata.Bob.UserID, testdata.Charlie.UserID}, nil)
+	s.mockPublisher.On("PublishPresence", mock.Anything, mock.MatchedBy(func(presence domain.Presence) bool {
+		return presence.Status == domain.StatusTyping && presence.ChatID == chatID
+	}), []string{testdata.Bob.UserID, testdata.Charlie.UserID}).Return(nil)
+
+	err := s.tracker.Typing(context.Background(), alice, chatID)
+
+	s.NoError(err)
+}
+
+func (s *PresenceTrackerTestSuite) TestSweep_AnnouncesOffline() {
+	alice := testdata.Alice.UserID
+	lastSeen := time.Now().UTC().Add(-2 * time.Minute)
+	expired := []domain.Presence{{UserID: alice, Status: domain.StatusOffline, LastSeen: lastSeen}}
+
+	s.mockRepo.On("ExpirePresence", mock.Anything, mock.MatchedBy(func(cutoff time.Time) bool {
+		return time.Since(cutoff) >= s.tracker.config.TTL
+	})).Return(expired, nil)
+	s.mockRepo.On("GetContactIDs", mock.Anything, alice).Return([]string{testdata.Bob.UserID}, nil)
+	s.mockPublisher.On("PublishPresence", mock.Anything, expired[0], []string{testdata.Bob.UserID}).Return(nil)
+
+	count, err := s.tracker.Sweep(context.Background())
+
+	s.NoError(err)
+	s.Equal(1, count)
+}
+
+func (s *PresenceTrackerTestSuite) TestSweep_PublishFailureDoesNotStopSweep() {
+	expired := []domain.Presence{
+		{UserID: testdata.Alice.UserID, Status: domain.StatusOffline},
+		{UserID: testdata.Bob.UserID, Status: domain.StatusOffline},
+	}
+
+	s.mockRepo.On("ExpirePresence", mock.Anything, mock.AnythingOfType("time.Time")).Return(expired, nil)
+	s.mockRepo.On("GetContactIDs", mock.Anything, testdata.Alice.UserID).Return([]string{testdata.Charlie.UserID}, nil)
+	s.mockRepo.On("GetContactIDs", mock.Anything, testdata.Bob.UserID).Return([]string{testdata.Charlie.UserID}, nil)
+	s.mockPublisher.On("PublishPresence", mock.Anything, expired[0], mock.Anything).Return(errors.New("nats down"))
+	s.mockPublisher.On("PublishPresence", mock.Anything, expired[1], mock.Anything).Return(nil)
+
+	count, err := s.tracker.Sweep(context.Background())
+
+	s.NoError(err)
+	s.Equal(2, count)
+}
+
+func TestPresenceTrackerSuite(t *testing.T) {
+	suite.Run(t, new(PresenceTrackerTestSuite))
+}
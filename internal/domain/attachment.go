@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxFileNameLength bounds the stored name of an attachment, in characters
+const MaxFileNameLength = 255
+
+// AttachmentPathPrefix is where attachments are downloaded from, followed by their ID
+const AttachmentPathPrefix = "/api/v1/attachments/"
+
+// MessageKind tells clients how to render a message
+type MessageKind string
+
+const (
+	MessageKindText  MessageKind = "text"
+	MessageKindImage MessageKind = "image"
+	MessageKindFile  MessageKind = "file"
+)
+
+// Attachment describes an uploaded file; its content lives in the blob store under its ID
+// An attachment is uploaded to a chat and can be sent once, by its uploader, in that chat
+type Attachment struct {
+	ID         string    `json:"id"`
+	UploaderID string    `json:"uploader_id"`
+	ChatID     string    `json:"chat_id"`
+	FileName   string    `json:"file_name"`
+	MimeType   string    `json:"mime_type"`
+	Size       int64     `json:"size"`
+	Checksum   string    `json:"checksum"` // Hex encoded SHA-256 of the content
+	Width      int       `json:"width,omitempty"`
+	Height     int       `json:"height,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// URL downloads the content; only participants of the chat can fetch it
+	URL string `json:"url"`
+}
+
+// NewAttachment creates the metadata of a file uploaded by uploaderID to chatID
+func NewAttachment(uploaderID, chatID, fileName string) Attachment {
+	id := uuid.Must(uuid.NewV7()).String()
+	return Attachment{
+		ID:         id,
+		UploaderID: uploaderID,
+		ChatID:     chatID,
+		FileName:   SanitizeFileName(fileName),
+		CreatedAt:  time.Now().UTC(),
+		URL:        AttachmentURL(id),
+	}
+}
+
+// AttachmentURL returns the download path of an attachment
+func AttachmentURL(attachmentID string) string {
+	return AttachmentPathPrefix + attachmentID
+}
+
+// ValidateAttachmentID checks that id can reference an attachment
+func ValidateAttachmentID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrInvalidAttachment
+	}
+	return nil
+}
+
+// SanitizeFileName keeps the base name of a client supplied file name, without control characters
+func SanitizeFileName(fileName string) string {
+	fileName = filepath.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	fileName = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, fileName)
+	fileName = strings.TrimSpace(fileName)
+
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return "attachment"
+	}
+	if utf8.RuneCountInString(fileName) > MaxFileNameLength {
+		fileName = string([]rune(fileName)[:MaxFileNameLength])
+	}
+	return fileName
+}
+
+// Kind returns how a message carrying the attachment is rendered
+func (a *Attachment) Kind() MessageKind {
+	if strings.HasPrefix(a.MimeType, "image/") {
+		return MessageKindImage
+	}
+	return MessageKindFile
+}
+
+// IsImage checks if the attachment is rendered inline as an image
+func (a *Attachment) IsImage() bool {
+	return a.Kind() == MessageKindImage
+}
+
+// Validate performs domain-level validation
+func (a *Attachment) Validate() error {
+	if err := ValidateAttachmentID(a.ID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(a.UploaderID) == "" || strings.TrimSpace(a.ChatID) == "" {
+		return ErrInvalidAttachment
+	}
+	if a.FileName == "" || utf8.RuneCountInString(a.FileName) > MaxFileNameLength {
+		return ErrInvalidAttachment
+	}
+	if a.MimeType == "" || a.Size <= 0 || a.Width < 0 || a.Height < 0 {
+		return ErrInvalidAttachment
+	}
+	if checksum, err := hex.DecodeString(a.Checksum); err != nil || len(checksum) != 32 {
+		return ErrInvalidAttachment
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+)
+
+// Block records that BlockerID no longer exchanges direct messages with BlockedID
+// Direct messages are refused in both directions; group chats are unaffected
+type Block struct {
+	BlockerID string    `json:"blocker_id"`
+	BlockedID string    `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate performs block validation
+func (b Block) Validate() error {
+	if err := ValidateUserID(b.BlockerID); err != nil {
+		return err
+	}
+	if err := ValidateUserID(b.BlockedID); err != nil {
+		return err
+	}
+	if b.BlockerID == b.BlockedID {
+		return ErrSelfBlock
+	}
+	return nil
+}
+
+// ChatMute silences a chat for a user: its messages are stored but neither
+// published to the user in real time nor counted as unread
+type ChatMute struct {
+	ChatID string `json:"chat_id"`
+	UserID string `json:"user_id"`
+	// MutedUntil ends the mute; nil mutes the chat until it is unmuted
+	MutedUntil *time.Time `json:"muted_until,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Validate performs mute validation; a timed mute must end after it was created
+func (m ChatMute) Validate() error {
+	if err := ValidateUserID(m.UserID); err != nil {
+		return err
+	}
+	if _, err := ParseChatID(m.ChatID); err != nil {
+		return err
+	}
+	if m.MutedUntil != nil && !m.MutedUntil.After(m.CreatedAt) {
+		return ErrInvalidMuteUntil
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// GroupChatIDPrefix marks chat IDs that belong to group conversations.
+	// 1:1 chats keep using the computed "a---b" form.
+	GroupChatIDPrefix = "group:"
+	// ChatIDSeparator joins the two participants of a 1:1 chat ID
+	ChatIDSeparator = "---"
+
+	MaxGroupNameLength = 100
+	MaxGroupMembers    = 256
+)
+
+const (
+	ChatTypeDirect = "direct"
+	ChatTypeGroup  = "group"
+)
+
+const (
+	ChatRoleOwner  = "owner"
+	ChatRoleMember = "member"
+)
+
+// Chat represents a group conversation with an explicit member list
+type Chat struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name" validate:"required,max=100"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	Members   []ChatMember `json:"members"`
+}
+
+type ChatMember struct {
+	ChatID   string    `json:"chat_id"`
+	UserID   string    `json:"user_id" validate:"required,max=100"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// NewGroupChat creates a group owned by creatorID with the given members.
+// Duplicate member IDs and the creator's own ID are ignored.
+func NewGroupChat(name, creatorID string, memberIDs []string) Chat {
+	now := time.Now().UTC()
+	chat := Chat{
+		ID:        NewGroupChatID(),
+		Name:      strings.TrimSpace(name),
+		CreatedBy: creatorID,
+		CreatedAt: now,
+	}
+
+	chat.Members = append(chat.Members, ChatMember{
+		ChatID:   chat.ID,
+		UserID:   creatorID,
+		Role:     ChatRoleOwner,
+		JoinedAt: now,
+	})
+
+	seen := map[string]bool{creatorID: true}
+	for _, memberID := range memberIDs {
+		memberID = strings.TrimSpace(memberID)
+		if seen[memberID] {
+			continue
+		}
+		seen[memberID] = true
+		chat.Members = append(chat.Members, ChatMember{
+			ChatID:   chat.ID,
+			UserID:   memberID,
+			Role:     ChatRoleMember,
+			JoinedAt: now,
+		})
+	}
+
+	return chat
+}
+
+// Validate performs domain-level validation
+func (c *Chat) Validate() error {
+	if !IsGroupChatID(c.ID) {
+		return ErrInvalidChatID
+	}
+	if strings.TrimSpace(c.Name) == "" || len(c.Name) > MaxGroupNameLength {
+		return ErrInvalidChatName
+	}
+	if len(c.Members) < 2 {
+		return ErrNotEnoughMembers
+	}
+	if len(c.Members) > MaxGroupMembers {
+		return ErrTooManyMembers
+	}
+
+	seen := make(map[string]bool, len(c.Members))
+	for _, member := range c.Members {
+		if err := ValidateUserID(member.UserID); err != nil {
+			return err
+		}
+		if seen[member.UserID] {
+			return ErrMemberExists
+		}
+		seen[member.UserID] = true
+	}
+	return nil
+}
+
+// HasMember checks if the user belongs to the chat
+func (c *Chat) HasMember(userID string) bool {
+	for _, member := range c.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOwner checks if the user owns the chat
+func (c *Chat) IsOwner(userID string) bool {
+	for _, member := range c.Members {
+		if member.UserID == userID {
+			return member.Role == ChatRoleOwner
+		}
+	}
+	return false
+}
+
+// MemberIDs returns the user IDs of every member
+func (c *Chat) MemberIDs() []string {
+	ids := make([]string, 0, len(c.Members))
+	for _, member := range c.Members {
+		ids = append(ids, member.UserID)
+	}
+	return ids
+}
+
+// NewGroupChatID generates a new unique group chat identifier
+func NewGroupChatID() string {
+	return GroupChatIDPrefix + uuid.NewString()
+}
+
+// IsGroupChatID checks if the chat ID refers to a group conversation
+func IsGroupChatID(chatID string) bool {
+	return strings.HasPrefix(chatID, GroupChatIDPrefix) && len(chatID) > len(GroupChatIDPrefix)
+}
+
+// ChatRef is a chat ID that passed ParseChatID
+type ChatRef struct {
+	ID string
+
+	// Participants are the two users of a 1:1 chat in ID order, empty for groups whose members are stored
+	Participants []string
+}
+
+// ParseChatID validates a chat ID: a group chat ID, or exactly the ID ComputeChatID gives
+// for two distinct valid user IDs, so that every 1:1 chat has a single unambiguous ID
+func ParseChatID(chatID string) (ChatRef, error) {
+	if IsGroupChatID(chatID) {
+		return ChatRef{ID: chatID}, nil
+	}
+
+	first, second, found := strings.Cut(chatID, ChatIDSeparator)
+	if !found || ValidateUserID(first) != nil || ValidateUserID(second) != nil ||
+		first == second || ComputeChatID(first, second) != chatID {
+		return ChatRef{}, ErrInvalidChatID
+	}
+	return ChatRef{ID: chatID, Participants: []string{first, second}}, nil
+}
+
+// IsGroup checks if the chat is a group conversation
+func (c ChatRef) IsGroup() bool {
+	return len(c.Participants) == 0
+}
+
+// HasParticipant checks if userID is one of the two users of a 1:1 chat
+// Group membership is not known from the ID and always reports false
+func (c ChatRef) HasParticipant(userID string) bool {
+	for _, participant := range c.Participants {
+		if participant == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateUserID checks that a user ID can take part in chats without making chat IDs ambiguous:
+// it cannot contain ChatIDSeparator, start or end with a dash, or look like a group chat ID.
+// User IDs also become NATS subject tokens, so subject separators, wildcards and whitespace are rejected
+func ValidateUserID(userID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return ErrMissingUserID
+	}
+	if strings.Contains(userID, ChatIDSeparator) ||
+		strings.HasPrefix(userID, "-") || strings.HasSuffix(userID, "-") ||
+		strings.HasPrefix(userID, GroupChatIDPrefix) ||
+		strings.ContainsAny(userID, ".*>") ||
+		strings.IndexFunc(userID, unicode.IsSpace) >= 0 {
+		return ErrInvalidUserID
+	}
+	return nil
+}
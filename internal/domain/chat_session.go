@@ -6,11 +6,29 @@ import (
 
 type ChatSession struct {
 	ChatID           string    `json:"chat_id"`
-	OtherParticipant string    `json:"other_participant"`
+	ChatType         string    `json:"chat_type"`
+	Name             string    `json:"name,omitempty"`
+	OtherParticipant string    `json:"other_participant,omitempty"`
+	Participants     []string  `json:"participants,omitempty"`
 	LastMessageAt    time.Time `json:"last_message_at"`
 	UnreadCount      int       `json:"unread_count"`
 	LastMessage      string    `json:"last_message"`
 	LastMessageBy    string    `json:"last_message_by"`
+
+	// Muted sessions always report zero unread messages
+	Muted      bool       `json:"muted"`
+	MutedUntil *time.Time `json:"muted_until,omitempty"`
+
+	// Presence of the other participant, only known for 1:1 chats
+	Online   bool       `json:"online"`
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
+
+// ChatCursor is the keyset position of a chat session in the chat list.
+// Sessions are ordered by last message time, ties broken by chat ID.
+type ChatCursor struct {
+	LastMessageAt time.Time
+	ChatID        string
 }
 
 // IsUnread checks if the session has unread messages
@@ -18,7 +36,39 @@ func (cs *ChatSession) IsUnread() bool {
 	return cs.UnreadCount > 0
 }
 
+// IsGroup checks if the session belongs to a group chat
+func (cs *ChatSession) IsGroup() bool {
+	return cs.ChatType == ChatTypeGroup
+}
+
+// Cursor returns the position right after this session in the chat list
+func (cs *ChatSession) Cursor() ChatCursor {
+	return ChatCursor{
+		LastMessageAt: cs.LastMessageAt,
+		ChatID:        cs.ChatID,
+	}
+}
+
+// IsZero reports whether the cursor points at the start of the chat list
+func (c ChatCursor) IsZero() bool {
+	return c.LastMessageAt.IsZero() && c.ChatID == ""
+}
+
+// Encode returns the opaque string form handed to clients
+func (c ChatCursor) Encode() string {
+	return encodeCursor(c.LastMessageAt, c.ChatID)
+}
+
+// ParseChatCursor decodes a cursor produced by Encode
+func ParseChatCursor(value string) (ChatCursor, error) {
+	lastMessageAt, chatID, err := decodeCursor(value)
+	if err != nil {
+		return ChatCursor{}, err
+	}
+	return ChatCursor{LastMessageAt: lastMessageAt, ChatID: chatID}, nil
+}
+
 // ComputeChatIDFromParticipants creates chat ID from two participants
 func ComputeChatIDFromParticipants(participant1, participant2 string) string {
 	return ComputeChatID(participant1, participant2)
-}
\ No newline at end of file
+}
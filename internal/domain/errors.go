@@ -11,12 +11,40 @@ var (
 	ErrContentTooLong    = errors.New("message content exceeds maximum length")
 	ErrInvalidStatus     = errors.New("invalid message status")
 	ErrMissingUserID     = errors.New("user ID is required")
+	ErrInvalidUserID     = errors.New("invalid user ID")
 	ErrMissingEmail      = errors.New("user email is required")
 	ErrMissingHandler    = errors.New("user handler is required")
 	ErrChatNotFound      = errors.New("chat not found")
 	ErrMessageNotFound   = errors.New("message not found")
+	ErrInvalidMessageID  = errors.New("invalid message ID")
+
+	ErrInvalidClientMessageID = errors.New("client message ID exceeds maximum length")
+	ErrInvalidCursor          = errors.New("invalid pagination cursor")
+	ErrInvalidDeleteScope     = errors.New("delete scope must be me or everyone")
+	ErrMessageDeleted         = errors.New("message has been deleted")
+	ErrInvalidEmoji           = errors.New("reaction must be a single emoji")
+	ErrInvalidAttachment      = errors.New("invalid attachment")
+	ErrAttachmentNotFound     = errors.New("attachment not found")
+	ErrAttachmentInUse        = errors.New("attachment was already sent")
+	ErrBlobNotFound           = errors.New("blob not found")
+	ErrInvalidSearchQuery     = errors.New("invalid search query")
+	ErrSelfBlock              = errors.New("cannot block self")
+	ErrUserBlocked            = errors.New("messages between these users are blocked")
+	ErrInvalidMuteUntil       = errors.New("mute end must be in the future")
+	ErrHealthDegraded         = errors.New("component is degraded")
 	ErrUnauthorized      = errors.New("unauthorized access")
 	ErrDuplicateMessage  = errors.New("duplicate message")
+	ErrInvalidChatID     = errors.New("invalid chat ID")
+	ErrInvalidChatName   = errors.New("invalid group name")
+	ErrNotEnoughMembers  = errors.New("group must have at least two members")
+	ErrTooManyMembers    = errors.New("group exceeds maximum number of members")
+	ErrMemberExists      = errors.New("user is already a member of this chat")
+	ErrNotChatMember     = errors.New("user is not a member of this chat")
+
+	ErrMissingToken       = errors.New("missing bearer token")
+	ErrMalformedToken     = errors.New("malformed token")
+	ErrTokenExpired       = errors.New("token has expired")
+	ErrInvalidToken       = errors.New("invalid token")
 )
 
 // IsValidationError checks if error is domain validation related
@@ -24,7 +52,12 @@ func IsValidationError(err error) bool {
 	validationErrors := []error{
 		ErrInvalidSenderID, ErrInvalidReceiverID, ErrSelfMessage,
 		ErrEmptyContent, ErrContentTooLong, ErrInvalidStatus,
-		ErrMissingUserID, ErrMissingEmail, ErrMissingHandler,
+		ErrMissingUserID, ErrInvalidUserID, ErrMissingEmail, ErrMissingHandler,
+		ErrInvalidChatID, ErrInvalidChatName, ErrNotEnoughMembers,
+		ErrTooManyMembers, ErrInvalidMessageID, ErrInvalidClientMessageID,
+		ErrInvalidCursor, ErrInvalidDeleteScope, ErrInvalidEmoji,
+		ErrInvalidAttachment, ErrInvalidSearchQuery, ErrSelfBlock,
+		ErrInvalidMuteUntil,
 	}
 
 	for _, ve := range validationErrors {
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type HealthStatus string
+
+const (
+	HealthStatusUp HealthStatus = "up"
+	// HealthStatusDegraded reports a problem that does not stop the instance from serving requests
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusDown     HealthStatus = "down"
+)
+
+// ComponentHealth is the result of checking one dependency
+type ComponentHealth struct {
+	Status    HealthStatus `json:"status"`
+	LatencyMs float64      `json:"latency_ms"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// HealthReport is the readiness of an instance and of each of its dependencies
+type HealthReport struct {
+	Status HealthStatus `json:"status"`
+	// Draining is set once the instance is shutting down, when it is no longer ready whatever its dependencies
+	Draining   bool                       `json:"draining,omitempty"`
+	Components map[string]ComponentHealth `json:"components,omitempty"`
+}
+
+// NewComponentHealth classifies the result of a check that took latency;
+// errors wrapping ErrHealthDegraded leave the component degraded rather than down
+func NewComponentHealth(err error, latency time.Duration) ComponentHealth {
+	health := ComponentHealth{
+		Status:    HealthStatusUp,
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+	}
+	if err != nil {
+		health.Status = HealthStatusDown
+		if errors.Is(err, ErrHealthDegraded) {
+			health.Status = HealthStatusDegraded
+		}
+		health.Error = err.Error()
+	}
+	return health
+}
+
+// NewHealthReport summarizes component results; the instance is down if any component is down
+func NewHealthReport(components map[string]ComponentHealth) HealthReport {
+	report := HealthReport{Status: HealthStatusUp, Components: components}
+	for _, component := range components {
+		switch component.Status {
+		case HealthStatusDown:
+			report.Status = HealthStatusDown
+		case HealthStatusDegraded:
+			if report.Status == HealthStatusUp {
+				report.Status = HealthStatusDegraded
+			}
+		}
+	}
+	return report
+}
+
+// Ready reports whether the instance should receive traffic
+func (r HealthReport) Ready() bool {
+	return r.Status != HealthStatusDown
+}
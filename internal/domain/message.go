@@ -1,46 +1,173 @@
 package domain
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Message struct {
+	ID         string    `json:"id"`
 	SenderID   string    `json:"sender_id" validate:"required,max=100"`
 	ReceiverID string    `json:"receiver_id" validate:"required,max=100"`
 	CreatedAt  time.Time `json:"created_at" validate:"required"`
 	Content    string    `json:"content" validate:"required,max=10000"`
 	Status     string    `json:"status" validate:"required,oneof=sent delivered read"`
+
+	// ClientMessageID is the sender's idempotency key, unique per sender
+	ClientMessageID string `json:"client_message_id,omitempty" validate:"max=100"`
+
+	// EditedAt is set once the sender changed the content
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	// DeletedAt is set once the message was deleted; deleted messages are tombstones without content
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Reactions summarises the emoji reactions, filled in when listing messages
+	Reactions []ReactionSummary `json:"reactions,omitempty"`
+
+	// ReplyTo quotes the message this one answers, always from the same chat
+	ReplyTo *Quote `json:"reply_to,omitempty"`
+
+	// Kind is text unless the message carries an attachment; Content is then an optional caption
+	Kind       MessageKind `json:"kind"`
+	Attachment *Attachment `json:"attachment,omitempty"`
 }
 
-// MessageID represents the composite primary key
+// QuoteSnippetLength bounds the quoted content of a reply, in characters
+const QuoteSnippetLength = 140
+
+// Quote is the excerpt of a replied-to message, enough to render it without fetching it
+type Quote struct {
+	ID        string    `json:"id"`
+	SenderID  string    `json:"sender_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Snippet   string    `json:"snippet"`
+	Deleted   bool      `json:"deleted,omitempty"`
+}
+
+// NewQuote builds the quote of message shown in replies to it
+func NewQuote(message Message) *Quote {
+	snippet := []rune(message.Content)
+	if len(snippet) > QuoteSnippetLength {
+		snippet = snippet[:QuoteSnippetLength]
+	}
+	return &Quote{
+		ID:        message.ID,
+		SenderID:  message.SenderID,
+		CreatedAt: message.CreatedAt,
+		Snippet:   string(snippet),
+		Deleted:   message.IsDeleted(),
+	}
+}
+
+// Scopes of a message deletion
+const (
+	DeleteForMe       = "me"
+	DeleteForEveryone = "everyone"
+)
+
+// IsValidDeleteScope checks that scope is DeleteForMe or DeleteForEveryone
+func IsValidDeleteScope(scope string) bool {
+	return scope == DeleteForMe || scope == DeleteForEveryone
+}
+
+// MessageID references a message either by its server-assigned ID
+// or by the legacy (sender, receiver, created_at) composite
 type MessageID struct {
-	SenderID   string    `json:"sender_id"`
-	ReceiverID string    `json:"receiver_id"`
+	ID         string    `json:"id,omitempty"`
+	SenderID   string    `json:"sender_id,omitempty"`
+	ReceiverID string    `json:"receiver_id,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// NewMessageID generates a time-ordered UUIDv7 message ID
+func NewMessageID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// UnmarshalJSON accepts either the bare ID string or the object form
+func (id *MessageID) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*id = MessageID{ID: value}
+		return nil
+	}
+
+	type messageID MessageID
+	var object messageID
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*id = MessageID(object)
+	return nil
+}
+
+// IsComposite reports whether the sender, receiver and timestamp are all known
+func (id MessageID) IsComposite() bool {
+	return id.SenderID != "" && id.ReceiverID != "" && !id.CreatedAt.IsZero()
+}
+
+// Validate checks that the reference identifies a message
+func (id MessageID) Validate() error {
+	if id.ID != "" {
+		if _, err := uuid.Parse(id.ID); err != nil {
+			return ErrInvalidMessageID
+		}
+		return nil
+	}
+	if !id.IsComposite() {
+		return ErrInvalidMessageID
+	}
+	return nil
+}
+
+// String returns a stable textual key for the message, e.g. for deduplication
+func (id MessageID) String() string {
+	if id.ID != "" {
+		return id.ID
+	}
+	return fmt.Sprintf("%s|%s|%d", id.SenderID, id.ReceiverID, id.CreatedAt.UnixNano())
+}
+
+// MessageRange describes the messages affected by a status change
+type MessageRange struct {
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Count int64     `json:"count"`
+
+	// Senders of the affected group messages, who get the receipt; not part of it
+	Senders []string `json:"-"`
+}
+
 // Validate performs domain-level validation
 func (m *Message) Validate() error {
-	if strings.TrimSpace(m.SenderID) == "" {
+	if ValidateUserID(m.SenderID) != nil {
 		return ErrInvalidSenderID
 	}
-	if strings.TrimSpace(m.ReceiverID) == "" {
+	if !IsGroupChatID(m.ReceiverID) && ValidateUserID(m.ReceiverID) != nil {
 		return ErrInvalidReceiverID
 	}
 	if m.SenderID == m.ReceiverID {
 		return ErrSelfMessage
 	}
-	if strings.TrimSpace(m.Content) == "" {
+	if strings.TrimSpace(m.Content) == "" && m.Attachment == nil {
 		return ErrEmptyContent
 	}
 	if len(m.Content) > 10000 {
 		return ErrContentTooLong
 	}
+	if m.Attachment != nil && m.Kind != m.Attachment.Kind() {
+		return ErrInvalidAttachment
+	}
 	if !IsValidStatus(m.Status) {
 		return ErrInvalidStatus
 	}
+	if len(m.ClientMessageID) > 100 {
+		return ErrInvalidClientMessageID
+	}
 	return nil
 }
 
@@ -60,12 +187,112 @@ func IsValidStatus(status string) bool {
 	return false
 }
 
-// ComputeChatID creates a consistent chat identifier using a separator that won't conflict with user IDs
+// ComputeChatID creates a consistent chat identifier using a separator that valid user IDs cannot contain
 func ComputeChatID(user1, user2 string) string {
 	if user1 < user2 {
-		return fmt.Sprintf("%s---%s", user1, user2)
+		return user1 + ChatIDSeparator + user2
+	}
+	return user2 + ChatIDSeparator + user1
+}
+
+// DirectChatParticipants splits a 1:1 chat ID into its two participants
+// Returns ErrInvalidChatID for group chat IDs and anything ParseChatID rejects
+func DirectChatParticipants(chatID string) (string, string, error) {
+	chat, err := ParseChatID(chatID)
+	if err != nil || chat.IsGroup() {
+		return "", "", ErrInvalidChatID
+	}
+	return chat.Participants[0], chat.Participants[1], nil
+}
+
+// Key returns the reference identifying the message, including its composite key
+func (m *Message) Key() MessageID {
+	return MessageID{
+		ID:         m.ID,
+		SenderID:   m.SenderID,
+		ReceiverID: m.ReceiverID,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// IsDeleted checks if the message is a tombstone
+func (m *Message) IsDeleted() bool {
+	return m.DeletedAt != nil
+}
+
+// IsWithinWindow checks if the message is younger than window at now
+// A zero window never expires
+func (m *Message) IsWithinWindow(window time.Duration, now time.Time) bool {
+	return window <= 0 || now.Sub(m.CreatedAt) <= window
+}
+
+// Edit returns the message with its content replaced
+func (m *Message) Edit(content string, editedAt time.Time) (Message, error) {
+	if m.IsDeleted() {
+		return *m, ErrMessageDeleted
+	}
+	if strings.TrimSpace(content) == "" && m.Attachment == nil {
+		return *m, ErrEmptyContent
+	}
+	if len(content) > 10000 {
+		return *m, ErrContentTooLong
+	}
+
+	edited := *m
+	edited.Content = content
+	edited.EditedAt = &editedAt
+	return edited, nil
+}
+
+// Tombstone returns the message as shown once deleted, without its content
+func (m *Message) Tombstone(deletedAt time.Time) Message {
+	deleted := *m
+	deleted.Content = ""
+	deleted.Attachment = nil
+	deleted.DeletedAt = &deletedAt
+	return deleted
+}
+
+// IsParticipant checks if userID sent or received a 1:1 message
+// Group membership is not known to the message and must be checked against the chat
+func (m *Message) IsParticipant(userID string) bool {
+	return m.SenderID == userID || m.ReceiverID == userID
+}
+
+// ReplyToID returns the ID of the quoted message, or an empty string for messages that are not replies
+func (m *Message) ReplyToID() string {
+	if m.ReplyTo == nil {
+		return ""
+	}
+	return m.ReplyTo.ID
+}
+
+// Attach sends the attachment with the message, which takes the attachment's kind
+func (m *Message) Attach(attachment Attachment) {
+	m.Attachment = &attachment
+	m.Kind = attachment.Kind()
+}
+
+// AttachmentID returns the ID of the attached file, or an empty string for text messages
+func (m *Message) AttachmentID() string {
+	if m.Attachment == nil {
+		return ""
+	}
+	return m.Attachment.ID
+}
+
+// IsGroupMessage checks if the message was sent to a group chat
+func (m *Message) IsGroupMessage() bool {
+	return IsGroupChatID(m.ReceiverID)
+}
+
+// GetChatID returns the chat the message belongs to.
+// Group messages use the group ID as receiver, 1:1 messages compute it from both participants.
+func (m *Message) GetChatID() string {
+	if m.IsGroupMessage() {
+		return m.ReceiverID
 	}
-	return fmt.Sprintf("%s---%s", user2, user1)
+	return ComputeChatID(m.SenderID, m.ReceiverID)
 }
 
 // GetOtherParticipant returns the other participant in a 1:1 chat
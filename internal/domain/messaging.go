@@ -14,8 +14,12 @@ const (
 type MessageType string
 
 const (
-	MessageTypeNewMessage   MessageType = "new_message"
-	MessageTypeStatusUpdate MessageType = "status_update"
+	MessageTypeNewMessage      MessageType = "new_message"
+	MessageTypeStatusUpdate    MessageType = "status_update"
+	MessageTypeMessageEdited   MessageType = "message_edited"
+	MessageTypeMessageDeleted  MessageType = "message_deleted"
+	MessageTypeReactionUpdated MessageType = "reaction_updated"
+	MessageTypePresenceUpdate  MessageType = "presence_update"
 )
 
 type StatusType string
@@ -33,6 +37,12 @@ type MessageEnvelope struct {
 	Data      Message     `json:"data"`
 }
 
+type ReactionUpdateEnvelope struct {
+	Type      MessageType    `json:"type"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      ReactionUpdate `json:"data"`
+}
+
 type StatusUpdateEnvelope struct {
 	Type      MessageType `json:"type"`
 	Timestamp time.Time   `json:"timestamp"`
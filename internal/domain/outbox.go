@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboxEvent is a real-time event stored alongside the change that produced it
+// and relayed to the publisher once the transaction has committed
+type OutboxEvent struct {
+	ID            int64           `json:"id"`
+	EventType     MessageType     `json:"event_type"`
+	Recipient     string          `json:"recipient"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+
+	// TraceContext holds the trace propagation headers of the request that produced the event
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+
+	// DeliveredTo lists the group members earlier attempts already published the event to
+	DeliveredTo []string `json:"delivered_to,omitempty"`
+}
+
+// OutboxEventsFunc builds the events for a status change once the affected messages are known
+// Repositories call it inside their transaction and only when at least one message changed
+type OutboxEventsFunc func(affected MessageRange) ([]OutboxEvent, error)
+
+// NewOutboxEvent encodes data as the payload of an event addressed to recipient
+// The recipient is a user ID, or a group chat ID for messages fanned out to members
+func NewOutboxEvent(eventType MessageType, recipient string, data interface{}) (OutboxEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return OutboxEvent{}, fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+
+	now := time.Now().UTC()
+	return OutboxEvent{
+		EventType:     eventType,
+		Recipient:     recipient,
+		Payload:       payload,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// NewMessageOutboxEvent creates the event announcing a new message to its receiver
+func NewMessageOutboxEvent(message Message) (OutboxEvent, error) {
+	return NewOutboxEvent(MessageTypeNewMessage, message.ReceiverID, message)
+}
+
+// NewMessageUpdateOutboxEvents creates the events announcing an edited or deleted message
+func NewMessageUpdateOutboxEvents(eventType MessageType, message Message) ([]OutboxEvent, error) {
+	return newMessageUpdateOutboxEvents(eventType, message, message)
+}
+
+// NewReactionOutboxEvents creates the events announcing a reaction change on message
+func NewReactionOutboxEvents(message Message, update ReactionUpdate) ([]OutboxEvent, error) {
+	return newMessageUpdateOutboxEvents(MessageTypeReactionUpdated, message, update)
+}
+
+// newMessageUpdateOutboxEvents addresses data to everyone following message
+// Group messages reach every member; 1:1 messages reach both participants so the sender's other devices follow
+func newMessageUpdateOutboxEvents(eventType MessageType, message Message, data interface{}) ([]OutboxEvent, error) {
+	recipients := []string{message.ReceiverID}
+	if !message.IsGroupMessage() {
+		recipients = append(recipients, message.SenderID)
+	}
+
+	events := make([]OutboxEvent, 0, len(recipients))
+	for _, recipient := range recipients {
+		event, err := NewOutboxEvent(eventType, recipient, data)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
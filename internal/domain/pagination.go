@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Directions of a message history page relative to its cursor
+const (
+	PageBefore = "before"
+	PageAfter  = "after"
+	PageAround = "around"
+)
+
+// MessageCursor is the keyset position of a message in a chat's history.
+// Messages are ordered by creation time, ties broken by message ID.
+// A cursor without an ID stands for every message created at that instant.
+type MessageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// MessagePage selects a window of a chat's history
+type MessagePage struct {
+	// Direction is PageBefore (older than Cursor, the latest messages when Cursor is zero),
+	// PageAfter (newer than Cursor) or PageAround (centred on the message Cursor.ID, included)
+	Direction string
+	Cursor    MessageCursor
+	Limit     int
+
+	// UserID is the reader; messages they deleted for themselves are left out
+	UserID string
+}
+
+// IsZero reports whether the cursor points at the end of the history
+func (c MessageCursor) IsZero() bool {
+	return c.CreatedAt.IsZero() && c.ID == ""
+}
+
+// Encode returns the opaque string form handed to clients
+func (c MessageCursor) Encode() string {
+	return encodeCursor(c.CreatedAt, c.ID)
+}
+
+// ParseMessageCursor decodes a cursor produced by Encode
+func ParseMessageCursor(value string) (MessageCursor, error) {
+	createdAt, id, err := decodeCursor(value)
+	if err != nil {
+		return MessageCursor{}, err
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+	return MessageCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// Cursor returns the keyset position of the message
+func (m *Message) Cursor() MessageCursor {
+	return MessageCursor{
+		CreatedAt: m.CreatedAt,
+		ID:        m.ID,
+	}
+}
+
+// encodeCursor packs a timestamp at full precision and a tie-breaking key into an opaque string
+func encodeCursor(timestamp time.Time, key string) string {
+	raw := timestamp.UTC().Format(time.RFC3339Nano) + "|" + key
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor reverses encodeCursor
+func decodeCursor(value string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	encoded, key, found := strings.Cut(string(raw), "|")
+	if !found || key == "" {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, encoded)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	return timestamp.UTC(), key, nil
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"time"
+)
+
+// Presence is the last known activity of a user as announced to their contacts
+type Presence struct {
+	UserID   string     `json:"user_id"`
+	Status   StatusType `json:"status"`
+	ChatID   string     `json:"chat_id,omitempty"` // Set while typing
+	LastSeen time.Time  `json:"last_seen"`
+}
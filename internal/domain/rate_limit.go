@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit allows Requests per Window on average, in bursts of up to Burst requests
+// A zero limit is unset
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+	Burst    int // Defaults to Requests
+}
+
+// IsZero reports whether the limit is unset
+func (l RateLimit) IsZero() bool {
+	return l.Requests <= 0 || l.Window <= 0
+}
+
+// Capacity is the size of the token bucket enforcing the limit
+func (l RateLimit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// refillInterval is how long the bucket takes to regain one token
+func (l RateLimit) refillInterval() time.Duration {
+	return l.Window / time.Duration(l.Requests)
+}
+
+// RateLimitDecision is the outcome of taking a token, reported to clients in rate limit headers
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // Until the next token is available; zero when allowed
+	ResetAfter time.Duration // Until the bucket is full again
+}
+
+// TokenBucket is the state of one client's bucket; the zero bucket is full
+type TokenBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Take refills the bucket for the time elapsed since its last update and takes one token if there is one
+func (b TokenBucket) Take(limit RateLimit, now time.Time) (TokenBucket, RateLimitDecision) {
+	capacity := float64(limit.Capacity())
+	interval := limit.refillInterval()
+
+	tokens := capacity
+	if !b.UpdatedAt.IsZero() {
+		tokens = b.Tokens
+		if elapsed := now.Sub(b.UpdatedAt); elapsed > 0 {
+			tokens = math.Min(capacity, tokens+float64(elapsed)/float64(interval))
+		}
+	}
+
+	decision := RateLimitDecision{Limit: limit.Capacity()}
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = time.Duration((1 - tokens) * float64(interval))
+	}
+
+	decision.Remaining = int(math.Floor(tokens))
+	decision.ResetAfter = time.Duration((capacity - tokens) * float64(interval))
+
+	return TokenBucket{Tokens: tokens, UpdatedAt: now}, decision
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxEmojiLength bounds a reaction in runes; emoji built from joined sequences span several runes
+const MaxEmojiLength = 16
+
+// Reaction is the emoji a user reacted to a message with; each user holds at most one per message
+type Reaction struct {
+	MessageID string    `json:"message_id"`
+	UserID    string    `json:"user_id"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReactionSummary groups the users who reacted to a message with the same emoji
+type ReactionSummary struct {
+	Emoji   string   `json:"emoji"`
+	Count   int      `json:"count"`
+	UserIDs []string `json:"user_ids"`
+}
+
+// Reaction update actions
+const (
+	ReactionAdded   = "added"
+	ReactionRemoved = "removed"
+)
+
+// ReactionUpdate announces that a user's reaction to a message changed
+// Emoji is the user's current reaction and is empty once removed
+type ReactionUpdate struct {
+	MessageID string    `json:"message_id"`
+	ChatID    string    `json:"chat_id"`
+	UserID    string    `json:"user_id"`
+	Emoji     string    `json:"emoji,omitempty"`
+	Action    string    `json:"action"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate performs domain-level validation
+func (r *Reaction) Validate() error {
+	if strings.TrimSpace(r.UserID) == "" {
+		return ErrMissingUserID
+	}
+	return ValidateEmoji(r.Emoji)
+}
+
+// ValidateEmoji rejects empty, overlong and plain-text reactions
+func ValidateEmoji(emoji string) error {
+	length := utf8.RuneCountInString(emoji)
+	if length == 0 || length > MaxEmojiLength || !utf8.ValidString(emoji) {
+		return ErrInvalidEmoji
+	}
+	for _, r := range emoji {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || unicode.IsLetter(r) {
+			return ErrInvalidEmoji
+		}
+	}
+	return nil
+}
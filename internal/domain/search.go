@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxSearchQueryLength bounds the search text, in characters
+const MaxSearchQueryLength = 200
+
+// Markers the search store wraps matches in before the snippet is escaped.
+// They are private use characters, so they cannot be confused with markup.
+const (
+	SearchMatchStart = "\ue000"
+	SearchMatchStop  = "\ue001"
+)
+
+// MessageSearch selects the messages of a user's chats matching a text
+type MessageSearch struct {
+	// UserID is the searcher; only chats they take part in are searched
+	// and messages they deleted for themselves are left out
+	UserID string
+	Text   string
+
+	// Optional filters; From is inclusive and To exclusive
+	ChatID   string
+	SenderID string
+	From     time.Time
+	To       time.Time
+
+	Cursor SearchCursor
+	Limit  int
+}
+
+// SearchHit is a message matching a search, with the excerpt showing the match
+type SearchHit struct {
+	Message Message `json:"message"`
+	ChatID  string  `json:"chat_id"`
+	Rank    float64 `json:"rank"`
+
+	// Snippet is HTML-escaped content with every match wrapped in <mark></mark>
+	Snippet string `json:"snippet"`
+}
+
+// SearchCursor is the keyset position of a hit in search results.
+// Hits are ordered by rank, then by creation time, ties broken by message ID.
+type SearchCursor struct {
+	Rank      float64
+	CreatedAt time.Time
+	ID        string
+}
+
+// Validate checks the search text and the date range
+func (s *MessageSearch) Validate() error {
+	text := strings.TrimSpace(s.Text)
+	if text == "" || utf8.RuneCountInString(text) > MaxSearchQueryLength {
+		return ErrInvalidSearchQuery
+	}
+	if !s.From.IsZero() && !s.To.IsZero() && !s.From.Before(s.To) {
+		return ErrInvalidSearchQuery
+	}
+	return nil
+}
+
+// Cursor returns the position right after this hit in the results
+func (h *SearchHit) Cursor() SearchCursor {
+	return SearchCursor{
+		Rank:      h.Rank,
+		CreatedAt: h.Message.CreatedAt,
+		ID:        h.Message.ID,
+	}
+}
+
+// IsZero reports whether the cursor points at the first result
+func (c SearchCursor) IsZero() bool {
+	return c.CreatedAt.IsZero() && c.ID == ""
+}
+
+// Encode returns the opaque string form handed to clients
+func (c SearchCursor) Encode() string {
+	return encodeCursor(c.CreatedAt, c.ID+"|"+strconv.FormatFloat(c.Rank, 'g', -1, 64))
+}
+
+// ParseSearchCursor decodes a cursor produced by Encode
+func ParseSearchCursor(value string) (SearchCursor, error) {
+	createdAt, key, err := decodeCursor(value)
+	if err != nil {
+		return SearchCursor{}, err
+	}
+
+	id, rankStr, found := strings.Cut(key, "|")
+	if !found {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+	rank, err := strconv.ParseFloat(rankStr, 64)
+	if err != nil {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+
+	return SearchCursor{Rank: rank, CreatedAt: createdAt, ID: id}, nil
+}
+
+// HighlightSnippet escapes an excerpt whose matches are wrapped in SearchMatchStart and SearchMatchStop
+// and turns the markers into <mark> elements, so clients can render it as HTML
+func HighlightSnippet(excerpt string) string {
+	return strings.NewReplacer(
+		SearchMatchStart, "<mark>",
+		SearchMatchStop, "</mark>",
+	).Replace(html.EscapeString(excerpt))
+}
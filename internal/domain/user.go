@@ -13,8 +13,8 @@ type UserContext struct {
 
 // Validate performs user context validation
 func (uc *UserContext) Validate() error {
-	if strings.TrimSpace(uc.UserID) == "" {
-		return ErrMissingUserID
+	if err := ValidateUserID(uc.UserID); err != nil {
+		return err
 	}
 	if strings.TrimSpace(uc.Email) == "" {
 		return ErrMissingEmail
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+)
+
+// UserDataExport holds everything stored about a user
+type UserDataExport struct {
+	UserID     string    `json:"user_id"`
+	ExportedAt time.Time `json:"exported_at"`
+	// Messages are the ones the user sent and the direct messages they received, oldest first
+	Messages    []Message    `json:"messages"`
+	Memberships []ChatMember `json:"memberships"`
+	Reactions   []Reaction   `json:"reactions"`
+	Blocks      []Block      `json:"blocks"`
+	Mutes       []ChatMute   `json:"mutes"`
+	// Attachments are the files the user uploaded; their content stays in the blob store
+	Attachments []Attachment `json:"attachments"`
+}
+
+// UserDataPurge counts what was removed when a user's data was purged
+type UserDataPurge struct {
+	Messages    int64 `json:"messages"`
+	Reactions   int64 `json:"reactions"`
+	Memberships int64 `json:"memberships"`
+	Blocks      int64 `json:"blocks"`
+	Mutes       int64 `json:"mutes"`
+	// AttachmentIDs are the removed attachments, whose blobs the caller deletes
+	AttachmentIDs []string `json:"attachment_ids"`
+}
@@ -0,0 +1,379 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"image"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	// Decoders used to read the dimensions of uploaded images
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+const (
+	// AttachmentFormField is the multipart field carrying the uploaded file
+	AttachmentFormField = "file"
+
+	// sniffLength is how much of a file is inspected to detect its type
+	sniffLength = 512
+	// multipartOverhead leaves room for the multipart framing around the file
+	multipartOverhead = 64 << 10
+)
+
+// AttachmentConfig limits what can be uploaded
+type AttachmentConfig struct {
+	MaxSize         int64            // Bytes
+	AllowedTypes    []string         // MIME types detected from the content; "image/*" allows every image type
+	UploadRateLimit domain.RateLimit // Per uploader; zero uses the server default
+}
+
+func DefaultAttachmentConfig() AttachmentConfig {
+	return AttachmentConfig{
+		MaxSize:         10 << 20,
+		AllowedTypes:    []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf", "text/plain"},
+		UploadRateLimit: domain.RateLimit{Requests: 20, Window: time.Minute, Burst: 5},
+	}
+}
+
+// AttachmentHandler handles file uploads and downloads
+type AttachmentHandler struct {
+	Config      AttachmentConfig
+	MessageRepo ports.MessageRepository
+	ChatRepo    ports.ChatRepository
+	Blobs       ports.BlobStore
+	Authorizer  *Authorizer
+	Logger      ports.Logger
+}
+
+func NewAttachmentHandler(config AttachmentConfig, messageRepo ports.MessageRepository, chatRepo ports.ChatRepository, blobs ports.BlobStore, logger ports.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		Config:      config,
+		MessageRepo: messageRepo,
+		ChatRepo:    chatRepo,
+		Blobs:       blobs,
+		Authorizer:  NewAuthorizer(chatRepo),
+		Logger:      logger,
+	}
+}
+
+// UploadAttachment handles POST /api/v1/chats/{receiverId}/attachments
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	// Extract receiverId from path: /api/v1/chats/{receiverId}/attachments
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing receiver ID", "MISSING_RECEIVER_ID", "receiverId path parameter is required")
+		return
+	}
+	receiverID := pathParts[3]
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	chatID, herr := h.uploadChatID(r.Context(), user, receiverID)
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.Config.MaxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid multipart body", "INVALID_MULTIPART", err.Error())
+		return
+	}
+
+	part, herr := filePart(reader)
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+	defer part.Close()
+
+	attachment := domain.NewAttachment(user.UserID, chatID, part.FileName())
+	if herr := h.storeContent(r.Context(), &attachment, part); herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	if err := h.MessageRepo.SaveAttachment(r.Context(), attachment); err != nil {
+		h.deleteBlob(r.Context(), attachment.ID)
+		if err == domain.ErrUserBlocked {
+			h.writeErrorResponse(w, http.StatusForbidden, "Message blocked", "USER_BLOCKED", err.Error())
+			return
+		}
+		h.Logger.Error("Failed to save attachment", "error", err, "attachment_id", attachment.ID, "user", user.UserID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to upload attachment", "UPLOAD_ERROR", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(AttachmentResponse(attachment))
+
+	h.Logger.Debug("Attachment uploaded successfully", "attachment_id", attachment.ID, "user", user.UserID, "size", attachment.Size)
+}
+
+// DownloadAttachment handles GET /api/v1/attachments/{attachmentId}
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	// Extract attachmentId from path: /api/v1/attachments/{attachmentId}
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing attachment ID", "MISSING_ATTACHMENT_ID", "attachmentId path parameter is required")
+		return
+	}
+	attachmentID := pathParts[3]
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	attachment, herr := h.getAttachment(r.Context(), user, attachmentID)
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	// Content never changes for a given ID, so the checksum is a strong validator
+	etag := `"` + attachment.Checksum + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	blob, err := h.Blobs.Get(r.Context(), attachment.ID)
+	if err == domain.ErrBlobNotFound {
+		h.Logger.Error("Attachment content missing", "attachment_id", attachment.ID)
+		h.writeErrorResponse(w, http.StatusNotFound, "Attachment not found", "ATTACHMENT_NOT_FOUND", "")
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Failed to open attachment", "error", err, "attachment_id", attachment.ID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to download attachment", "DOWNLOAD_ERROR", "")
+		return
+	}
+	defer blob.Close()
+
+	// Only images are rendered inline; everything else is downloaded and never sniffed by the browser
+	disposition := "attachment"
+	if attachment.IsImage() {
+		disposition = "inline"
+	}
+
+	w.Header().Set("Content-Type", attachment.MimeType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, blob); err != nil {
+		h.Logger.Warn("Failed to stream attachment", "error", err, "attachment_id", attachment.ID, "user", user.UserID)
+	}
+}
+
+// uploadChatID resolves the chat a file is uploaded to, which the user must take part in
+func (h *AttachmentHandler) uploadChatID(ctx context.Context, user domain.UserContext, receiverID string) (string, *handlerError) {
+	if !domain.IsGroupChatID(receiverID) {
+		if receiverID == user.UserID {
+			return "", &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", domain.ErrSelfMessage.Error()}
+		}
+		if err := domain.ValidateUserID(receiverID); err != nil {
+			return "", &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", domain.ErrInvalidReceiverID.Error()}
+		}
+		return domain.ComputeChatID(user.UserID, receiverID), nil
+	}
+
+	isMember, err := h.Authorizer.CanAccessChat(ctx, user.UserID, receiverID)
+	if err != nil {
+		h.Logger.Error("Failed to check group membership", "error", err, "chat_id", receiverID, "user", user.UserID)
+		return "", &handlerError{http.StatusInternalServerError, "Failed to upload attachment", "UPLOAD_ERROR", ""}
+	}
+	if !isMember {
+		return "", &handlerError{http.StatusForbidden, "Access denied", "ACCESS_DENIED", "User is not a member of this group"}
+	}
+	return receiverID, nil
+}
+
+// storeContent streams the file to the blob store, filling in its type, size, checksum and dimensions
+func (h *AttachmentHandler) storeContent(ctx context.Context, attachment *domain.Attachment, part io.Reader) *handlerError {
+	content := bufio.NewReaderSize(part, sniffLength)
+	head, err := content.Peek(sniffLength)
+	if err != nil && err != io.EOF {
+		return h.readError(err, attachment)
+	}
+	if len(head) == 0 {
+		return &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", "The file is empty"}
+	}
+
+	// The type is detected from the content; the one claimed by the client is ignored
+	attachment.MimeType = detectMimeType(head)
+	if !h.isAllowedType(attachment.MimeType) {
+		return &handlerError{http.StatusUnsupportedMediaType, "Unsupported file type", "UNSUPPORTED_MEDIA_TYPE", attachment.MimeType + " files are not accepted"}
+	}
+
+	// One byte past the limit is read so oversized files are told apart from files of exactly MaxSize
+	hash := sha256.New()
+	var size byteCounter
+	limited := io.TeeReader(io.LimitReader(content, h.Config.MaxSize+1), io.MultiWriter(hash, &size))
+	if err := h.Blobs.Put(ctx, attachment.ID, limited); err != nil {
+		return h.readError(err, attachment)
+	}
+	if int64(size) > h.Config.MaxSize {
+		h.deleteBlob(ctx, attachment.ID)
+		return h.tooLarge()
+	}
+
+	attachment.Size = int64(size)
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+	if attachment.IsImage() {
+		attachment.Width, attachment.Height = h.imageSize(ctx, attachment.ID)
+	}
+	return nil
+}
+
+// readError maps a failure while receiving or storing the upload
+func (h *AttachmentHandler) readError(err error, attachment *domain.Attachment) *handlerError {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return h.tooLarge()
+	}
+	h.Logger.Error("Failed to store attachment", "error", err, "attachment_id", attachment.ID, "user", attachment.UploaderID)
+	return &handlerError{http.StatusInternalServerError, "Failed to upload attachment", "UPLOAD_ERROR", ""}
+}
+
+func (h *AttachmentHandler) tooLarge() *handlerError {
+	return &handlerError{http.StatusRequestEntityTooLarge, "Attachment too large", "ATTACHMENT_TOO_LARGE", "Files are limited to " + strconv.FormatInt(h.Config.MaxSize, 10) + " bytes"}
+}
+
+// imageSize reads the dimensions of a stored image; formats without a decoder report zero
+func (h *AttachmentHandler) imageSize(ctx context.Context, key string) (int, int) {
+	blob, err := h.Blobs.Get(ctx, key)
+	if err != nil {
+		h.Logger.Warn("Failed to open image", "error", err, "attachment_id", key)
+		return 0, 0
+	}
+	defer blob.Close()
+
+	config, _, err := image.DecodeConfig(blob)
+	if err != nil {
+		return 0, 0
+	}
+	return config.Width, config.Height
+}
+
+// getAttachment resolves an attachment the user may download
+func (h *AttachmentHandler) getAttachment(ctx context.Context, user domain.UserContext, attachmentID string) (*domain.Attachment, *handlerError) {
+	notFound := &handlerError{http.StatusNotFound, "Attachment not found", "ATTACHMENT_NOT_FOUND", ""}
+	if err := domain.ValidateAttachmentID(attachmentID); err != nil {
+		return nil, notFound
+	}
+
+	attachment, err := h.MessageRepo.GetAttachment(ctx, attachmentID)
+	if err == domain.ErrAttachmentNotFound {
+		return nil, notFound
+	}
+	if err != nil {
+		h.Logger.Error("Failed to get attachment", "error", err, "attachment_id", attachmentID, "user", user.UserID)
+		return nil, &handlerError{http.StatusInternalServerError, "Failed to download attachment", "DOWNLOAD_ERROR", ""}
+	}
+
+	// Outsiders cannot tell an attachment they may not see from a missing one
+	isParticipant, err := h.Authorizer.CanAccessChat(ctx, user.UserID, attachment.ChatID)
+	if err != nil {
+		h.Logger.Error("Failed to check chat participation", "error", err, "chat_id", attachment.ChatID, "user", user.UserID)
+		return nil, &handlerError{http.StatusInternalServerError, "Failed to download attachment", "DOWNLOAD_ERROR", ""}
+	}
+	if !isParticipant {
+		return nil, notFound
+	}
+
+	return attachment, nil
+}
+
+func (h *AttachmentHandler) isAllowedType(mimeType string) bool {
+	for _, allowed := range h.Config.AllowedTypes {
+		if allowed == mimeType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mimeType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *AttachmentHandler) deleteBlob(ctx context.Context, key string) {
+	if err := h.Blobs.Delete(ctx, key); err != nil {
+		h.Logger.Error("Failed to delete attachment content", "error", err, "attachment_id", key)
+	}
+}
+
+func (h *AttachmentHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message, code, details string) {
+	w.WriteHeader(statusCode)
+
+	response := httpAdapter.ErrorResponse{
+		Error:   message,
+		Code:    code,
+		Details: details,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to write error response", "error", err)
+	}
+}
+
+// filePart skips ahead to the part carrying the file
+func filePart(reader *multipart.Reader) (*multipart.Part, *handlerError) {
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, &handlerError{http.StatusBadRequest, "Missing file", "MISSING_FILE", "The " + AttachmentFormField + " form field is required"}
+		}
+		if err != nil {
+			return nil, &handlerError{http.StatusBadRequest, "Invalid multipart body", "INVALID_MULTIPART", err.Error()}
+		}
+		if part.FormName() == AttachmentFormField {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+// detectMimeType sniffs the media type of a file from its first bytes, without parameters
+func detectMimeType(head []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
+
+// byteCounter counts the bytes written to it
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,346 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentHandlerTestSuite struct {
+	suite.Suite
+	handler      *AttachmentHandler
+	mockRepo     *mocks.MessageRepository
+	mockChatRepo *mocks.ChatRepository
+	mockBlobs    *mocks.BlobStore
+	mockLogger   *mocks.Logger
+}
+
+func (s *AttachmentHandlerTestSuite) SetupTest() {
+	s.mockRepo = &mocks.MessageRepository{}
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.mockBlobs = &mocks.BlobStore{}
+	s.mockLogger = &mocks.Logger{}
+	s.handler = NewAttachmentHandler(DefaultAttachmentConfig(), s.mockRepo, s.mockChatRepo, s.mockBlobs, s.mockLogger)
+}
+
+func (s *AttachmentHandlerTestSuite) TearDownTest() {
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockChatRepo.AssertExpectations(s.T())
+	s.mockBlobs.AssertExpectations(s.T())
+	s.mockLogger.AssertExpectations(s.T())
+}
+
+// uploadRequest builds a multipart upload of content as the file field
+func (s *AttachmentHandlerTestSuite) uploadRequest(receiverID, fileName string, content []byte, user domain.UserContext) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(AttachmentFormField, fileName)
+	s.Require().NoError(err)
+	_, err = part.Write(content)
+	s.Require().NoError(err)
+	s.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest("POST", "/api/v1/chats/"+receiverID+"/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	ctx := context.WithValue(req.Context(), httpAdapter.UserContextKey, user)
+	return req.WithContext(ctx)
+}
+
+func (s *AttachmentHandlerTestSuite) downloadRequest(attachmentID string, user domain.UserContext) *http.Request {
+	req := httptest.NewRequest("GET", domain.AttachmentURL(attachmentID), nil)
+	ctx := context.WithValue(req.Context(), httpAdapter.UserContextKey, user)
+	return req.WithContext(ctx)
+}
+
+// storeBlobs keeps whatever is put in the mocked store so it can be read back
+func (s *AttachmentHandlerTestSuite) storeBlobs() *[]byte {
+	stored := new([]byte)
+	s.mockBlobs.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Run(func(args mock.Arguments) {
+		content, err := io.ReadAll(args.Get(2).(io.Reader))
+		s.Require().NoError(err)
+		*stored = content
+	}).Return(nil)
+	return stored
+}
+
+func pngImage(width, height int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+func storedAttachment() domain.Attachment {
+	attachment := domain.NewAttachment(testdata.Alice.UserID, domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID), "notes.txt")
+	attachment.MimeType = "text/plain"
+	attachment.Size = 5
+	attachment.Checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	attachment.CreatedAt = time.Now().UTC()
+	return attachment
+}
+
+// Upload Tests
+
+func (s *AttachmentHandlerTestSuite) TestUploadAttachment_Image() {
+	alice := testdata.Alice
+	bob := testdata.Bob
+	content := pngImage(3, 2)
+
+	stored := s.storeBlobs()
+	s.mockBlobs.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(func(context.Context, string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(*stored)), nil
+	})
+	s.mockRepo.On("SaveAttachment", mock.Anything, mock.MatchedBy(func(attachment domain.Attachment) bool {
+		return attachment.Validate() == nil &&
+			attachment.ChatID == domain.ComputeChatID(alice.UserID, bob.UserID) &&
+			attachment.MimeType == "image/png"
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Attachment uploaded successfully", "attachment_id", mock.Anything, "user", alice.UserID, "size", int64(len(content))).Return()
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UploadAttachment(recorder, s.uploadRequest(bob.UserID, "photo.png", content, alice))
+
+	// Assertions
+	s.Equal(http.StatusCreated, recorder.Code)
+
+	var response AttachmentResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal(alice.UserID, response.UploaderID)
+	s.Equal("photo.png", response.FileName)
+	s.Equal("image/png", response.MimeType)
+	s.Equal(int64(len(content)), response.Size)
+	s.Len(response.Checksum, 64)
+	s.Equal(3, response.Width)
+	s.Equal(2, response.Height)
+	s.Equal(domain.AttachmentURL(response.ID), response.URL)
+	s.Equal(content, *stored)
+}
+
+func (s *AttachmentHandlerTestSuite) TestUploadAttachment_GroupNotMember() {
+	groupID := domain.NewGroupChatID()
+
+	s.mockChatRepo.On("IsMember", mock.Anything, groupID, testdata.Alice.UserID).Return(false, nil)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UploadAttachment(recorder, s.uploadRequest(groupID, "notes.txt", []byte("hello"), testdata.Alice))
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("ACCESS_DENIED", errorResp.Code)
+}
+
+func (s *AttachmentHandlerTestSuite) TestUploadAttachment_UnsupportedType() {
+	recorder := httptest.NewRecorder()
+
+	// Execute: the claimed name does not matter, the content is an executable
+	s.handler.UploadAttachment(recorder, s.uploadRequest(testdata.Bob.UserID, "photo.png", []byte("MZ\x90\x00\x03\x00\x00\x00"), testdata.Alice))
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusUnsupportedMediaType, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("UNSUPPORTED_MEDIA_TYPE", errorResp.Code)
+}
+
+func (s *AttachmentHandlerTestSuite) TestUploadAttachment_TooLarge() {
+	s.handler.Config.MaxSize = 16
+
+	s.storeBlobs()
+	s.mockBlobs.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UploadAttachment(recorder, s.uploadRequest(testdata.Bob.UserID, "notes.txt", []byte(strings.Repeat("a", 17)), testdata.Alice))
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusRequestEntityTooLarge, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("ATTACHMENT_TOO_LARGE", errorResp.Code)
+}
+
+func (s *AttachmentHandlerTestSuite) TestUploadAttachment_MissingFile() {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	s.Require().NoError(writer.WriteField("caption", "no file here"))
+	s.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest("POST", "/api/v1/chats/"+testdata.Bob.UserID+"/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req = req.WithContext(context.WithValue(req.Context(), httpAdapter.UserContextKey, testdata.Alice))
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UploadAttachment(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("MISSING_FILE", errorResp.Code)
+}
+
+func (s *AttachmentHandlerTestSuite) TestUploadAttachment_SaveErrorRemovesBlob() {
+	s.storeBlobs()
+	s.mockRepo.On("SaveAttachment", mock.Anything, mock.Anything).Return(errors.New("database down"))
+	s.mockBlobs.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	s.mockLogger.On("Error", "Failed to save attachment", "error", mock.Anything, "attachment_id", mock.Anything, "user", testdata.Alice.UserID).Return()
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UploadAttachment(recorder, s.uploadRequest(testdata.Bob.UserID, "notes.txt", []byte("hello"), testdata.Alice))
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusInternalServerError, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("UPLOAD_ERROR", errorResp.Code)
+}
+
+func (s *AttachmentHandlerTestSuite) TestUploadAttachment_Blocked() {
+	// The same block that stops messages between the users stops files
+	s.storeBlobs()
+	s.mockRepo.On("SaveAttachment", mock.Anything, mock.Anything).Return(domain.ErrUserBlocked)
+	s.mockBlobs.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UploadAttachment(recorder, s.uploadRequest(testdata.Bob.UserID, "notes.txt", []byte("hello"), testdata.Alice))
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("USER_BLOCKED", errorResp.Code)
+}
+
+// Download Tests
+
+func (s *AttachmentHandlerTestSuite) TestDownloadAttachment_Participant() {
+	attachment := storedAttachment()
+
+	s.mockRepo.On("GetAttachment", mock.Anything, attachment.ID).Return(&attachment, nil)
+	s.mockBlobs.On("Get", mock.Anything, attachment.ID).Return(io.NopCloser(strings.NewReader("hello")), nil)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DownloadAttachment(recorder, s.downloadRequest(attachment.ID, testdata.Bob))
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal("hello", recorder.Body.String())
+	s.Equal("text/plain", recorder.Header().Get("Content-Type"))
+	s.Equal("5", recorder.Header().Get("Content-Length"))
+	s.Equal(`attachment; filename=notes.txt`, recorder.Header().Get("Content-Disposition"))
+	s.Equal("nosniff", recorder.Header().Get("X-Content-Type-Options"))
+	s.Equal(`"`+attachment.Checksum+`"`, recorder.Header().Get("ETag"))
+}
+
+func (s *AttachmentHandlerTestSuite) TestDownloadAttachment_NotModified() {
+	attachment := storedAttachment()
+
+	s.mockRepo.On("GetAttachment", mock.Anything, attachment.ID).Return(&attachment, nil)
+
+	req := s.downloadRequest(attachment.ID, testdata.Alice)
+	req.Header.Set("If-None-Match", `"`+attachment.Checksum+`"`)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DownloadAttachment(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNotModified, recorder.Code)
+	s.Empty(recorder.Body.Bytes())
+}
+
+func (s *AttachmentHandlerTestSuite) TestDownloadAttachment_Outsider() {
+	attachment := storedAttachment()
+
+	s.mockRepo.On("GetAttachment", mock.Anything, attachment.ID).Return(&attachment, nil)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute: Charlie is not part of the Alice and Bob chat
+	s.handler.DownloadAttachment(recorder, s.downloadRequest(attachment.ID, testdata.Charlie))
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusNotFound, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("ATTACHMENT_NOT_FOUND", errorResp.Code)
+}
+
+func (s *AttachmentHandlerTestSuite) TestDownloadAttachment_GroupMember() {
+	attachment := storedAttachment()
+	attachment.ChatID = domain.NewGroupChatID()
+
+	s.mockRepo.On("GetAttachment", mock.Anything, attachment.ID).Return(&attachment, nil)
+	s.mockChatRepo.On("IsMember", mock.Anything, attachment.ChatID, testdata.Charlie.UserID).Return(true, nil)
+	s.mockBlobs.On("Get", mock.Anything, attachment.ID).Return(io.NopCloser(strings.NewReader("hello")), nil)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DownloadAttachment(recorder, s.downloadRequest(attachment.ID, testdata.Charlie))
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal("hello", recorder.Body.String())
+}
+
+func (s *AttachmentHandlerTestSuite) TestDownloadAttachment_NotFound() {
+	attachmentID := domain.NewAttachment(testdata.Alice.UserID, "chat", "a.txt").ID
+
+	s.mockRepo.On("GetAttachment", mock.Anything, attachmentID).Return(nil, domain.ErrAttachmentNotFound)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DownloadAttachment(recorder, s.downloadRequest(attachmentID, testdata.Alice))
+
+	// Assertions
+	s.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (s *AttachmentHandlerTestSuite) TestDownloadAttachment_InvalidID() {
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DownloadAttachment(recorder, s.downloadRequest("not-a-uuid", testdata.Alice))
+
+	// Assertions
+	s.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestAttachmentHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentHandlerTestSuite))
+}
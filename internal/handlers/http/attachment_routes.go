@@ -0,0 +1,44 @@
+package http
+
+import (
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/ports"
+)
+
+type AttachmentRoutes struct {
+	config      AttachmentConfig
+	messageRepo ports.MessageRepository
+	chatRepo    ports.ChatRepository
+	blobs       ports.BlobStore
+	logger      ports.Logger
+}
+
+func NewAttachmentRoutes(config AttachmentConfig, messageRepo ports.MessageRepository, chatRepo ports.ChatRepository, blobs ports.BlobStore, logger ports.Logger) *AttachmentRoutes {
+	return &AttachmentRoutes{
+		config:      config,
+		messageRepo: messageRepo,
+		chatRepo:    chatRepo,
+		blobs:       blobs,
+		logger:      logger,
+	}
+}
+
+func (ar *AttachmentRoutes) GetRoutes() []httpAdapter.Route {
+	handler := NewAttachmentHandler(ar.config, ar.messageRepo, ar.chatRepo, ar.blobs, ar.logger)
+
+	return []httpAdapter.Route{
+		{
+			Method:      "POST",
+			Pattern:     "/api/v1/chats/{receiverId}/attachments",
+			Handler:     handler.UploadAttachment,
+			RequireAuth: true,
+			RateLimit:   ar.config.UploadRateLimit,
+		},
+		{
+			Method:      "GET",
+			Pattern:     "/api/v1/attachments/{attachmentId}",
+			Handler:     handler.DownloadAttachment,
+			RequireAuth: true,
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// Authorizer decides which chats and messages a user can access
+// Handlers go through it rather than checking participation themselves
+type Authorizer struct {
+	chatRepo ports.ChatRepository
+}
+
+func NewAuthorizer(chatRepo ports.ChatRepository) *Authorizer {
+	return &Authorizer{
+		chatRepo: chatRepo,
+	}
+}
+
+// CanAccessChat reports whether userID takes part in chatID: one of the two participants
+// of a 1:1 chat, or a current member of a group
+// Returns domain.ErrInvalidChatID if chatID is malformed
+func (a *Authorizer) CanAccessChat(ctx context.Context, userID, chatID string) (bool, error) {
+	chat, err := domain.ParseChatID(chatID)
+	if err != nil {
+		return false, err
+	}
+	if chat.IsGroup() {
+		return a.chatRepo.IsMember(ctx, chat.ID, userID)
+	}
+	return chat.HasParticipant(userID), nil
+}
+
+// CanAccessMessage reports whether userID takes part in the chat of message
+func (a *Authorizer) CanAccessMessage(ctx context.Context, userID string, message domain.Message) (bool, error) {
+	if message.IsGroupMessage() {
+		return a.chatRepo.IsMember(ctx, message.ReceiverID, userID)
+	}
+	return message.IsParticipant(userID), nil
+}
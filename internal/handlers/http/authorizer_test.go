@@ -0,0 +1,168 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorizerTestSuite struct {
+	suite.Suite
+	authorizer   *Authorizer
+	mockChatRepo *mocks.ChatRepository
+}
+
+func (s *AuthorizerTestSuite) SetupTest() {
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.authorizer = NewAuthorizer(s.mockChatRepo)
+}
+
+func (s *AuthorizerTestSuite) TearDownTest() {
+	s.mockChatRepo.AssertExpectations(s.T())
+}
+
+func (s *AuthorizerTestSuite) TestCanAccessChat_DirectChats() {
+	aliceBob := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+
+	tests := []struct {
+		name    string
+		userID  string
+		chatID  string
+		allowed bool
+		err     error
+	}{
+		{name: "first participant", userID: "alice", chatID: aliceBob, allowed: true},
+		{name: "second participant", userID: "bob", chatID: aliceBob, allowed: true},
+		{name: "outsider", userID: "charlie", chatID: aliceBob},
+		{name: "prefix of a participant", userID: "al", chatID: aliceBob},
+		{name: "suffix of a participant", userID: "ob", chatID: aliceBob},
+		{name: "participant is a prefix of the user", userID: "bob", chatID: "alice---bobby"},
+		{name: "whole chat ID as user ID", userID: aliceBob, chatID: aliceBob},
+		{name: "separator as user ID", userID: "---", chatID: aliceBob},
+		{name: "empty user ID", userID: "", chatID: aliceBob},
+		{name: "non-canonical order", userID: "alice", chatID: "bob---alice", err: domain.ErrInvalidChatID},
+		{name: "injected third participant", userID: "charlie", chatID: "alice---bob---charlie", err: domain.ErrInvalidChatID},
+		{name: "injected separator in participant", userID: "bob", chatID: "alice---bob---eve", err: domain.ErrInvalidChatID},
+		{name: "extra dash before participant", userID: "b", chatID: "a----b", err: domain.ErrInvalidChatID},
+		{name: "trailing dash", userID: "alice", chatID: "alice---", err: domain.ErrInvalidChatID},
+		{name: "leading dash", userID: "bob", chatID: "---bob", err: domain.ErrInvalidChatID},
+		{name: "self chat", userID: "alice", chatID: "alice---alice", err: domain.ErrInvalidChatID},
+		{name: "no separator", userID: "alice", chatID: "alice_bob", err: domain.ErrInvalidChatID},
+		{name: "empty chat ID", userID: "alice", chatID: "", err: domain.ErrInvalidChatID},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			allowed, err := s.authorizer.CanAccessChat(context.Background(), tt.userID, tt.chatID)
+
+			if tt.err != nil {
+				s.ErrorIs(err, tt.err)
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(tt.allowed, allowed)
+		})
+	}
+}
+
+func (s *AuthorizerTestSuite) TestCanAccessChat_Groups() {
+	chatID := domain.NewGroupChatID()
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, testdata.Alice.UserID).Return(true, nil)
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, testdata.Eve.UserID).Return(false, nil)
+
+	allowed, err := s.authorizer.CanAccessChat(context.Background(), testdata.Alice.UserID, chatID)
+	s.NoError(err)
+	s.True(allowed)
+
+	allowed, err = s.authorizer.CanAccessChat(context.Background(), testdata.Eve.UserID, chatID)
+	s.NoError(err)
+	s.False(allowed)
+}
+
+func (s *AuthorizerTestSuite) TestCanAccessChat_GroupRepositoryError() {
+	chatID := domain.NewGroupChatID()
+	repoErr := errors.New("database error")
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, testdata.Alice.UserID).Return(false, repoErr)
+
+	allowed, err := s.authorizer.CanAccessChat(context.Background(), testdata.Alice.UserID, chatID)
+	s.ErrorIs(err, repoErr)
+	s.False(allowed)
+}
+
+func (s *AuthorizerTestSuite) TestCanAccessMessage() {
+	message := testdata.ValidMessages()[0]
+	groupMessage := message
+	groupMessage.ReceiverID = domain.NewGroupChatID()
+
+	s.mockChatRepo.On("IsMember", mock.Anything, groupMessage.ReceiverID, testdata.Charlie.UserID).Return(true, nil)
+
+	tests := []struct {
+		name    string
+		userID  string
+		message domain.Message
+		allowed bool
+	}{
+		{name: "sender", userID: message.SenderID, message: message, allowed: true},
+		{name: "receiver", userID: message.ReceiverID, message: message, allowed: true},
+		{name: "outsider", userID: testdata.Charlie.UserID, message: message},
+		{name: "substring of the sender", userID: message.SenderID[:2], message: message},
+		{name: "group member", userID: testdata.Charlie.UserID, message: groupMessage, allowed: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			allowed, err := s.authorizer.CanAccessMessage(context.Background(), tt.userID, tt.message)
+
+			s.NoError(err)
+			s.Equal(tt.allowed, allowed)
+		})
+	}
+}
+
+func (s *AuthorizerTestSuite) TestValidateUserID() {
+	tests := []struct {
+		userID string
+		err    error
+	}{
+		{userID: "alice"},
+		{userID: "alice-smith"},
+		{userID: "alice_bob"},
+		{userID: "", err: domain.ErrMissingUserID},
+		{userID: "   ", err: domain.ErrMissingUserID},
+		{userID: "alice---bob", err: domain.ErrInvalidUserID},
+		{userID: "---", err: domain.ErrInvalidUserID},
+		{userID: "alice-", err: domain.ErrInvalidUserID},
+		{userID: "-bob", err: domain.ErrInvalidUserID},
+		{userID: domain.NewGroupChatID(), err: domain.ErrInvalidUserID},
+		{userID: "alice.bob", err: domain.ErrInvalidUserID},
+		{userID: "alice*", err: domain.ErrInvalidUserID},
+		{userID: ">", err: domain.ErrInvalidUserID},
+		{userID: "alice bob", err: domain.ErrInvalidUserID},
+		{userID: "alice\t", err: domain.ErrInvalidUserID},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.userID, func() {
+			err := domain.ValidateUserID(tt.userID)
+
+			if tt.err != nil {
+				s.ErrorIs(err, tt.err)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func TestAuthorizerSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizerTestSuite))
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// BlockHandler manages the users the authenticated user blocked
+type BlockHandler struct {
+	BlockRepo ports.BlockRepository
+	Logger    ports.Logger
+}
+
+func NewBlockHandler(blockRepo ports.BlockRepository, logger ports.Logger) *BlockHandler {
+	return &BlockHandler{
+		BlockRepo: blockRepo,
+		Logger:    logger,
+	}
+}
+
+// GetBlocks handles GET /api/v1/users/me/blocks
+func (h *BlockHandler) GetBlocks(w http.ResponseWriter, r *http.Request) {
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	blocks, err := h.BlockRepo.GetBlockedUsers(r.Context(), user.UserID)
+	if err != nil {
+		h.Logger.Error("Failed to get blocks", "error", err, "user", user.UserID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to get blocks", "GET_BLOCKS_ERROR", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(BlocksResponse{Blocks: blocks})
+
+	h.Logger.Debug("Blocks retrieved successfully", "user", user.UserID, "count", len(blocks))
+}
+
+// BlockUser handles POST /api/v1/users/me/blocks
+// Blocking a user twice is not an error
+func (h *BlockHandler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	var req BlockUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON", err.Error())
+		return
+	}
+
+	block := domain.Block{
+		BlockerID: user.UserID,
+		BlockedID: strings.TrimSpace(req.UserID),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := block.Validate(); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	if err := h.BlockRepo.BlockUser(r.Context(), block); err != nil {
+		h.Logger.Error("Failed to block user", "error", err, "user", user.UserID, "blocked", block.BlockedID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to block user", "BLOCK_ERROR", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.Logger.Debug("User blocked successfully", "user", user.UserID, "blocked", block.BlockedID)
+}
+
+// UnblockUser handles DELETE /api/v1/users/me/blocks/{userId}
+// Unblocking a user that is not blocked is not an error
+func (h *BlockHandler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	// Extract userId from path: /api/v1/users/me/blocks/{userId}
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 6 || pathParts[5] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing user ID", "MISSING_USER_ID", "userId path parameter is required")
+		return
+	}
+	blockedID := pathParts[5]
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	if err := h.BlockRepo.UnblockUser(r.Context(), user.UserID, blockedID); err != nil {
+		h.Logger.Error("Failed to unblock user", "error", err, "user", user.UserID, "blocked", blockedID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to unblock user", "UNBLOCK_ERROR", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.Logger.Debug("User unblocked successfully", "user", user.UserID, "blocked", blockedID)
+}
+
+func (h *BlockHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message, code, details string) {
+	w.WriteHeader(statusCode)
+
+	response := httpAdapter.ErrorResponse{
+		Error:   message,
+		Code:    code,
+		Details: details,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to write error response", "error", err)
+	}
+}
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type BlockHandlerTestSuite struct {
+	suite.Suite
+	handler       *BlockHandler
+	mockBlockRepo *mocks.BlockRepository
+	mockLogger    *mocks.Logger
+	helpers       *TestHelpers
+}
+
+func (s *BlockHandlerTestSuite) SetupTest() {
+	s.mockBlockRepo = &mocks.BlockRepository{}
+	s.mockLogger = &mocks.Logger{}
+	s.handler = NewBlockHandler(s.mockBlockRepo, s.mockLogger)
+	s.helpers = NewTestHelpers(s.T())
+}
+
+func (s *BlockHandlerTestSuite) TearDownTest() {
+	s.mockBlockRepo.AssertExpectations(s.T())
+	s.mockLogger.AssertExpectations(s.T())
+}
+
+// GetBlocks Tests
+
+func (s *BlockHandlerTestSuite) TestGetBlocks_Success() {
+	alice := testdata.Alice
+	blocks := []domain.Block{
+		{BlockerID: alice.UserID, BlockedID: testdata.Eve.UserID, CreatedAt: testdata.BaseTime},
+	}
+
+	s.mockBlockRepo.On("GetBlockedUsers", mock.Anything, alice.UserID).Return(blocks, nil)
+	s.mockLogger.On("Debug", "Blocks retrieved successfully", "user", alice.UserID, "count", 1).Return()
+
+	req := s.helpers.CreateRequestWithUser("GET", "/api/v1/users/me/blocks", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetBlocks(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response BlocksResponse
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal(blocks, response.Blocks)
+}
+
+func (s *BlockHandlerTestSuite) TestGetBlocks_RepositoryError() {
+	alice := testdata.Alice
+
+	s.mockBlockRepo.On("GetBlockedUsers", mock.Anything, alice.UserID).Return(nil, errors.New("database error"))
+	s.mockLogger.On("Error", "Failed to get blocks", "error", mock.Anything, "user", alice.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("GET", "/api/v1/users/me/blocks", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetBlocks(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusInternalServerError, "Failed to get blocks", "GET_BLOCKS_ERROR")
+}
+
+// BlockUser Tests
+
+func (s *BlockHandlerTestSuite) TestBlockUser_Success() {
+	alice := testdata.Alice
+	eve := testdata.Eve
+
+	s.mockBlockRepo.On("BlockUser", mock.Anything, mock.MatchedBy(func(block domain.Block) bool {
+		return block.BlockerID == alice.UserID && block.BlockedID == eve.UserID && !block.CreatedAt.IsZero()
+	})).Return(nil)
+	s.mockLogger.On("Debug", "User blocked successfully", "user", alice.UserID, "blocked", eve.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/users/me/blocks", BlockUserRequest{UserID: " " + eve.UserID + " "}, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.BlockUser(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+	s.Empty(recorder.Body.Bytes())
+}
+
+func (s *BlockHandlerTestSuite) TestBlockUser_InvalidUser() {
+	alice := testdata.Alice
+
+	userIDs := map[string]string{
+		"self":      alice.UserID,
+		"missing":   "",
+		"separator": "bob---eve",
+		"group":     domain.NewGroupChatID(),
+	}
+
+	for name, userID := range userIDs {
+		s.Run(name, func() {
+			req := s.helpers.CreateRequestWithUser("POST", "/api/v1/users/me/blocks", BlockUserRequest{UserID: userID}, alice)
+			recorder := httptest.NewRecorder()
+
+			// Execute
+			s.handler.BlockUser(recorder, req)
+
+			// Assertions
+			s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR")
+		})
+	}
+}
+
+func (s *BlockHandlerTestSuite) TestBlockUser_InvalidJSON() {
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/users/me/blocks", nil, testdata.Alice)
+	req.Body = http.NoBody // Invalid JSON
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.BlockUser(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON")
+}
+
+func (s *BlockHandlerTestSuite) TestBlockUser_RepositoryError() {
+	alice := testdata.Alice
+	eve := testdata.Eve
+
+	s.mockBlockRepo.On("BlockUser", mock.Anything, mock.Anything).Return(errors.New("database error"))
+	s.mockLogger.On("Error", "Failed to block user", "error", mock.Anything, "user", alice.UserID, "blocked", eve.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/users/me/blocks", BlockUserRequest{UserID: eve.UserID}, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.BlockUser(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusInternalServerError, "Failed to block user", "BLOCK_ERROR")
+}
+
+// UnblockUser Tests
+
+func (s *BlockHandlerTestSuite) TestUnblockUser_Success() {
+	alice := testdata.Alice
+	eve := testdata.Eve
+
+	s.mockBlockRepo.On("UnblockUser", mock.Anything, alice.UserID, eve.UserID).Return(nil)
+	s.mockLogger.On("Debug", "User unblocked successfully", "user", alice.UserID, "blocked", eve.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("DELETE", "/api/v1/users/me/blocks/"+eve.UserID, nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UnblockUser(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *BlockHandlerTestSuite) TestUnblockUser_MissingUserID() {
+	req := s.helpers.CreateRequestWithUser("DELETE", "/api/v1/users/me/blocks/", nil, testdata.Alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UnblockUser(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Missing user ID", "MISSING_USER_ID")
+}
+
+func (s *BlockHandlerTestSuite) TestBlockUser_NoUserContext() {
+	req := s.helpers.CreateRequestWithoutUser("POST", "/api/v1/users/me/blocks", BlockUserRequest{UserID: testdata.Eve.UserID})
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.BlockUser(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT")
+}
+
+func TestBlockHandlerSuite(t *testing.T) {
+	suite.Run(t, new(BlockHandlerTestSuite))
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/ports"
+)
+
+type BlockRoutes struct {
+	blockRepo ports.BlockRepository
+	logger    ports.Logger
+}
+
+func NewBlockRoutes(blockRepo ports.BlockRepository, logger ports.Logger) *BlockRoutes {
+	return &BlockRoutes{
+		blockRepo: blockRepo,
+		logger:    logger,
+	}
+}
+
+func (br *BlockRoutes) GetRoutes() []httpAdapter.Route {
+	handler := NewBlockHandler(br.blockRepo, br.logger)
+
+	return []httpAdapter.Route{
+		{
+			Method:      "GET",
+			Pattern:     "/api/v1/users/me/blocks",
+			Handler:     handler.GetBlocks,
+			RequireAuth: true,
+		},
+		{
+			Method:      "POST",
+			Pattern:     "/api/v1/users/me/blocks",
+			Handler:     handler.BlockUser,
+			RequireAuth: true,
+		},
+		{
+			Method:      "DELETE",
+			Pattern:     "/api/v1/users/me/blocks/{userId}",
+			Handler:     handler.UnblockUser,
+			RequireAuth: true,
+		},
+	}
+}
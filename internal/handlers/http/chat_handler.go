@@ -3,8 +3,10 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
 	"messaging-app/internal/ports"
 )
 
@@ -21,7 +23,7 @@ func NewChatHandler(messageRepo ports.MessageRepository, logger ports.Logger) *C
 	}
 }
 
-// GetChats handles GET /chats
+// GetChats handles GET /api/v1/chats
 func (h *ChatHandler) GetChats(w http.ResponseWriter, r *http.Request) {
 	user, ok := httpAdapter.GetUserFromContext(r.Context())
 	if !ok {
@@ -29,16 +31,52 @@ func (h *ChatHandler) GetChats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get chat sessions for the user
-	sessions, err := h.MessageRepo.GetChatSessions(r.Context(), user.UserID)
+	// Parse query parameters
+	cursorStr := r.URL.Query().Get("cursor")
+	limitStr := r.URL.Query().Get("limit")
+
+	var cursor domain.ChatCursor
+	if cursorStr != "" {
+		var err error
+		cursor, err = domain.ParseChatCursor(cursorStr)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid cursor format", "INVALID_CURSOR", "Cursor must be a next_cursor value returned by a previous page")
+			return
+		}
+	}
+
+	limit := 50 // Default limit
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid limit", "INVALID_LIMIT", "Limit must be between 1 and 100")
+			return
+		}
+	}
+
+	// Get chat sessions for the user, plus one telling whether another page follows
+	sessions, err := h.MessageRepo.GetChatSessions(r.Context(), user.UserID, cursor, limit+1)
 	if err != nil {
 		h.Logger.Error("Failed to get chat sessions", "error", err, "user", user.UserID)
 		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to get chats", "GET_CHATS_ERROR", "")
 		return
 	}
 
+	hasMore := len(sessions) > limit
+	if hasMore {
+		sessions = sessions[:limit]
+	}
+
+	// Build response
 	response := GetChatsResponse{
-		Chats: sessions,
+		Chats:   sessions,
+		HasMore: hasMore,
+	}
+
+	// Set next cursor if there are more chats
+	if response.HasMore {
+		response.NextCursor = sessions[len(sessions)-1].Cursor().Encode()
 	}
 
 	w.WriteHeader(http.StatusOK)
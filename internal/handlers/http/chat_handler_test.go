@@ -81,7 +81,7 @@ func (s *ChatHandlerTestSuite) TestGetChats_Success() {
 	}
 
 	// Mock expectations
-	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID).Return(expectedSessions, nil)
+	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID, domain.ChatCursor{}, 51).Return(expectedSessions, nil)
 	s.mockLogger.On("Debug", "Chat sessions retrieved successfully", "user", alice.UserID, "count", len(expectedSessions)).Return()
 
 	// Create request
@@ -111,7 +111,7 @@ func (s *ChatHandlerTestSuite) TestGetChats_EmptyResult() {
 	expectedSessions := []domain.ChatSession{}
 
 	// Mock expectations
-	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID).Return(expectedSessions, nil)
+	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID, domain.ChatCursor{}, 51).Return(expectedSessions, nil)
 	s.mockLogger.On("Debug", "Chat sessions retrieved successfully", "user", alice.UserID, "count", 0).Return()
 
 	// Create request
@@ -155,7 +155,7 @@ func (s *ChatHandlerTestSuite) TestGetChats_RepositoryError() {
 
 	// Mock repository error
 	repoError := assert.AnError
-	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID).Return(nil, repoError)
+	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID, domain.ChatCursor{}, 51).Return(nil, repoError)
 	s.mockLogger.On("Error", "Failed to get chat sessions", "error", repoError, "user", alice.UserID).Return()
 
 	// Create request
@@ -190,11 +190,11 @@ func (s *ChatHandlerTestSuite) TestGetChats_LargeNumberOfChats() {
 	}
 
 	// Mock expectations
-	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID).Return(expectedSessions, nil)
+	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID, domain.ChatCursor{}, 101).Return(expectedSessions, nil)
 	s.mockLogger.On("Debug", "Chat sessions retrieved successfully", "user", alice.UserID, "count", 100).Return()
 
 	// Create request
-	req := s.createRequestWithUser("GET", "/api/v1/chats", alice)
+	req := s.createRequestWithUser("GET", "/api/v1/chats?limit=100", alice)
 	recorder := httptest.NewRecorder()
 
 	// Execute
@@ -209,6 +209,10 @@ func (s *ChatHandlerTestSuite) TestGetChats_LargeNumberOfChats() {
 
 	s.Equal(100, len(response.Chats))
 	s.Equal(expectedSessions[0].ChatID, response.Chats[0].ChatID)
+
+	// Exactly the limit is left, so there is no next page to point at
+	s.False(response.HasMore)
+	s.Empty(response.NextCursor)
 }
 
 func (s *ChatHandlerTestSuite) TestGetChats_UserWithSpecialCharacters() {
@@ -225,7 +229,7 @@ func (s *ChatHandlerTestSuite) TestGetChats_UserWithSpecialCharacters() {
 	}
 
 	// Mock expectations
-	s.mockRepo.On("GetChatSessions", mock.Anything, specialUser.UserID).Return(expectedSessions, nil)
+	s.mockRepo.On("GetChatSessions", mock.Anything, specialUser.UserID, domain.ChatCursor{}, 51).Return(expectedSessions, nil)
 	s.mockLogger.On("Debug", "Chat sessions retrieved successfully", "user", specialUser.UserID, "count", 1).Return()
 
 	// Create request
@@ -272,7 +276,7 @@ func (s *ChatHandlerTestSuite) TestGetChats_SortedByLastMessage() {
 	}
 
 	// Mock expectations
-	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID).Return(expectedSessions, nil)
+	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID, domain.ChatCursor{}, 51).Return(expectedSessions, nil)
 	s.mockLogger.On("Debug", "Chat sessions retrieved successfully", "user", alice.UserID, "count", 3).Return()
 
 	// Create request
@@ -323,7 +327,7 @@ func (s *ChatHandlerTestSuite) TestGetChats_ResponseFormat() {
 	}
 
 	// Mock expectations
-	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID).Return(expectedSessions, nil)
+	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID, domain.ChatCursor{}, 51).Return(expectedSessions, nil)
 	s.mockLogger.On("Debug", "Chat sessions retrieved successfully", "user", alice.UserID, "count", 1).Return()
 
 	// Create request
@@ -359,6 +363,130 @@ func (s *ChatHandlerTestSuite) TestGetChats_ResponseFormat() {
 	s.Contains(chat, "other_participant")
 }
 
+func (s *ChatHandlerTestSuite) TestGetChats_Pagination() {
+	alice := testdata.Alice
+
+	cursor := domain.ChatCursor{LastMessageAt: testdata.BaseTime, ChatID: domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)}
+	expectedSessions := []domain.ChatSession{
+		{
+			ChatID:           domain.ComputeChatID(alice.UserID, testdata.Charlie.UserID),
+			ChatType:         domain.ChatTypeDirect,
+			LastMessageAt:    testdata.BaseTime.Add(-1 * time.Hour),
+			OtherParticipant: testdata.Charlie.UserID,
+		},
+		{
+			ChatID:           domain.ComputeChatID(alice.UserID, testdata.Diana.UserID),
+			ChatType:         domain.ChatTypeDirect,
+			LastMessageAt:    testdata.BaseTime.Add(-2 * time.Hour),
+			OtherParticipant: testdata.Diana.UserID,
+		},
+		{
+			ChatID:           domain.ComputeChatID(alice.UserID, "eve"),
+			ChatType:         domain.ChatTypeDirect,
+			LastMessageAt:    testdata.BaseTime.Add(-3 * time.Hour),
+			OtherParticipant: "eve",
+		},
+	}
+
+	// Mock expectations
+	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID, cursor, 3).Return(expectedSessions, nil)
+	s.mockLogger.On("Debug", "Chat sessions retrieved successfully", "user", alice.UserID, "count", 2).Return()
+
+	// Create request
+	req := s.createRequestWithUser("GET", "/api/v1/chats?limit=2&cursor="+cursor.Encode(), alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetChats(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response GetChatsResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.NoError(err)
+
+	s.Len(response.Chats, 2)
+	s.True(response.HasMore)
+
+	// The next cursor points at the last chat of the page
+	next, err := domain.ParseChatCursor(response.NextCursor)
+	s.NoError(err)
+	s.Equal(expectedSessions[1].ChatID, next.ChatID)
+	s.True(expectedSessions[1].LastMessageAt.Equal(next.LastMessageAt))
+}
+
+func (s *ChatHandlerTestSuite) TestGetChats_LastPage() {
+	alice := testdata.Alice
+
+	expectedSessions := []domain.ChatSession{
+		{
+			ChatID:           domain.ComputeChatID(alice.UserID, testdata.Bob.UserID),
+			LastMessageAt:    testdata.BaseTime,
+			OtherParticipant: testdata.Bob.UserID,
+		},
+	}
+
+	// Mock expectations
+	s.mockRepo.On("GetChatSessions", mock.Anything, alice.UserID, domain.ChatCursor{}, 51).Return(expectedSessions, nil)
+	s.mockLogger.On("Debug", "Chat sessions retrieved successfully", "user", alice.UserID, "count", 1).Return()
+
+	// Create request
+	req := s.createRequestWithUser("GET", "/api/v1/chats", alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetChats(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response GetChatsResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.NoError(err)
+
+	s.False(response.HasMore)
+	s.Empty(response.NextCursor)
+}
+
+func (s *ChatHandlerTestSuite) TestGetChats_InvalidCursor() {
+	alice := testdata.Alice
+
+	// Create request
+	req := s.createRequestWithUser("GET", "/api/v1/chats?cursor=not-a-cursor", alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetChats(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusBadRequest, recorder.Code)
+
+	var errorResp httpAdapter.ErrorResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
+	s.NoError(err)
+	s.Equal("INVALID_CURSOR", errorResp.Code)
+}
+
+func (s *ChatHandlerTestSuite) TestGetChats_InvalidLimit() {
+	alice := testdata.Alice
+
+	// Create request
+	req := s.createRequestWithUser("GET", "/api/v1/chats?limit=101", alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetChats(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusBadRequest, recorder.Code)
+
+	var errorResp httpAdapter.ErrorResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
+	s.NoError(err)
+	s.Equal("INVALID_LIMIT", errorResp.Code)
+}
+
 func TestChatHandlerSuite(t *testing.T) {
 	suite.Run(t, new(ChatHandlerTestSuite))
 }
\ No newline at end of file
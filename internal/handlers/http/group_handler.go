@@ -0,0 +1,266 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// GroupHandler handles group chat management requests
+type GroupHandler struct {
+	ChatRepo   ports.ChatRepository
+	Authorizer *Authorizer
+	Logger     ports.Logger
+}
+
+func NewGroupHandler(chatRepo ports.ChatRepository, logger ports.Logger) *GroupHandler {
+	return &GroupHandler{
+		ChatRepo:   chatRepo,
+		Authorizer: NewAuthorizer(chatRepo),
+		Logger:     logger,
+	}
+}
+
+// CreateGroup handles POST /api/v1/groups
+func (h *GroupHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	var req CreateGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON", err.Error())
+		return
+	}
+
+	chat := domain.NewGroupChat(req.Name, user.UserID, req.Members)
+	if err := chat.Validate(); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	if err := h.ChatRepo.CreateGroupChat(r.Context(), chat); err != nil {
+		h.Logger.Error("Failed to create group", "error", err, "user", user.UserID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to create group", "CREATE_GROUP_ERROR", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(GroupResponse{Group: chat})
+
+	h.Logger.Debug("Group created successfully", "chat_id", chat.ID, "user", user.UserID, "members", len(chat.Members))
+}
+
+// GetGroup handles GET /api/v1/groups/{chatId}
+func (h *GroupHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := h.chatIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	chat, ok := h.loadGroupForMember(w, r, chatID, user.UserID)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(GroupResponse{Group: *chat})
+}
+
+// AddMember handles POST /api/v1/groups/{chatId}/members
+func (h *GroupHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := h.chatIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	var req AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON", err.Error())
+		return
+	}
+
+	newMemberID := strings.TrimSpace(req.UserID)
+	if err := domain.ValidateUserID(newMemberID); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	chat, ok := h.loadGroupForMember(w, r, chatID, user.UserID)
+	if !ok {
+		return
+	}
+
+	if !chat.IsOwner(user.UserID) {
+		h.writeErrorResponse(w, http.StatusForbidden, "Access denied", "ACCESS_DENIED", "Only group owners can add members")
+		return
+	}
+
+	if len(chat.Members) >= domain.MaxGroupMembers {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", domain.ErrTooManyMembers.Error())
+		return
+	}
+
+	member := domain.ChatMember{
+		ChatID:   chatID,
+		UserID:   newMemberID,
+		Role:     domain.ChatRoleMember,
+		JoinedAt: time.Now().UTC(),
+	}
+
+	if err := h.ChatRepo.AddMember(r.Context(), member); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMemberExists):
+			h.writeErrorResponse(w, http.StatusConflict, "Member already exists", "MEMBER_EXISTS", err.Error())
+		case errors.Is(err, domain.ErrChatNotFound):
+			h.writeErrorResponse(w, http.StatusNotFound, "Group not found", "GROUP_NOT_FOUND", "")
+		default:
+			h.Logger.Error("Failed to add member", "error", err, "chat_id", chatID, "user", user.UserID)
+			h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to add member", "ADD_MEMBER_ERROR", "")
+		}
+		return
+	}
+
+	chat.Members = append(chat.Members, member)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(GroupResponse{Group: *chat})
+
+	h.Logger.Debug("Member added successfully", "chat_id", chatID, "user", user.UserID, "member", newMemberID)
+}
+
+// RemoveMember handles DELETE /api/v1/groups/{chatId}/members/{userId}
+func (h *GroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := h.chatIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Extract userId from path: /api/v1/groups/{chatId}/members/{userId}
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 6 || pathParts[5] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing user ID", "MISSING_MEMBER_ID", "userId path parameter is required")
+		return
+	}
+	memberID := pathParts[5]
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	chat, ok := h.loadGroupForMember(w, r, chatID, user.UserID)
+	if !ok {
+		return
+	}
+
+	// Members may leave on their own, only owners can remove others
+	if memberID != user.UserID && !chat.IsOwner(user.UserID) {
+		h.writeErrorResponse(w, http.StatusForbidden, "Access denied", "ACCESS_DENIED", "Only group owners can remove other members")
+		return
+	}
+
+	// Ownership cannot be transferred, so the owner leaving would leave the group unmanaged
+	if memberID == user.UserID && chat.IsOwner(user.UserID) {
+		h.writeErrorResponse(w, http.StatusConflict, "Owner cannot leave", "OWNER_CANNOT_LEAVE", "Group owners cannot remove themselves")
+		return
+	}
+
+	if err := h.ChatRepo.RemoveMember(r.Context(), chatID, memberID); err != nil {
+		if errors.Is(err, domain.ErrNotChatMember) {
+			h.writeErrorResponse(w, http.StatusNotFound, "Member not found", "MEMBER_NOT_FOUND", err.Error())
+		} else {
+			h.Logger.Error("Failed to remove member", "error", err, "chat_id", chatID, "user", user.UserID)
+			h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to remove member", "REMOVE_MEMBER_ERROR", "")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.Logger.Debug("Member removed successfully", "chat_id", chatID, "user", user.UserID, "member", memberID)
+}
+
+// Helper methods
+
+// chatIDFromPath extracts chatId from path: /api/v1/groups/{chatId}/...
+func (h *GroupHandler) chatIDFromPath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing chat ID", "MISSING_CHAT_ID", "chatId path parameter is required")
+		return "", false
+	}
+
+	return pathParts[3], true
+}
+
+// loadGroupForMember ensures the user belongs to the group, then fetches it
+// Unknown groups are denied like any other the user is not a member of
+func (h *GroupHandler) loadGroupForMember(w http.ResponseWriter, r *http.Request, chatID, userID string) (*domain.Chat, bool) {
+	isMember, err := h.Authorizer.CanAccessChat(r.Context(), userID, chatID)
+	if errors.Is(err, domain.ErrInvalidChatID) {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID", err.Error())
+		return nil, false
+	}
+	if err != nil {
+		h.Logger.Error("Failed to check group membership", "error", err, "chat_id", chatID, "user", userID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to get group", "GET_GROUP_ERROR", "")
+		return nil, false
+	}
+	if !domain.IsGroupChatID(chatID) {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID", "chatId must reference a group chat")
+		return nil, false
+	}
+	if !isMember {
+		h.writeErrorResponse(w, http.StatusForbidden, "Access denied", "ACCESS_DENIED", "User is not a member of this group")
+		return nil, false
+	}
+
+	chat, err := h.ChatRepo.GetChat(r.Context(), chatID)
+	if err != nil {
+		if errors.Is(err, domain.ErrChatNotFound) {
+			h.writeErrorResponse(w, http.StatusNotFound, "Group not found", "GROUP_NOT_FOUND", "")
+		} else {
+			h.Logger.Error("Failed to get group", "error", err, "chat_id", chatID, "user", userID)
+			h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to get group", "GET_GROUP_ERROR", "")
+		}
+		return nil, false
+	}
+
+	return chat, true
+}
+
+func (h *GroupHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message, code, details string) {
+	w.WriteHeader(statusCode)
+
+	response := httpAdapter.ErrorResponse{
+		Error:   message,
+		Code:    code,
+		Details: details,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to write error response", "error", err)
+	}
+}
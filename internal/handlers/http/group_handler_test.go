@@ -0,0 +1,418 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupHandlerTestSuite struct {
+	suite.Suite
+	handler      *GroupHandler
+	mockChatRepo *mocks.ChatRepository
+	mockLogger   *mocks.Logger
+	helpers      *TestHelpers
+}
+
+func (s *GroupHandlerTestSuite) SetupTest() {
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.mockLogger = &mocks.Logger{}
+	s.handler = NewGroupHandler(s.mockChatRepo, s.mockLogger)
+	s.helpers = NewTestHelpers(s.T())
+}
+
+func (s *GroupHandlerTestSuite) TearDownTest() {
+	s.mockChatRepo.AssertExpectations(s.T())
+	s.mockLogger.AssertExpectations(s.T())
+}
+
+func (s *GroupHandlerTestSuite) sampleGroup() *domain.Chat {
+	chat := domain.NewGroupChat("Design review", testdata.Alice.UserID, []string{testdata.Bob.UserID, testdata.Charlie.UserID})
+	return &chat
+}
+
+// CreateGroup Tests
+
+func (s *GroupHandlerTestSuite) TestCreateGroup_Success() {
+	alice := testdata.Alice
+
+	requestBody := CreateGroupRequest{
+		Name:    "Design review",
+		Members: []string{testdata.Bob.UserID, testdata.Charlie.UserID, testdata.Bob.UserID},
+	}
+
+	s.mockChatRepo.On("CreateGroupChat", mock.Anything, mock.MatchedBy(func(chat domain.Chat) bool {
+		return domain.IsGroupChatID(chat.ID) &&
+			chat.Name == requestBody.Name &&
+			chat.CreatedBy == alice.UserID &&
+			len(chat.Members) == 3 &&
+			chat.IsOwner(alice.UserID)
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Group created successfully", "chat_id", mock.Anything, "user", alice.UserID, "members", 3).Return()
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/groups", requestBody, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.CreateGroup(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusCreated, recorder.Code)
+
+	var response GroupResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.True(domain.IsGroupChatID(response.Group.ID))
+	s.ElementsMatch([]string{alice.UserID, testdata.Bob.UserID, testdata.Charlie.UserID}, response.Group.MemberIDs())
+}
+
+func (s *GroupHandlerTestSuite) TestCreateGroup_NotEnoughMembers() {
+	alice := testdata.Alice
+
+	requestBody := CreateGroupRequest{
+		Name:    "Just me",
+		Members: []string{alice.UserID},
+	}
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/groups", requestBody, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.CreateGroup(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR")
+}
+
+func (s *GroupHandlerTestSuite) TestCreateGroup_MissingName() {
+	alice := testdata.Alice
+
+	requestBody := CreateGroupRequest{
+		Name:    "   ",
+		Members: []string{testdata.Bob.UserID},
+	}
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/groups", requestBody, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.CreateGroup(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR")
+}
+
+func (s *GroupHandlerTestSuite) TestCreateGroup_InvalidMemberID() {
+	alice := testdata.Alice
+
+	requestBody := CreateGroupRequest{
+		Name:    "Design review",
+		Members: []string{testdata.Bob.UserID, "messages.>"},
+	}
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/groups", requestBody, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.CreateGroup(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR")
+}
+
+func (s *GroupHandlerTestSuite) TestCreateGroup_NoUserContext() {
+	req := s.helpers.CreateRequestWithoutUser("POST", "/api/v1/groups", CreateGroupRequest{Name: "x"})
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.CreateGroup(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT")
+}
+
+// AddMember Tests
+
+func (s *GroupHandlerTestSuite) TestAddMember_Success() {
+	alice := testdata.Alice
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members"
+
+	s.mockChatRepo.On("IsMember", mock.Anything, group.ID, alice.UserID).Return(true, nil)
+	s.mockChatRepo.On("GetChat", mock.Anything, group.ID).Return(group, nil)
+	s.mockChatRepo.On("AddMember", mock.Anything, mock.MatchedBy(func(member domain.ChatMember) bool {
+		return member.ChatID == group.ID && member.UserID == testdata.Diana.UserID && member.Role == domain.ChatRoleMember
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Member added successfully", "chat_id", group.ID, "user", alice.UserID, "member", testdata.Diana.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("POST", path, AddMemberRequest{UserID: testdata.Diana.UserID}, alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddMember(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response GroupResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.True(response.Group.HasMember(testdata.Diana.UserID))
+}
+
+func (s *GroupHandlerTestSuite) TestAddMember_AlreadyMember() {
+	alice := testdata.Alice
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members"
+
+	s.mockChatRepo.On("IsMember", mock.Anything, group.ID, alice.UserID).Return(true, nil)
+	s.mockChatRepo.On("GetChat", mock.Anything, group.ID).Return(group, nil)
+	s.mockChatRepo.On("AddMember", mock.Anything, mock.Anything).Return(domain.ErrMemberExists)
+
+	req := s.helpers.CreateRequestWithUser("POST", path, AddMemberRequest{UserID: testdata.Charlie.UserID}, alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddMember(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusConflict, "Member already exists", "MEMBER_EXISTS")
+}
+
+func (s *GroupHandlerTestSuite) TestAddMember_NotMember() {
+	eve := testdata.Eve
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members"
+
+	s.mockChatRepo.On("IsMember", mock.Anything, group.ID, eve.UserID).Return(false, nil)
+
+	req := s.helpers.CreateRequestWithUser("POST", path, AddMemberRequest{UserID: eve.UserID}, eve)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddMember(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusForbidden, "Access denied", "ACCESS_DENIED")
+}
+
+func (s *GroupHandlerTestSuite) TestAddMember_NonOwner() {
+	bob := testdata.Bob
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members"
+
+	s.mockChatRepo.On("IsMember", mock.Anything, group.ID, bob.UserID).Return(true, nil)
+	s.mockChatRepo.On("GetChat", mock.Anything, group.ID).Return(group, nil)
+
+	req := s.helpers.CreateRequestWithUser("POST", path, AddMemberRequest{UserID: testdata.Diana.UserID}, bob)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddMember(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("Only group owners can add members", errorResp.Details)
+}
+
+func (s *GroupHandlerTestSuite) TestAddMember_InvalidUserID() {
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members"
+
+	// The ID would become a wildcard in the member's NATS subjects
+	req := s.helpers.CreateRequestWithUser("POST", path, AddMemberRequest{UserID: "*"}, testdata.Alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddMember(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR")
+}
+
+func (s *GroupHandlerTestSuite) TestAddMember_UnknownGroup() {
+	alice := testdata.Alice
+	chatID := domain.NewGroupChatID()
+	path := "/api/v1/groups/" + chatID + "/members"
+
+	// Nobody is a member of a group that does not exist, so outsiders cannot probe for groups
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, alice.UserID).Return(false, nil)
+
+	req := s.helpers.CreateRequestWithUser("POST", path, AddMemberRequest{UserID: testdata.Bob.UserID}, alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddMember(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusForbidden, "Access denied", "ACCESS_DENIED")
+}
+
+func (s *GroupHandlerTestSuite) TestGetGroup_GroupNotFound() {
+	alice := testdata.Alice
+	chatID := domain.NewGroupChatID()
+	path := "/api/v1/groups/" + chatID
+
+	// The group was deleted after the membership check
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, alice.UserID).Return(true, nil)
+	s.mockChatRepo.On("GetChat", mock.Anything, chatID).Return(nil, domain.ErrChatNotFound)
+
+	req := s.helpers.CreateRequestWithUser("GET", path, nil, alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetGroup(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusNotFound, "Group not found", "GROUP_NOT_FOUND")
+}
+
+func (s *GroupHandlerTestSuite) TestGetGroup_MalformedChatID() {
+	path := "/api/v1/groups/not-a-chat"
+
+	req := s.helpers.CreateRequestWithUser("GET", path, nil, testdata.Alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetGroup(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID")
+}
+
+func (s *GroupHandlerTestSuite) TestAddMember_DirectChatID() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+	path := "/api/v1/groups/" + chatID + "/members"
+
+	req := s.helpers.CreateRequestWithUser("POST", path, AddMemberRequest{UserID: testdata.Charlie.UserID}, alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddMember(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID")
+}
+
+// RemoveMember Tests
+
+func (s *GroupHandlerTestSuite) TestRemoveMember_OwnerRemovesMember() {
+	alice := testdata.Alice
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members/" + testdata.Bob.UserID
+
+	s.mockChatRepo.On("IsMember", mock.Anything, group.ID, alice.UserID).Return(true, nil)
+	s.mockChatRepo.On("GetChat", mock.Anything, group.ID).Return(group, nil)
+	s.mockChatRepo.On("RemoveMember", mock.Anything, group.ID, testdata.Bob.UserID).Return(nil)
+	s.mockLogger.On("Debug", "Member removed successfully", "chat_id", group.ID, "user", alice.UserID, "member", testdata.Bob.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("DELETE", path, nil, alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.RemoveMember(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *GroupHandlerTestSuite) TestRemoveMember_MemberLeaves() {
+	charlie := testdata.Charlie
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members/" + charlie.UserID
+
+	s.mockChatRepo.On("IsMember", mock.Anything, group.ID, charlie.UserID).Return(true, nil)
+	s.mockChatRepo.On("GetChat", mock.Anything, group.ID).Return(group, nil)
+	s.mockChatRepo.On("RemoveMember", mock.Anything, group.ID, charlie.UserID).Return(nil)
+	s.mockLogger.On("Debug", "Member removed successfully", "chat_id", group.ID, "user", charlie.UserID, "member", charlie.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("DELETE", path, nil, charlie)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.RemoveMember(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *GroupHandlerTestSuite) TestRemoveMember_NonOwnerCannotRemoveOthers() {
+	bob := testdata.Bob
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members/" + testdata.Charlie.UserID
+
+	s.mockChatRepo.On("IsMember", mock.Anything, group.ID, bob.UserID).Return(true, nil)
+	s.mockChatRepo.On("GetChat", mock.Anything, group.ID).Return(group, nil)
+
+	req := s.helpers.CreateRequestWithUser("DELETE", path, nil, bob)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.RemoveMember(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("Only group owners can remove other members", errorResp.Details)
+}
+
+func (s *GroupHandlerTestSuite) TestRemoveMember_OwnerCannotLeave() {
+	alice := testdata.Alice
+	group := s.sampleGroup()
+	path := "/api/v1/groups/" + group.ID + "/members/" + alice.UserID
+
+	s.mockChatRepo.On("IsMember", mock.Anything, group.ID, alice.UserID).Return(true, nil)
+	s.mockChatRepo.On("GetChat", mock.Anything, group.ID).Return(group, nil)
+
+	req := s.helpers.CreateRequestWithUser("DELETE", path, nil, alice)
+	s.helpers.SetURLPath(req, path)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.RemoveMember(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusConflict, "Owner cannot leave", "OWNER_CANNOT_LEAVE")
+}
+
+func TestGroupHandlerSuite(t *testing.T) {
+	suite.Run(t, new(GroupHandlerTestSuite))
+}
+
+func TestGroupHandler_Creation(t *testing.T) {
+	mockChatRepo := &mocks.ChatRepository{}
+	mockLogger := &mocks.Logger{}
+
+	handler := NewGroupHandler(mockChatRepo, mockLogger)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockChatRepo, handler.ChatRepo)
+	assert.NotNil(t, handler.Authorizer)
+	assert.Equal(t, mockLogger, handler.Logger)
+}
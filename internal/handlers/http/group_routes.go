@@ -0,0 +1,49 @@
+package http
+
+import (
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/ports"
+)
+
+type GroupRoutes struct {
+	chatRepo ports.ChatRepository
+	logger   ports.Logger
+}
+
+func NewGroupRoutes(chatRepo ports.ChatRepository, logger ports.Logger) *GroupRoutes {
+	return &GroupRoutes{
+		chatRepo: chatRepo,
+		logger:   logger,
+	}
+}
+
+func (gr *GroupRoutes) GetRoutes() []httpAdapter.Route {
+	handler := NewGroupHandler(gr.chatRepo, gr.logger)
+
+	return []httpAdapter.Route{
+		{
+			Method:      "POST",
+			Pattern:     "/api/v1/groups",
+			Handler:     handler.CreateGroup,
+			RequireAuth: true,
+		},
+		{
+			Method:      "GET",
+			Pattern:     "/api/v1/groups/{chatId}",
+			Handler:     handler.GetGroup,
+			RequireAuth: true,
+		},
+		{
+			Method:      "POST",
+			Pattern:     "/api/v1/groups/{chatId}/members",
+			Handler:     handler.AddMember,
+			RequireAuth: true,
+		},
+		{
+			Method:      "DELETE",
+			Pattern:     "/api/v1/groups/{chatId}/members/{userId}",
+			Handler:     handler.RemoveMember,
+			RequireAuth: true,
+		},
+	}
+}
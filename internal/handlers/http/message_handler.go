@@ -1,8 +1,11 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -12,17 +15,41 @@ import (
 	"messaging-app/internal/ports"
 )
 
+// MessageConfig limits how long after sending a message can be changed, and how fast messages can be sent
+// A zero window never expires
+type MessageConfig struct {
+	EditWindow    time.Duration
+	DeleteWindow  time.Duration    // Applies to deleting for everyone; deleting for oneself is always allowed
+	SendRateLimit domain.RateLimit // Per sender; zero uses the server default
+}
+
+func DefaultMessageConfig() MessageConfig {
+	return MessageConfig{
+		EditWindow:    15 * time.Minute,
+		DeleteWindow:  time.Hour,
+		SendRateLimit: domain.RateLimit{Requests: 60, Window: time.Minute, Burst: 20},
+	}
+}
+
 // MessageHandler handles message-related requests
 type MessageHandler struct {
+	Config      MessageConfig
 	MessageRepo ports.MessageRepository
-	Publisher   ports.MessagePublisher
+	ChatRepo    ports.ChatRepository
+	Blobs       ports.BlobStore
+	Authorizer  *Authorizer
+	Metrics     ports.Metrics
 	Logger      ports.Logger
 }
 
-func NewMessageHandler(messageRepo ports.MessageRepository, publisher ports.MessagePublisher, logger ports.Logger) *MessageHandler {
+func NewMessageHandler(config MessageConfig, messageRepo ports.MessageRepository, chatRepo ports.ChatRepository, blobs ports.BlobStore, metrics ports.Metrics, logger ports.Logger) *MessageHandler {
 	return &MessageHandler{
+		Config:      config,
 		MessageRepo: messageRepo,
-		Publisher:   publisher,
+		ChatRepo:    chatRepo,
+		Blobs:       blobs,
+		Authorizer:  NewAuthorizer(chatRepo),
+		Metrics:     metrics,
 		Logger:      logger,
 	}
 }
@@ -49,55 +76,178 @@ func (h *MessageHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create message with current timestamp
+	// The Idempotency-Key header takes precedence over client_message_id in the body
+	if key := r.Header.Get(IdempotencyKeyHeader); key != "" {
+		req.ClientMessageID = key
+	}
+
+	message, replayed, herr := h.sendMessage(r.Context(), user, receiverID, req)
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	// A retried request gets the original message back instead of a new one
+	statusCode := http.StatusCreated
+	if replayed {
+		statusCode = http.StatusOK
+	}
+
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(newSendMessageResponse(message))
+
+	h.Logger.Debug("Message sent successfully", "sender", user.UserID, "receiver", receiverID)
+}
+
+// sendMessage validates, stores and publishes a new message; shared by HTTP and WebSocket
+// Reports replayed when the sender's idempotency key matched a message stored earlier
+func (h *MessageHandler) sendMessage(ctx context.Context, user domain.UserContext, receiverID string, req SendMessageRequest) (domain.Message, bool, *handlerError) {
+	// Create message with a server-assigned ID and current timestamp
 	message := domain.Message{
-		SenderID:   user.UserID,
-		ReceiverID: receiverID,
-		CreatedAt:  time.Now().UTC(),
-		Content:    req.Content,
-		Status:     "sent",
+		ID:              domain.NewMessageID(),
+		SenderID:        user.UserID,
+		ReceiverID:      receiverID,
+		CreatedAt:       time.Now().UTC(),
+		Content:         req.Content,
+		Status:          "sent",
+		ClientMessageID: strings.TrimSpace(req.ClientMessageID),
+		Kind:            domain.MessageKindText,
+	}
+
+	// The attachment is resolved first since it lets the content be empty
+	if attachmentID := strings.TrimSpace(req.AttachmentID); attachmentID != "" {
+		attachment, herr := h.messageAttachment(ctx, message, attachmentID)
+		if herr != nil {
+			return message, false, herr
+		}
+		message.Attach(*attachment)
 	}
 
 	// Validate message
 	if err := message.Validate(); err != nil {
 		if domain.IsValidationError(err) {
-			h.writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error())
-		} else {
-			h.writeErrorResponse(w, http.StatusInternalServerError, "Internal error", "INTERNAL_ERROR", err.Error())
+			return message, false, &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error()}
+		}
+		return message, false, &handlerError{http.StatusInternalServerError, "Internal error", "INTERNAL_ERROR", err.Error()}
+	}
+
+	// Only members can post to a group
+	if message.IsGroupMessage() {
+		isMember, err := h.Authorizer.CanAccessChat(ctx, user.UserID, receiverID)
+		if err != nil {
+			h.Logger.Error("Failed to check group membership", "error", err, "chat_id", receiverID, "user", user.UserID)
+			return message, false, &handlerError{http.StatusInternalServerError, "Failed to save message", "SAVE_ERROR", ""}
+		}
+		if !isMember {
+			return message, false, &handlerError{http.StatusForbidden, "Access denied", "ACCESS_DENIED", "User is not a member of this group"}
 		}
-		return
+	}
+
+	// Replies carry a snippet of the quoted message so clients can render it without fetching
+	if replyTo := strings.TrimSpace(req.ReplyTo); replyTo != "" {
+		quote, herr := h.quoteMessage(ctx, message, replyTo)
+		if herr != nil {
+			return message, false, herr
+		}
+		message.ReplyTo = quote
+	}
+
+	// The real-time event is stored with the message and relayed by the outbox worker
+	event, err := domain.NewMessageOutboxEvent(message)
+	if err != nil {
+		h.Logger.Error("Failed to save message", "error", err, "sender", user.UserID, "receiver", receiverID)
+		return message, false, &handlerError{http.StatusInternalServerError, "Failed to save message", "SAVE_ERROR", ""}
 	}
 
 	// Save to database
-	if err := h.MessageRepo.SaveMessage(r.Context(), message); err != nil {
+	if err := h.MessageRepo.SaveMessage(ctx, message, event); err != nil {
+		if err == domain.ErrDuplicateMessage && message.ClientMessageID != "" {
+			return h.replayMessage(ctx, message)
+		}
 		if err == domain.ErrDuplicateMessage {
-			h.writeErrorResponse(w, http.StatusConflict, "Duplicate message", "DUPLICATE_MESSAGE", "Message already exists")
-		} else {
-			h.Logger.Error("Failed to save message", "error", err, "sender", user.UserID, "receiver", receiverID)
-			h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to save message", "SAVE_ERROR", "")
+			return message, false, &handlerError{http.StatusConflict, "Duplicate message", "DUPLICATE_MESSAGE", "Message already exists"}
 		}
-		return
+		if err == domain.ErrAttachmentInUse || err == domain.ErrAttachmentNotFound {
+			return message, false, &handlerError{http.StatusBadRequest, "Invalid attachment", "INVALID_ATTACHMENT", err.Error()}
+		}
+		if err == domain.ErrUserBlocked {
+			return message, false, &handlerError{http.StatusForbidden, "Message blocked", "USER_BLOCKED", err.Error()}
+		}
+		h.Logger.Error("Failed to save message", "error", err, "sender", user.UserID, "receiver", receiverID)
+		return message, false, &handlerError{http.StatusInternalServerError, "Failed to save message", "SAVE_ERROR", ""}
 	}
 
-	// Publish to real-time system
-	if err := h.Publisher.PublishMessage(r.Context(), message); err != nil {
-		h.Logger.Error("Failed to publish message", "error", err, "sender", user.UserID, "receiver", receiverID)
-		// Don't fail the request if publishing fails - message is already saved
+	chatType := domain.ChatTypeDirect
+	if message.IsGroupMessage() {
+		chatType = domain.ChatTypeGroup
 	}
+	h.Metrics.MessageSent(chatType)
 
-	// Return response
-	response := SendMessageResponse{
-		SenderID:   message.SenderID,
-		ReceiverID: message.ReceiverID,
-		CreatedAt:  message.CreatedAt,
-		Content:    message.Content,
-		Status:     message.Status,
+	return message, false, nil
+}
+
+// quoteMessage resolves the message a reply refers to, which must be part of the same chat
+func (h *MessageHandler) quoteMessage(ctx context.Context, message domain.Message, replyToID string) (*domain.Quote, *handlerError) {
+	ref := domain.MessageID{ID: replyToID}
+	if err := ref.Validate(); err != nil {
+		return nil, &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error()}
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(response)
+	quoted, err := h.MessageRepo.GetMessageByID(ctx, ref)
+	if err != nil && err != domain.ErrMessageNotFound {
+		h.Logger.Error("Failed to get quoted message", "error", err, "reply_to", replyToID, "sender", message.SenderID)
+		return nil, &handlerError{http.StatusInternalServerError, "Failed to save message", "SAVE_ERROR", ""}
+	}
 
-	h.Logger.Debug("Message sent successfully", "sender", user.UserID, "receiver", receiverID)
+	// Messages from other chats are treated as missing so they cannot be probed
+	if err == domain.ErrMessageNotFound || quoted.GetChatID() != message.GetChatID() {
+		return nil, &handlerError{http.StatusBadRequest, "Invalid reply", "INVALID_REPLY_TO", "The quoted message is not part of this chat"}
+	}
+	if quoted.IsDeleted() {
+		return nil, &handlerError{http.StatusBadRequest, "Invalid reply", "INVALID_REPLY_TO", "The quoted message was deleted"}
+	}
+
+	return domain.NewQuote(*quoted), nil
+}
+
+// messageAttachment resolves a file the sender uploaded to the chat of message
+func (h *MessageHandler) messageAttachment(ctx context.Context, message domain.Message, attachmentID string) (*domain.Attachment, *handlerError) {
+	if err := domain.ValidateAttachmentID(attachmentID); err != nil {
+		return nil, &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error()}
+	}
+
+	attachment, err := h.MessageRepo.GetAttachment(ctx, attachmentID)
+	if err != nil && err != domain.ErrAttachmentNotFound {
+		h.Logger.Error("Failed to get attachment", "error", err, "attachment_id", attachmentID, "sender", message.SenderID)
+		return nil, &handlerError{http.StatusInternalServerError, "Failed to save message", "SAVE_ERROR", ""}
+	}
+
+	// Files uploaded by others or to other chats are treated as missing so they cannot be probed
+	if err == domain.ErrAttachmentNotFound || attachment.UploaderID != message.SenderID || attachment.ChatID != message.GetChatID() {
+		return nil, &handlerError{http.StatusBadRequest, "Invalid attachment", "INVALID_ATTACHMENT", domain.ErrAttachmentNotFound.Error()}
+	}
+
+	return attachment, nil
+}
+
+// replayMessage returns the message the sender stored earlier under the same idempotency key
+func (h *MessageHandler) replayMessage(ctx context.Context, message domain.Message) (domain.Message, bool, *handlerError) {
+	original, err := h.MessageRepo.GetMessageByClientID(ctx, message.SenderID, message.ClientMessageID)
+	if err == domain.ErrMessageNotFound {
+		return message, false, &handlerError{http.StatusConflict, "Duplicate message", "DUPLICATE_MESSAGE", "Message already exists"}
+	}
+	if err != nil {
+		h.Logger.Error("Failed to get message by client ID", "error", err, "sender", message.SenderID, "client_message_id", message.ClientMessageID)
+		return message, false, &handlerError{http.StatusInternalServerError, "Failed to save message", "SAVE_ERROR", ""}
+	}
+
+	// Reusing a key for a different message is a client bug rather than a retry
+	if original.ReceiverID != message.ReceiverID || original.Content != message.Content || original.ReplyToID() != message.ReplyToID() ||
+		original.AttachmentID() != message.AttachmentID() {
+		return message, false, &handlerError{http.StatusUnprocessableEntity, "Idempotency key reused", "IDEMPOTENCY_KEY_REUSED", "The key was already used for a different message"}
+	}
+
+	return *original, true, nil
 }
 
 // GetMessages handles GET /api/v1/chats/{chatId}/messages
@@ -117,100 +267,357 @@ func (h *MessageHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate user is participant in this chat
-	if !h.isUserParticipant(user.UserID, chatID) {
+	isParticipant, err := h.Authorizer.CanAccessChat(r.Context(), user.UserID, chatID)
+	if errors.Is(err, domain.ErrInvalidChatID) {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID", err.Error())
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Failed to check chat participation", "error", err, "chat_id", chatID, "user", user.UserID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to get messages", "GET_MESSAGES_ERROR", "")
+		return
+	}
+	if !isParticipant {
 		h.writeErrorResponse(w, http.StatusForbidden, "Access denied", "ACCESS_DENIED", "User is not a participant in this chat")
 		return
 	}
 
-	// Parse query parameters
-	cursorStr := r.URL.Query().Get("cursor")
-	limitStr := r.URL.Query().Get("limit")
+	page, herr := parseMessagePage(r.URL.Query())
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+	page.UserID = user.UserID
+
+	// Get messages
+	result, err := h.MessageRepo.GetMessages(r.Context(), chatID, page)
+	if err == domain.ErrMessageNotFound {
+		h.writeErrorResponse(w, http.StatusNotFound, "Message not found", "MESSAGE_NOT_FOUND", "The around anchor is not part of this chat")
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Failed to get messages", "error", err, "chat_id", chatID, "user", user.UserID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to get messages", "GET_MESSAGES_ERROR", "")
+		return
+	}
 
-	var cursor time.Time
-	if cursorStr != "" {
-		var err error
-		cursor, err = time.Parse(time.RFC3339, cursorStr)
-		if err != nil {
-			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid cursor format", "INVALID_CURSOR", "Cursor must be RFC3339 formatted timestamp")
-			return
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(GetMessagesResponse(result))
+
+	h.Logger.Debug("Messages retrieved successfully", "chat_id", chatID, "user", user.UserID, "count", len(result.Messages))
+}
+
+// parseMessagePage reads the history window from the query string
+// At most one of before, after and around may be given; cursor is the legacy name of before
+// and also accepts an RFC3339 timestamp
+func parseMessagePage(query url.Values) (domain.MessagePage, *handlerError) {
+	page := domain.MessagePage{Direction: domain.PageBefore, Limit: 50} // Default limit
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			return page, &handlerError{http.StatusBadRequest, "Invalid limit", "INVALID_LIMIT", "Limit must be between 1 and 100"}
 		}
+		page.Limit = limit
 	}
 
-	limit := 50 // Default limit
-	if limitStr != "" {
-		var err error
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil || limit < 1 || limit > 100 {
-			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid limit", "INVALID_LIMIT", "Limit must be between 1 and 100")
-			return
+	var cursorStr string
+	given := 0
+	for _, direction := range []string{"cursor", domain.PageBefore, domain.PageAfter, domain.PageAround} {
+		if value := query.Get(direction); value != "" {
+			given++
+			cursorStr = value
+			if direction != "cursor" {
+				page.Direction = direction
+			}
 		}
 	}
+	if given > 1 {
+		return page, &handlerError{http.StatusBadRequest, "Invalid cursor format", "INVALID_CURSOR", "Only one of cursor, before, after and around can be given"}
+	}
+	if given == 0 {
+		return page, nil
+	}
 
-	// Get messages
-	messages, err := h.MessageRepo.GetMessages(r.Context(), chatID, cursor, limit)
+	// Around is anchored on a message ID rather than a cursor
+	if page.Direction == domain.PageAround {
+		if err := (domain.MessageID{ID: cursorStr}).Validate(); err != nil {
+			return page, &handlerError{http.StatusBadRequest, "Invalid cursor format", "INVALID_CURSOR", "around must be a message ID"}
+		}
+		page.Cursor = domain.MessageCursor{ID: cursorStr}
+		return page, nil
+	}
+
+	cursor, err := domain.ParseMessageCursor(cursorStr)
+	if err != nil && query.Get("cursor") != "" {
+		// Legacy clients pass the timestamp of the oldest message they have
+		var createdAt time.Time
+		createdAt, err = time.Parse(time.RFC3339Nano, cursorStr)
+		cursor = domain.MessageCursor{CreatedAt: createdAt}
+	}
 	if err != nil {
-		h.Logger.Error("Failed to get messages", "error", err, "chat_id", chatID, "user", user.UserID)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to get messages", "GET_MESSAGES_ERROR", "")
+		return page, &handlerError{http.StatusBadRequest, "Invalid cursor format", "INVALID_CURSOR", "Cursor must be a cursor returned by a previous page"}
+	}
+	page.Cursor = cursor
+	return page, nil
+}
+
+// EditMessage handles PATCH /api/v1/messages/{messageId}
+func (h *MessageHandler) EditMessage(w http.ResponseWriter, r *http.Request) {
+	// Extract messageId from path: /api/v1/messages/{messageId}
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing message ID", "MISSING_MESSAGE_ID", "messageId path parameter is required")
+		return
+	}
+	messageID := pathParts[3]
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
 		return
 	}
 
-	// Build response
-	response := GetMessagesResponse{
-		Messages: messages,
-		HasMore:  len(messages) == limit,
+	var req EditMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON", err.Error())
+		return
 	}
 
-	// Set next cursor if there are more messages
-	if response.HasMore && len(messages) > 0 {
-		lastMessage := messages[len(messages)-1]
-		response.NextCursor = lastMessage.CreatedAt.Format(time.RFC3339)
+	message, herr := h.editMessage(r.Context(), user, messageID, req)
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(newSendMessageResponse(message))
 
-	h.Logger.Debug("Messages retrieved successfully", "chat_id", chatID, "user", user.UserID, "count", len(messages))
+	h.Logger.Debug("Message edited successfully", "message_id", messageID, "user", user.UserID)
 }
 
-// UpdateMessageStatus handles PATCH /api/v1/messages/status
-func (h *MessageHandler) UpdateMessageStatus(w http.ResponseWriter, r *http.Request) {
+// DeleteMessage handles DELETE /api/v1/messages/{messageId}?scope=me|everyone
+func (h *MessageHandler) DeleteMessage(w http.ResponseWriter, r *http.Request) {
+	// Extract messageId from path: /api/v1/messages/{messageId}
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing message ID", "MISSING_MESSAGE_ID", "messageId path parameter is required")
+		return
+	}
+	messageID := pathParts[3]
+
 	user, ok := httpAdapter.GetUserFromContext(r.Context())
 	if !ok {
 		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
 		return
 	}
 
-	var req UpdateStatusRequest
+	scope := r.URL.Query().Get("scope")
+	if herr := h.deleteMessage(r.Context(), user, messageID, scope); herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.Logger.Debug("Message deleted successfully", "message_id", messageID, "user", user.UserID, "scope", scope)
+}
+
+// AddReaction handles POST /api/v1/messages/{messageId}/reactions
+func (h *MessageHandler) AddReaction(w http.ResponseWriter, r *http.Request) {
+	// Extract messageId from path: /api/v1/messages/{messageId}/reactions
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing message ID", "MISSING_MESSAGE_ID", "messageId path parameter is required")
+		return
+	}
+	messageID := pathParts[3]
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	var req AddReactionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON", err.Error())
 		return
 	}
 
-	if req.MessageID.ReceiverID != user.UserID {
-		h.writeErrorResponse(w, http.StatusForbidden, "Access denied", "ACCESS_DENIED", "Can only update status of messages you received")
+	reaction, herr := h.addReaction(r.Context(), user, messageID, req)
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
 		return
 	}
 
-	// Update status
-	affected, err := h.MessageRepo.MarkMessagesUpToRead(r.Context(), req.MessageID)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ReactionResponse(reaction))
 
+	h.Logger.Debug("Reaction added successfully", "message_id", messageID, "user", user.UserID)
+}
+
+// RemoveReaction handles DELETE /api/v1/messages/{messageId}/reactions
+func (h *MessageHandler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	// Extract messageId from path: /api/v1/messages/{messageId}/reactions
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing message ID", "MISSING_MESSAGE_ID", "messageId path parameter is required")
+		return
+	}
+	messageID := pathParts[3]
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	if herr := h.removeReaction(r.Context(), user, messageID); herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.Logger.Debug("Reaction removed successfully", "message_id", messageID, "user", user.UserID)
+}
+
+// editMessage replaces the content of a message the user sent and announces the change;
+// shared by HTTP and WebSocket
+func (h *MessageHandler) editMessage(ctx context.Context, user domain.UserContext, messageID string, req EditMessageRequest) (domain.Message, *handlerError) {
+	original, herr := h.getOwnMessage(ctx, user, messageID, "Can only edit messages you sent")
+	if herr != nil {
+		return domain.Message{}, herr
+	}
+
+	now := time.Now().UTC()
+	if !original.IsWithinWindow(h.Config.EditWindow, now) {
+		return domain.Message{}, &handlerError{http.StatusForbidden, "Edit window expired", "EDIT_WINDOW_EXPIRED", "The message is too old to be edited"}
+	}
+
+	edited, err := original.Edit(req.Content, now)
+	if err == domain.ErrMessageDeleted {
+		return domain.Message{}, &handlerError{http.StatusGone, "Message deleted", "MESSAGE_DELETED", ""}
+	}
 	if err != nil {
-		h.Logger.Error("Failed to update message status", "error", err, "user", user.UserID, "message_id", req.MessageID)
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to update status", "UPDATE_STATUS_ERROR", "")
+		return domain.Message{}, &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error()}
+	}
+
+	// The edit is stored with the change and relayed by the outbox worker
+	events, err := domain.NewMessageUpdateOutboxEvents(domain.MessageTypeMessageEdited, edited)
+	if err != nil {
+		h.Logger.Error("Failed to edit message", "error", err, "message_id", messageID, "user", user.UserID)
+		return domain.Message{}, &handlerError{http.StatusInternalServerError, "Failed to edit message", "EDIT_MESSAGE_ERROR", ""}
+	}
+
+	switch err := h.MessageRepo.EditMessage(ctx, edited, events...); err {
+	case nil:
+		return edited, nil
+	case domain.ErrMessageNotFound:
+		return domain.Message{}, &handlerError{http.StatusNotFound, "Message not found", "MESSAGE_NOT_FOUND", ""}
+	case domain.ErrMessageDeleted:
+		return domain.Message{}, &handlerError{http.StatusGone, "Message deleted", "MESSAGE_DELETED", ""}
+	default:
+		h.Logger.Error("Failed to edit message", "error", err, "message_id", messageID, "user", user.UserID)
+		return domain.Message{}, &handlerError{http.StatusInternalServerError, "Failed to edit message", "EDIT_MESSAGE_ERROR", ""}
+	}
+}
+
+// deleteMessage deletes a message for everyone or only for the user and announces the change;
+// shared by HTTP and WebSocket
+func (h *MessageHandler) deleteMessage(ctx context.Context, user domain.UserContext, messageID, scope string) *handlerError {
+	if scope == "" {
+		scope = domain.DeleteForMe
+	}
+	if !domain.IsValidDeleteScope(scope) {
+		return &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", domain.ErrInvalidDeleteScope.Error()}
+	}
+
+	now := time.Now().UTC()
+	var err error
+
+	if scope == domain.DeleteForEveryone {
+		original, herr := h.getOwnMessage(ctx, user, messageID, "Can only delete messages you sent for everyone")
+		if herr != nil {
+			return herr
+		}
+		if original.IsDeleted() {
+			return &handlerError{http.StatusGone, "Message deleted", "MESSAGE_DELETED", ""}
+		}
+		if !original.IsWithinWindow(h.Config.DeleteWindow, now) {
+			return &handlerError{http.StatusForbidden, "Delete window expired", "DELETE_WINDOW_EXPIRED", "The message is too old to be deleted for everyone"}
+		}
+
+		// Everyone in the chat sees the tombstone
+		events, eventErr := domain.NewMessageUpdateOutboxEvents(domain.MessageTypeMessageDeleted, original.Tombstone(now))
+		if eventErr != nil {
+			h.Logger.Error("Failed to delete message", "error", eventErr, "message_id", messageID, "user", user.UserID)
+			return &handlerError{http.StatusInternalServerError, "Failed to delete message", "DELETE_MESSAGE_ERROR", ""}
+		}
+		err = h.MessageRepo.DeleteMessage(ctx, messageID, now, events...)
+
+		// The attachment row went with the message; its content is only removed once that committed
+		if err == nil && original.Attachment != nil {
+			if blobErr := h.Blobs.Delete(ctx, original.AttachmentID()); blobErr != nil {
+				h.Logger.Error("Failed to delete attachment content", "error", blobErr, "attachment_id", original.AttachmentID())
+			}
+		}
+	} else {
+		original, herr := h.getChatMessage(ctx, user, messageID)
+		if herr != nil {
+			return herr
+		}
+
+		// Only the user's own devices drop the message
+		event, eventErr := domain.NewOutboxEvent(domain.MessageTypeMessageDeleted, user.UserID, original.Tombstone(now))
+		if eventErr != nil {
+			h.Logger.Error("Failed to delete message", "error", eventErr, "message_id", messageID, "user", user.UserID)
+			return &handlerError{http.StatusInternalServerError, "Failed to delete message", "DELETE_MESSAGE_ERROR", ""}
+		}
+		err = h.MessageRepo.HideMessage(ctx, messageID, user.UserID, now, event)
+	}
+
+	switch err {
+	case nil:
+		return nil
+	case domain.ErrMessageNotFound:
+		return &handlerError{http.StatusNotFound, "Message not found", "MESSAGE_NOT_FOUND", ""}
+	case domain.ErrMessageDeleted:
+		return &handlerError{http.StatusGone, "Message deleted", "MESSAGE_DELETED", ""}
+	default:
+		h.Logger.Error("Failed to delete message", "error", err, "message_id", messageID, "user", user.UserID, "scope", scope)
+		return &handlerError{http.StatusInternalServerError, "Failed to delete message", "DELETE_MESSAGE_ERROR", ""}
+	}
+}
+
+// UpdateMessageStatus handles PATCH /api/v1/messages/status
+func (h *MessageHandler) UpdateMessageStatus(w http.ResponseWriter, r *http.Request) {
+	h.handleStatusUpdate(w, r, domain.MessageStatusRead)
+}
+
+// MarkMessagesDelivered handles PATCH /api/v1/messages/delivered
+func (h *MessageHandler) MarkMessagesDelivered(w http.ResponseWriter, r *http.Request) {
+	h.handleStatusUpdate(w, r, domain.MessageStatusDelivered)
+}
+
+func (h *MessageHandler) handleStatusUpdate(w http.ResponseWriter, r *http.Request, status string) {
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
 		return
 	}
 
-	// Publish status update
-	statusUpdate := ports.StatusUpdate{
-		MessageID: req.MessageID,
-		Status:    domain.MessageStatusRead,
-		UpdatedBy: user.UserID,
-		UpdatedAt: time.Now().UTC(),
+	var req UpdateStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON", err.Error())
+		return
 	}
 
-	if err := h.Publisher.PublishStatusUpdate(r.Context(), user.UserID, statusUpdate); err != nil {
-		h.Logger.Error("Failed to publish status update", "error", err, "user", user.UserID)
-		// Don't fail the request if publishing fails - status is already updated
+	affected, herr := h.updateMessageStatus(r.Context(), user, req, status)
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
 	}
 
 	response := UpdateStatusResponse{
@@ -220,13 +627,221 @@ func (h *MessageHandler) UpdateMessageStatus(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 
-	h.Logger.Debug("Message status updated successfully", "user", user.UserID, "count", affected, "status", domain.MessageStatusRead)
+	h.Logger.Debug("Message status updated successfully", "user", user.UserID, "count", affected, "status", status)
+}
+
+// updateMessageStatus moves messages up to the given one to status and publishes the change;
+// shared by HTTP and WebSocket for both read and delivered acknowledgements
+func (h *MessageHandler) updateMessageStatus(ctx context.Context, user domain.UserContext, req UpdateStatusRequest, status string) (int64, *handlerError) {
+	// The stored message anchors the update, so clients cannot choose its chat, sender or time
+	anchor, herr := h.loadStatusAnchor(ctx, req.MessageID)
+	if herr != nil {
+		return 0, herr
+	}
+	req.MessageID = anchor.Key()
+
+	isGroup := anchor.IsGroupMessage()
+	if anchor.SenderID == user.UserID || (!isGroup && anchor.ReceiverID != user.UserID) {
+		return 0, &handlerError{http.StatusForbidden, "Access denied", "ACCESS_DENIED", "Can only update status of messages you received"}
+	}
+
+	// The status update is stored with the status change and relayed by the outbox worker
+	events := statusEvents(user, req.MessageID, status)
+
+	// Update status
+	var affected int64
+	var err error
+	switch {
+	case isGroup && status == domain.MessageStatusDelivered:
+		// Group delivery and read state is tracked per member rather than on the message row
+		affected, err = h.ChatRepo.MarkDeliveredUpTo(ctx, req.MessageID.ReceiverID, user.UserID, req.MessageID.CreatedAt, events)
+	case isGroup:
+		affected, err = h.ChatRepo.MarkReadUpTo(ctx, req.MessageID.ReceiverID, user.UserID, req.MessageID.CreatedAt, events)
+	case status == domain.MessageStatusDelivered:
+		affected, err = h.MessageRepo.MarkMessagesUpToDelivered(ctx, req.MessageID, events)
+	default:
+		affected, err = h.MessageRepo.MarkMessagesUpToRead(ctx, req.MessageID, events)
+	}
+
+	if err == domain.ErrNotChatMember {
+		return 0, &handlerError{http.StatusForbidden, "Access denied", "ACCESS_DENIED", "User is not a member of this group"}
+	}
+	if err != nil {
+		h.Logger.Error("Failed to update message status", "error", err, "user", user.UserID, "message_id", req.MessageID)
+		return 0, &handlerError{http.StatusInternalServerError, "Failed to update status", "UPDATE_STATUS_ERROR", ""}
+	}
+
+	if status == domain.MessageStatusRead {
+		h.Metrics.MessagesRead(affected)
+	}
+
+	return affected, nil
 }
 
 // Helper methods
 
-func (h *MessageHandler) isUserParticipant(userID, chatID string) bool {
-	return strings.Contains(chatID, userID)
+// loadStatusAnchor loads the message a status update refers to, given by ID or by composite key
+func (h *MessageHandler) loadStatusAnchor(ctx context.Context, messageID domain.MessageID) (*domain.Message, *handlerError) {
+	if err := messageID.Validate(); err != nil {
+		return nil, &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error()}
+	}
+
+	message, err := h.MessageRepo.GetMessageByID(ctx, messageID)
+	if err == domain.ErrMessageNotFound {
+		return nil, &handlerError{http.StatusNotFound, "Message not found", "MESSAGE_NOT_FOUND", ""}
+	}
+	if err != nil {
+		h.Logger.Error("Failed to get message", "error", err, "message_id", messageID)
+		return nil, &handlerError{http.StatusInternalServerError, "Failed to update status", "UPDATE_STATUS_ERROR", ""}
+	}
+
+	return message, nil
+}
+
+// addReaction sets the user's reaction to a message and announces it to the chat;
+// shared by HTTP and WebSocket
+func (h *MessageHandler) addReaction(ctx context.Context, user domain.UserContext, messageID string, req AddReactionRequest) (domain.Reaction, *handlerError) {
+	message, herr := h.getChatMessage(ctx, user, messageID)
+	if herr != nil {
+		return domain.Reaction{}, herr
+	}
+	if message.IsDeleted() {
+		return domain.Reaction{}, &handlerError{http.StatusGone, "Message deleted", "MESSAGE_DELETED", ""}
+	}
+
+	now := time.Now().UTC()
+	reaction := domain.Reaction{
+		MessageID: message.ID,
+		UserID:    user.UserID,
+		Emoji:     req.Emoji,
+		CreatedAt: now,
+	}
+	if err := reaction.Validate(); err != nil {
+		return domain.Reaction{}, &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error()}
+	}
+
+	events, err := domain.NewReactionOutboxEvents(*message, domain.ReactionUpdate{
+		MessageID: message.ID,
+		ChatID:    message.GetChatID(),
+		UserID:    user.UserID,
+		Emoji:     reaction.Emoji,
+		Action:    domain.ReactionAdded,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		h.Logger.Error("Failed to add reaction", "error", err, "message_id", messageID, "user", user.UserID)
+		return domain.Reaction{}, &handlerError{http.StatusInternalServerError, "Failed to add reaction", "ADD_REACTION_ERROR", ""}
+	}
+
+	switch err := h.MessageRepo.AddReaction(ctx, reaction, events...); err {
+	case nil:
+		return reaction, nil
+	case domain.ErrMessageNotFound:
+		return domain.Reaction{}, &handlerError{http.StatusNotFound, "Message not found", "MESSAGE_NOT_FOUND", ""}
+	default:
+		h.Logger.Error("Failed to add reaction", "error", err, "message_id", messageID, "user", user.UserID)
+		return domain.Reaction{}, &handlerError{http.StatusInternalServerError, "Failed to add reaction", "ADD_REACTION_ERROR", ""}
+	}
+}
+
+// removeReaction removes the user's reaction to a message and announces it to the chat;
+// shared by HTTP and WebSocket
+func (h *MessageHandler) removeReaction(ctx context.Context, user domain.UserContext, messageID string) *handlerError {
+	message, herr := h.getChatMessage(ctx, user, messageID)
+	if herr != nil {
+		return herr
+	}
+
+	events, err := domain.NewReactionOutboxEvents(*message, domain.ReactionUpdate{
+		MessageID: message.ID,
+		ChatID:    message.GetChatID(),
+		UserID:    user.UserID,
+		Action:    domain.ReactionRemoved,
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		h.Logger.Error("Failed to remove reaction", "error", err, "message_id", messageID, "user", user.UserID)
+		return &handlerError{http.StatusInternalServerError, "Failed to remove reaction", "REMOVE_REACTION_ERROR", ""}
+	}
+
+	if err := h.MessageRepo.RemoveReaction(ctx, message.ID, user.UserID, events...); err != nil {
+		h.Logger.Error("Failed to remove reaction", "error", err, "message_id", messageID, "user", user.UserID)
+		return &handlerError{http.StatusInternalServerError, "Failed to remove reaction", "REMOVE_REACTION_ERROR", ""}
+	}
+	return nil
+}
+
+// getChatMessage loads a message from a chat the user takes part in
+func (h *MessageHandler) getChatMessage(ctx context.Context, user domain.UserContext, messageID string) (*domain.Message, *handlerError) {
+	ref := domain.MessageID{ID: messageID}
+	if err := ref.Validate(); err != nil {
+		return nil, &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error()}
+	}
+
+	message, err := h.MessageRepo.GetMessageByID(ctx, ref)
+	if err == domain.ErrMessageNotFound {
+		return nil, &handlerError{http.StatusNotFound, "Message not found", "MESSAGE_NOT_FOUND", ""}
+	}
+	if err != nil {
+		h.Logger.Error("Failed to get message", "error", err, "message_id", messageID)
+		return nil, &handlerError{http.StatusInternalServerError, "Failed to get message", "GET_MESSAGE_ERROR", ""}
+	}
+
+	// Outsiders get the same answer as for a missing message
+	isParticipant, err := h.Authorizer.CanAccessMessage(ctx, user.UserID, *message)
+	if err != nil {
+		h.Logger.Error("Failed to check chat participation", "error", err, "chat_id", message.ReceiverID, "user", user.UserID)
+		return nil, &handlerError{http.StatusInternalServerError, "Failed to get message", "GET_MESSAGE_ERROR", ""}
+	}
+	if !isParticipant {
+		return nil, &handlerError{http.StatusNotFound, "Message not found", "MESSAGE_NOT_FOUND", ""}
+	}
+
+	return message, nil
+}
+
+// getOwnMessage loads a message the user sent to a chat they still take part in
+func (h *MessageHandler) getOwnMessage(ctx context.Context, user domain.UserContext, messageID, details string) (*domain.Message, *handlerError) {
+	message, herr := h.getChatMessage(ctx, user, messageID)
+	if herr != nil {
+		return nil, herr
+	}
+	if message.SenderID != user.UserID {
+		return nil, &handlerError{http.StatusForbidden, "Access denied", "ACCESS_DENIED", details}
+	}
+	return message, nil
+}
+
+// statusEvents builds the receipts for a status change once the affected messages are known
+// Receipts go to the senders of the affected messages; read receipts also reach the reader's other devices
+func statusEvents(user domain.UserContext, messageID domain.MessageID, status string) domain.OutboxEventsFunc {
+	return func(affected domain.MessageRange) ([]domain.OutboxEvent, error) {
+		recipients := []string{messageID.SenderID}
+		if domain.IsGroupChatID(messageID.ReceiverID) {
+			recipients = append([]string(nil), affected.Senders...)
+		}
+		if status == domain.MessageStatusRead {
+			recipients = append(recipients, user.UserID)
+		}
+
+		statusUpdate := ports.StatusUpdate{
+			MessageID: messageID,
+			Status:    status,
+			UpdatedBy: user.UserID,
+			UpdatedAt: time.Now().UTC(),
+			Range:     &affected,
+		}
+
+		events := make([]domain.OutboxEvent, 0, len(recipients))
+		for _, recipient := range recipients {
+			event, err := ports.NewStatusOutboxEvent(recipient, statusUpdate)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+		return events, nil
+	}
 }
 
 func (h *MessageHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message, code, details string) {
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,22 +23,28 @@ import (
 
 type MessageHandlerTestSuite struct {
 	suite.Suite
-	handler       *MessageHandler
-	mockRepo      *mocks.MessageRepository
-	mockPublisher *mocks.MessagePublisher
-	mockLogger    *mocks.Logger
+	handler      *MessageHandler
+	mockRepo     *mocks.MessageRepository
+	mockChatRepo *mocks.ChatRepository
+	mockBlobs    *mocks.BlobStore
+	mockMetrics  *mocks.Metrics
+	mockLogger   *mocks.Logger
 }
 
 func (s *MessageHandlerTestSuite) SetupTest() {
 	s.mockRepo = &mocks.MessageRepository{}
-	s.mockPublisher = &mocks.MessagePublisher{}
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.mockBlobs = &mocks.BlobStore{}
+	s.mockMetrics = &mocks.Metrics{}
 	s.mockLogger = &mocks.Logger{}
-	s.handler = NewMessageHandler(s.mockRepo, s.mockPublisher, s.mockLogger)
+	s.handler = NewMessageHandler(DefaultMessageConfig(), s.mockRepo, s.mockChatRepo, s.mockBlobs, s.mockMetrics, s.mockLogger)
 }
 
 func (s *MessageHandlerTestSuite) TearDownTest() {
 	s.mockRepo.AssertExpectations(s.T())
-	s.mockPublisher.AssertExpectations(s.T())
+	s.mockChatRepo.AssertExpectations(s.T())
+	s.mockBlobs.AssertExpectations(s.T())
+	s.mockMetrics.AssertExpectations(s.T())
 	s.mockLogger.AssertExpectations(s.T())
 }
 
@@ -91,13 +98,11 @@ func (s *MessageHandlerTestSuite) TestSendMessage_Success() {
 			msg.ReceiverID == expectedMessage.ReceiverID &&
 			msg.Content == expectedMessage.Content &&
 			msg.Status == expectedMessage.Status
+	}), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		return event.EventType == domain.MessageTypeNewMessage &&
+			event.Recipient == bob.UserID
 	})).Return(nil)
-
-	s.mockPublisher.On("PublishMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
-		return msg.SenderID == expectedMessage.SenderID &&
-			msg.ReceiverID == expectedMessage.ReceiverID &&
-			msg.Content == expectedMessage.Content
-	})).Return(nil)
+	s.mockMetrics.On("MessageSent", domain.ChatTypeDirect).Once()
 
 	s.mockLogger.On("Debug", "Message sent successfully", "sender", alice.UserID, "receiver", bob.UserID).Return()
 
@@ -124,6 +129,7 @@ func (s *MessageHandlerTestSuite) TestSendMessage_Success() {
 	s.Equal(requestBody.Content, response.Content)
 	s.Equal("sent", response.Status)
 	s.WithinDuration(time.Now(), response.CreatedAt, 5*time.Second)
+	s.NoError(domain.MessageID{ID: response.ID}.Validate())
 }
 
 func (s *MessageHandlerTestSuite) TestSendMessage_NoUserContext() {
@@ -251,7 +257,7 @@ func (s *MessageHandlerTestSuite) TestSendMessage_DuplicateMessage() {
 	}
 
 	// Mock expectations - repository returns duplicate error
-	s.mockRepo.On("SaveMessage", mock.Anything, mock.Anything).Return(domain.ErrDuplicateMessage)
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrDuplicateMessage)
 
 	req := s.createRequestWithUser("POST", "/api/v1/chats/"+bob.UserID+"/messages", requestBody, alice)
 	req.URL.Path = "/api/v1/chats/" + bob.UserID + "/messages"
@@ -270,7 +276,7 @@ func (s *MessageHandlerTestSuite) TestSendMessage_DuplicateMessage() {
 	s.Equal("DUPLICATE_MESSAGE", errorResp.Code)
 }
 
-func (s *MessageHandlerTestSuite) TestSendMessage_RepositoryError() {
+func (s *MessageHandlerTestSuite) TestSendMessage_Blocked() {
 	alice := testdata.Alice
 	bob := testdata.Bob
 
@@ -278,54 +284,285 @@ func (s *MessageHandlerTestSuite) TestSendMessage_RepositoryError() {
 		Content: "Hello Bob!",
 	}
 
-	// Mock expectations - repository returns generic error
-	repoError := assert.AnError
-	s.mockRepo.On("SaveMessage", mock.Anything, mock.Anything).Return(repoError)
-	s.mockLogger.On("Error", "Failed to save message", "error", repoError, "sender", alice.UserID, "receiver", bob.UserID).Return()
+	// Mock expectations - the receiver blocked the sender, so nothing is stored or published
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrUserBlocked)
 
 	req := s.createRequestWithUser("POST", "/api/v1/chats/"+bob.UserID+"/messages", requestBody, alice)
-	req.URL.Path = "/api/v1/chats/" + bob.UserID + "/messages"
 	recorder := httptest.NewRecorder()
 
 	// Execute
 	s.handler.SendMessage(recorder, req)
 
 	// Assertions
-	s.Equal(http.StatusInternalServerError, recorder.Code)
+	s.Equal(http.StatusForbidden, recorder.Code)
 
 	var errorResp httpAdapter.ErrorResponse
 	err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
 	s.NoError(err)
-	s.Equal("Failed to save message", errorResp.Error)
-	s.Equal("SAVE_ERROR", errorResp.Code)
+	s.Equal("USER_BLOCKED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_IdempotentReplay() {
+	alice := testdata.Alice
+	bob := testdata.Bob
+
+	original := domain.Message{
+		ID:              domain.NewMessageID(),
+		SenderID:        alice.UserID,
+		ReceiverID:      bob.UserID,
+		CreatedAt:       testdata.BaseTime,
+		Content:         "Hello Bob!",
+		Status:          domain.MessageStatusSent,
+		ClientMessageID: "retry-1",
+	}
+
+	// The retried insert hits the per-sender key and the original is returned
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+		return msg.ClientMessageID == "retry-1"
+	}), mock.Anything).Return(domain.ErrDuplicateMessage)
+	s.mockRepo.On("GetMessageByClientID", mock.Anything, alice.UserID, "retry-1").Return(&original, nil)
+	s.mockLogger.On("Debug", "Message sent successfully", "sender", alice.UserID, "receiver", bob.UserID).Return()
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+bob.UserID+"/messages", SendMessageRequest{Content: "Hello Bob!"}, alice)
+	req.URL.Path = "/api/v1/chats/" + bob.UserID + "/messages"
+	req.Header.Set(IdempotencyKeyHeader, "retry-1")
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response SendMessageResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal(original.ID, response.ID)
+	s.True(original.CreatedAt.Equal(response.CreatedAt))
+	s.Equal("retry-1", response.ClientMessageID)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_IdempotencyKeyReused() {
+	alice := testdata.Alice
+	bob := testdata.Bob
+
+	original := domain.Message{
+		ID:              domain.NewMessageID(),
+		SenderID:        alice.UserID,
+		ReceiverID:      bob.UserID,
+		CreatedAt:       testdata.BaseTime,
+		Content:         "Something else",
+		Status:          domain.MessageStatusSent,
+		ClientMessageID: "retry-1",
+	}
+
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrDuplicateMessage)
+	s.mockRepo.On("GetMessageByClientID", mock.Anything, alice.UserID, "retry-1").Return(&original, nil)
+
+	requestBody := SendMessageRequest{Content: "Hello Bob!", ClientMessageID: "retry-1"}
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+bob.UserID+"/messages", requestBody, alice)
+	req.URL.Path = "/api/v1/chats/" + bob.UserID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+	var errorResp httpAdapter.ErrorResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("IDEMPOTENCY_KEY_REUSED", errorResp.Code)
 }
 
-func (s *MessageHandlerTestSuite) TestSendMessage_PublisherError() {
-	// Should still succeed even if publisher fails
+func (s *MessageHandlerTestSuite) TestSendMessage_Reply() {
 	alice := testdata.Alice
 	bob := testdata.Bob
+	quoted := recentMessage() // Alice to Bob
+	quoted.Content = strings.Repeat("a", domain.QuoteSnippetLength+10)
 
 	requestBody := SendMessageRequest{
-		Content: "Hello Bob!",
+		Content: "Agreed",
+		ReplyTo: quoted.ID,
 	}
 
-	// Mock expectations
-	s.mockRepo.On("SaveMessage", mock.Anything, mock.Anything).Return(nil)
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: quoted.ID}).Return(&quoted, nil)
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+		return msg.ReplyToID() == quoted.ID
+	}), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		// The envelope carries the quote so clients need no extra fetch
+		var message domain.Message
+		return json.Unmarshal(event.Payload, &message) == nil &&
+			message.ReplyTo != nil &&
+			message.ReplyTo.SenderID == alice.UserID &&
+			len(message.ReplyTo.Snippet) == domain.QuoteSnippetLength
+	})).Return(nil)
+	s.mockMetrics.On("MessageSent", domain.ChatTypeDirect).Once()
+	s.mockLogger.On("Debug", "Message sent successfully", "sender", bob.UserID, "receiver", alice.UserID).Return()
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+alice.UserID+"/messages", requestBody, bob)
+	req.URL.Path = "/api/v1/chats/" + alice.UserID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusCreated, recorder.Code)
 
-	publishError := assert.AnError
-	s.mockPublisher.On("PublishMessage", mock.Anything, mock.Anything).Return(publishError)
-	s.mockLogger.On("Error", "Failed to publish message", "error", publishError, "sender", alice.UserID, "receiver", bob.UserID).Return()
+	var response SendMessageResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Require().NotNil(response.ReplyTo)
+	s.Equal(quoted.ID, response.ReplyTo.ID)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_ReplyFromOtherChat() {
+	quoted := recentMessage() // Alice to Bob
+
+	requestBody := SendMessageRequest{
+		Content: "Wrong thread",
+		ReplyTo: quoted.ID,
+	}
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: quoted.ID}).Return(&quoted, nil)
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+testdata.Charlie.UserID+"/messages", requestBody, testdata.Alice)
+	req.URL.Path = "/api/v1/chats/" + testdata.Charlie.UserID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("INVALID_REPLY_TO", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_ReplyNotFound() {
+	replyTo := domain.MessageID{ID: domain.NewMessageID()}
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, replyTo).Return(nil, domain.ErrMessageNotFound)
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+testdata.Bob.UserID+"/messages", SendMessageRequest{Content: "Hm?", ReplyTo: replyTo.ID}, testdata.Alice)
+	req.URL.Path = "/api/v1/chats/" + testdata.Bob.UserID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("INVALID_REPLY_TO", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_Attachment() {
+	alice := testdata.Alice
+	bob := testdata.Bob
+	attachment := domain.NewAttachment(alice.UserID, domain.ComputeChatID(alice.UserID, bob.UserID), "photo.png")
+	attachment.MimeType = "image/png"
+	attachment.Size = 1024
+	attachment.Checksum = strings.Repeat("ab", 32)
+
+	s.mockRepo.On("GetAttachment", mock.Anything, attachment.ID).Return(&attachment, nil)
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+		return msg.Kind == domain.MessageKindImage && msg.AttachmentID() == attachment.ID && msg.Content == ""
+	}), mock.Anything).Return(nil)
+	s.mockMetrics.On("MessageSent", domain.ChatTypeDirect).Once()
 	s.mockLogger.On("Debug", "Message sent successfully", "sender", alice.UserID, "receiver", bob.UserID).Return()
 
-	req := s.createRequestWithUser("POST", "/api/v1/chats/"+bob.UserID+"/messages", requestBody, alice)
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+bob.UserID+"/messages", SendMessageRequest{AttachmentID: attachment.ID}, alice)
 	req.URL.Path = "/api/v1/chats/" + bob.UserID + "/messages"
 	recorder := httptest.NewRecorder()
 
 	// Execute
 	s.handler.SendMessage(recorder, req)
 
-	// Assertions - should still return 201 even if publishing fails
+	// Assertions
 	s.Equal(http.StatusCreated, recorder.Code)
+
+	var response SendMessageResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal(domain.MessageKindImage, response.Kind)
+	s.Require().NotNil(response.Attachment)
+	s.Equal(attachment.URL, response.Attachment.URL)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_AttachmentFromOtherChat() {
+	// Uploaded to the Alice and Bob chat, then sent to Charlie
+	attachment := domain.NewAttachment(testdata.Alice.UserID, domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID), "photo.png")
+
+	s.mockRepo.On("GetAttachment", mock.Anything, attachment.ID).Return(&attachment, nil)
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+testdata.Charlie.UserID+"/messages", SendMessageRequest{AttachmentID: attachment.ID}, testdata.Alice)
+	req.URL.Path = "/api/v1/chats/" + testdata.Charlie.UserID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("INVALID_ATTACHMENT", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_AttachmentInUse() {
+	alice := testdata.Alice
+	bob := testdata.Bob
+	attachment := domain.NewAttachment(alice.UserID, domain.ComputeChatID(alice.UserID, bob.UserID), "notes.txt")
+	attachment.MimeType = "text/plain"
+	attachment.Size = 5
+	attachment.Checksum = strings.Repeat("ab", 32)
+
+	s.mockRepo.On("GetAttachment", mock.Anything, attachment.ID).Return(&attachment, nil)
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrAttachmentInUse)
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+bob.UserID+"/messages", SendMessageRequest{Content: "again", AttachmentID: attachment.ID}, alice)
+	req.URL.Path = "/api/v1/chats/" + bob.UserID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("INVALID_ATTACHMENT", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_RepositoryError() {
+	alice := testdata.Alice
+	bob := testdata.Bob
+
+	requestBody := SendMessageRequest{
+		Content: "Hello Bob!",
+	}
+
+	// Mock expectations - repository returns generic error
+	repoError := assert.AnError
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.Anything, mock.Anything).Return(repoError)
+	s.mockLogger.On("Error", "Failed to save message", "error", repoError, "sender", alice.UserID, "receiver", bob.UserID).Return()
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+bob.UserID+"/messages", requestBody, alice)
+	req.URL.Path = "/api/v1/chats/" + bob.UserID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusInternalServerError, recorder.Code)
+
+	var errorResp httpAdapter.ErrorResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
+	s.NoError(err)
+	s.Equal("Failed to save message", errorResp.Error)
+	s.Equal("SAVE_ERROR", errorResp.Code)
 }
 
 // GetMessages Tests
@@ -333,10 +570,10 @@ func (s *MessageHandlerTestSuite) TestSendMessage_PublisherError() {
 func (s *MessageHandlerTestSuite) TestGetMessages_Success() {
 	alice := testdata.Alice
 	validMessages := testdata.ValidMessages()
-	chatID := "alice_bob"
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
 
 	// Mock expectations
-	s.mockRepo.On("GetMessages", mock.Anything, chatID, mock.AnythingOfType("time.Time"), 50).Return(validMessages, nil)
+	s.mockRepo.On("GetMessages", mock.Anything, chatID, domain.MessagePage{Direction: domain.PageBefore, Limit: 50, UserID: alice.UserID}).Return(ports.PaginationResult{Messages: validMessages}, nil)
 	s.mockLogger.On("Debug", "Messages retrieved successfully", "chat_id", chatID, "user", alice.UserID, "count", len(validMessages)).Return()
 
 	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages", nil, alice)
@@ -360,7 +597,7 @@ func (s *MessageHandlerTestSuite) TestGetMessages_Success() {
 func (s *MessageHandlerTestSuite) TestGetMessages_WithPagination() {
 	alice := testdata.Alice
 	validMessages := testdata.ValidMessages()
-	chatID := "alice_bob"
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
 
 	// Request with cursor and limit
 	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages?cursor=2024-01-15T10:05:00Z&limit=10", nil, alice)
@@ -368,7 +605,8 @@ func (s *MessageHandlerTestSuite) TestGetMessages_WithPagination() {
 	req.URL.RawQuery = "cursor=2024-01-15T10:05:00Z&limit=10"
 
 	// Mock expectations
-	s.mockRepo.On("GetMessages", mock.Anything, chatID, mock.AnythingOfType("time.Time"), 10).Return(validMessages[:2], nil)
+	cursor := domain.MessageCursor{CreatedAt: time.Date(2024, 1, 15, 10, 5, 0, 0, time.UTC)}
+	s.mockRepo.On("GetMessages", mock.Anything, chatID, domain.MessagePage{Direction: domain.PageBefore, Cursor: cursor, Limit: 10, UserID: alice.UserID}).Return(ports.PaginationResult{Messages: validMessages[:2]}, nil)
 	s.mockLogger.On("Debug", "Messages retrieved successfully", "chat_id", chatID, "user", alice.UserID, "count", 2).Return()
 
 	recorder := httptest.NewRecorder()
@@ -389,7 +627,7 @@ func (s *MessageHandlerTestSuite) TestGetMessages_WithPagination() {
 
 func (s *MessageHandlerTestSuite) TestGetMessages_InvalidCursor() {
 	alice := testdata.Alice
-	chatID := "alice_bob"
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
 
 	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages?cursor=invalid-date", nil, alice)
 	req.URL.Path = "/api/v1/chats/" + chatID + "/messages"
@@ -411,7 +649,7 @@ func (s *MessageHandlerTestSuite) TestGetMessages_InvalidCursor() {
 
 func (s *MessageHandlerTestSuite) TestGetMessages_InvalidLimit() {
 	alice := testdata.Alice
-	chatID := "alice_bob"
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
 
 	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages?limit=500", nil, alice)
 	req.URL.Path = "/api/v1/chats/" + chatID + "/messages"
@@ -433,10 +671,10 @@ func (s *MessageHandlerTestSuite) TestGetMessages_InvalidLimit() {
 
 func (s *MessageHandlerTestSuite) TestGetMessages_RepositoryError() {
 	alice := testdata.Alice
-	chatID := "alice_bob"
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
 
 	repoError := assert.AnError
-	s.mockRepo.On("GetMessages", mock.Anything, chatID, mock.AnythingOfType("time.Time"), 50).Return(nil, repoError)
+	s.mockRepo.On("GetMessages", mock.Anything, chatID, domain.MessagePage{Direction: domain.PageBefore, Limit: 50, UserID: alice.UserID}).Return(ports.PaginationResult{}, repoError)
 	s.mockLogger.On("Error", "Failed to get messages", "error", repoError, "chat_id", chatID, "user", alice.UserID).Return()
 
 	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages", nil, alice)
@@ -458,6 +696,94 @@ func (s *MessageHandlerTestSuite) TestGetMessages_RepositoryError() {
 
 // UpdateMessageStatus Tests
 
+func (s *MessageHandlerTestSuite) TestGetMessages_After() {
+	alice := testdata.Alice
+	validMessages := testdata.ValidMessages()
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+
+	cursor := domain.MessageCursor{CreatedAt: testdata.BaseTime, ID: domain.NewMessageID()}
+	result := ports.PaginationResult{
+		Messages:   validMessages[:2],
+		PrevCursor: validMessages[0].Cursor().Encode(),
+		NextCursor: validMessages[1].Cursor().Encode(),
+		HasMore:    true,
+	}
+
+	s.mockRepo.On("GetMessages", mock.Anything, chatID, domain.MessagePage{Direction: domain.PageAfter, Cursor: cursor, Limit: 2, UserID: alice.UserID}).Return(result, nil)
+	s.mockLogger.On("Debug", "Messages retrieved successfully", "chat_id", chatID, "user", alice.UserID, "count", 2).Return()
+
+	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages?after="+cursor.Encode()+"&limit=2", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetMessages(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response GetMessagesResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.NoError(err)
+
+	s.Len(response.Messages, 2)
+	s.Equal(result.PrevCursor, response.PrevCursor)
+	s.Equal(result.NextCursor, response.NextCursor)
+	s.True(response.HasMore)
+	s.False(response.HasNewer)
+}
+
+func (s *MessageHandlerTestSuite) TestGetMessages_AroundNotFound() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+	anchorID := domain.NewMessageID()
+
+	s.mockRepo.On("GetMessages", mock.Anything, chatID, domain.MessagePage{Direction: domain.PageAround, Cursor: domain.MessageCursor{ID: anchorID}, Limit: 50, UserID: alice.UserID}).Return(ports.PaginationResult{}, domain.ErrMessageNotFound)
+
+	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages?around="+anchorID, nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetMessages(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNotFound, recorder.Code)
+
+	var errorResp httpAdapter.ErrorResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
+	s.NoError(err)
+	s.Equal("MESSAGE_NOT_FOUND", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestGetMessages_InvalidPagination() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+	cursor := domain.MessageCursor{CreatedAt: testdata.BaseTime, ID: domain.NewMessageID()}.Encode()
+
+	queries := map[string]string{
+		"conflicting cursors": "before=" + cursor + "&after=" + cursor,
+		"timestamp as before": "before=2024-01-15T10:05:00Z",
+		"cursor as around":    "around=" + cursor,
+		"malformed after":     "after=invalid-cursor",
+	}
+
+	for name, query := range queries {
+		s.Run(name, func() {
+			req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages?"+query, nil, alice)
+			recorder := httptest.NewRecorder()
+
+			// Execute
+			s.handler.GetMessages(recorder, req)
+
+			// Assertions
+			s.Equal(http.StatusBadRequest, recorder.Code)
+
+			var errorResp httpAdapter.ErrorResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
+			s.NoError(err)
+			s.Equal("INVALID_CURSOR", errorResp.Code)
+		})
+	}
+}
 func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_Success() {
 	bob := testdata.Bob
 	validMessages := testdata.ValidMessages()
@@ -474,12 +800,10 @@ func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_Success() {
 	}
 
 	// Mock expectations - Bob is updating status of message he received
-	s.mockRepo.On("MarkMessagesUpToRead", mock.Anything, messageID).Return(int64(3), nil)
-	s.mockPublisher.On("PublishStatusUpdate", mock.Anything, bob.UserID, mock.MatchedBy(func(status ports.StatusUpdate) bool {
-		return status.MessageID == messageID &&
-			status.Status == domain.MessageStatusRead &&
-			status.UpdatedBy == bob.UserID
-	})).Return(nil)
+	// The read receipt goes to the sender and to Bob's other devices
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(&testMessage, nil)
+	s.mockRepo.On("MarkMessagesUpToRead", mock.Anything, messageID, statusEventsTo(domain.MessageStatusRead, bob.UserID, testMessage.SenderID, bob.UserID)).Return(int64(3), nil)
+	s.mockMetrics.On("MessagesRead", int64(3)).Once()
 	s.mockLogger.On("Debug", "Message status updated successfully", "user", bob.UserID, "count", int64(3), "status", domain.MessageStatusRead).Return()
 
 	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", requestBody, bob)
@@ -514,6 +838,7 @@ func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_AccessDenied() {
 	}
 
 	// Alice tries to update status of message she sent (not received)
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(&testMessage, nil)
 	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", requestBody, alice)
 	recorder := httptest.NewRecorder()
 
@@ -547,7 +872,8 @@ func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_RepositoryError() {
 	}
 
 	repoError := assert.AnError
-	s.mockRepo.On("MarkMessagesUpToRead", mock.Anything, messageID).Return(int64(0), repoError)
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(&testMessage, nil)
+	s.mockRepo.On("MarkMessagesUpToRead", mock.Anything, messageID, mock.Anything).Return(int64(0), repoError)
 	s.mockLogger.On("Error", "Failed to update message status", "error", repoError, "user", bob.UserID, "message_id", messageID).Return()
 
 	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", requestBody, bob)
@@ -566,38 +892,752 @@ func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_RepositoryError() {
 	s.Equal("UPDATE_STATUS_ERROR", errorResp.Code)
 }
 
-func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_PublisherError() {
-	// Should still succeed even if publisher fails
+func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_ByMessageID() {
 	bob := testdata.Bob
-	validMessages := testdata.ValidMessages()
-	testMessage := validMessages[0]
+	testMessage := testdata.ValidMessages()[0] // This is from Alice to Bob
+	testMessage.ID = domain.NewMessageID()
+
+	// Clients may reference the message by its ID alone
+	body := []byte(`{"message_id": "` + testMessage.ID + `"}`)
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: testMessage.ID}).Return(&testMessage, nil)
+	s.mockRepo.On("MarkMessagesUpToRead", mock.Anything, testMessage.Key(), mock.Anything).Return(int64(1), nil)
+	s.mockMetrics.On("MessagesRead", int64(1)).Once()
+	s.mockLogger.On("Debug", "Message status updated successfully", "user", bob.UserID, "count", int64(1), "status", domain.MessageStatusRead).Return()
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", json.RawMessage(body), bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UpdateMessageStatus(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_MessageIDNotFound() {
+	messageID := domain.MessageID{ID: domain.NewMessageID()}
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(nil, domain.ErrMessageNotFound)
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", UpdateStatusRequest{MessageID: messageID}, testdata.Bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UpdateMessageStatus(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusNotFound, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("MESSAGE_NOT_FOUND", errorResp.Code)
+}
 
+func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_ForgedKeyNotFound() {
+	// A composite key naming a group and time of the client's choosing must match a stored message
 	messageID := domain.MessageID{
-		SenderID:   testMessage.SenderID,
-		ReceiverID: testMessage.ReceiverID,
-		CreatedAt:  testMessage.CreatedAt,
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: domain.NewGroupChatID(),
+		CreatedAt:  testdata.BaseTime.Add(24 * time.Hour),
 	}
 
-	requestBody := UpdateStatusRequest{
-		MessageID: messageID,
-	}
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(nil, domain.ErrMessageNotFound)
 
-	publishError := assert.AnError
-	s.mockRepo.On("MarkMessagesUpToRead", mock.Anything, messageID).Return(int64(2), nil)
-	s.mockPublisher.On("PublishStatusUpdate", mock.Anything, bob.UserID, mock.Anything).Return(publishError)
-	s.mockLogger.On("Error", "Failed to publish status update", "error", publishError, "user", bob.UserID).Return()
-	s.mockLogger.On("Debug", "Message status updated successfully", "user", bob.UserID, "count", int64(2), "status", domain.MessageStatusRead).Return()
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", UpdateStatusRequest{MessageID: messageID}, testdata.Bob)
+	recorder := httptest.NewRecorder()
 
-	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", requestBody, bob)
+	// Execute
+	s.handler.UpdateMessageStatus(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNotFound, recorder.Code)
+	s.mockChatRepo.AssertNotCalled(s.T(), "MarkReadUpTo", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_InvalidMessageID() {
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", UpdateStatusRequest{MessageID: domain.MessageID{ID: "not-a-uuid"}}, testdata.Bob)
 	recorder := httptest.NewRecorder()
 
 	// Execute
 	s.handler.UpdateMessageStatus(recorder, req)
 
-	// Assertions - should still return 200 even if publishing fails
-	s.Equal(http.StatusOK, recorder.Code)
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("VALIDATION_ERROR", errorResp.Code)
 }
 
-func TestMessageHandlerSuite(t *testing.T) {
-	suite.Run(t, new(MessageHandlerTestSuite))
-}
\ No newline at end of file
+func (s *MessageHandlerTestSuite) TestSendMessage_GroupFanOut() {
+	alice := testdata.Alice
+	chatID := domain.NewGroupChatID()
+
+	requestBody := SendMessageRequest{
+		Content: "Hello team!",
+	}
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, alice.UserID).Return(true, nil)
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+		return msg.SenderID == alice.UserID && msg.ReceiverID == chatID
+	}), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		return event.EventType == domain.MessageTypeNewMessage && event.Recipient == chatID
+	})).Return(nil)
+	s.mockMetrics.On("MessageSent", domain.ChatTypeGroup).Once()
+	s.mockLogger.On("Debug", "Message sent successfully", "sender", alice.UserID, "receiver", chatID).Return()
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+chatID+"/messages", requestBody, alice)
+	req.URL.Path = "/api/v1/chats/" + chatID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusCreated, recorder.Code)
+
+	var response SendMessageResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal(chatID, response.ReceiverID)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessage_GroupNotMember() {
+	eve := testdata.Eve
+	chatID := domain.NewGroupChatID()
+
+	requestBody := SendMessageRequest{
+		Content: "Let me in",
+	}
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, eve.UserID).Return(false, nil)
+
+	req := s.createRequestWithUser("POST", "/api/v1/chats/"+chatID+"/messages", requestBody, eve)
+	req.URL.Path = "/api/v1/chats/" + chatID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SendMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusForbidden, recorder.Code)
+
+	var errorResp httpAdapter.ErrorResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
+	s.NoError(err)
+	s.Equal("ACCESS_DENIED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestGetMessages_SubstringOfParticipant() {
+	bo := domain.UserContext{UserID: "bo", Email: "bo@interface.ai", Handler: "bo"}
+	chatID := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+
+	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages", nil, bo)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetMessages(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusForbidden, recorder.Code)
+
+	var errorResp httpAdapter.ErrorResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
+	s.NoError(err)
+	s.Equal("ACCESS_DENIED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestGetMessages_InvalidChatID() {
+	alice := testdata.Alice
+
+	for _, chatID := range []string{"alice_bob", "bob---alice", "alice---bob---eve"} {
+		s.Run(chatID, func() {
+			req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages", nil, alice)
+			recorder := httptest.NewRecorder()
+
+			// Execute
+			s.handler.GetMessages(recorder, req)
+
+			// Assertions
+			s.Equal(http.StatusBadRequest, recorder.Code)
+
+			var errorResp httpAdapter.ErrorResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &errorResp)
+			s.NoError(err)
+			s.Equal("INVALID_CHAT_ID", errorResp.Code)
+		})
+	}
+}
+
+func (s *MessageHandlerTestSuite) TestGetMessages_GroupMember() {
+	bob := testdata.Bob
+	chatID := domain.NewGroupChatID()
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, bob.UserID).Return(true, nil)
+	s.mockRepo.On("GetMessages", mock.Anything, chatID, domain.MessagePage{Direction: domain.PageBefore, Limit: 50, UserID: bob.UserID}).Return(ports.PaginationResult{Messages: []domain.Message{}}, nil)
+	s.mockLogger.On("Debug", "Messages retrieved successfully", "chat_id", chatID, "user", bob.UserID, "count", 0).Return()
+
+	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages", nil, bob)
+	req.URL.Path = "/api/v1/chats/" + chatID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetMessages(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestGetMessages_GroupNotMember() {
+	eve := testdata.Eve
+	chatID := domain.NewGroupChatID()
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, eve.UserID).Return(false, nil)
+
+	req := s.createRequestWithUser("GET", "/api/v1/chats/"+chatID+"/messages", nil, eve)
+	req.URL.Path = "/api/v1/chats/" + chatID + "/messages"
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.GetMessages(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestUpdateMessageStatus_GroupReadMarker() {
+	bob := testdata.Bob
+	chatID := domain.NewGroupChatID()
+
+	messageID := domain.MessageID{
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: chatID,
+		CreatedAt:  testdata.BaseTime,
+	}
+
+	requestBody := UpdateStatusRequest{
+		MessageID: messageID,
+	}
+
+	anchor := domain.Message{ID: domain.NewMessageID(), SenderID: messageID.SenderID, ReceiverID: chatID, CreatedAt: messageID.CreatedAt}
+
+	// Every sender in the range gets the receipt, along with Bob's other devices
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(&anchor, nil)
+	s.mockChatRepo.On("MarkReadUpTo", mock.Anything, chatID, bob.UserID, messageID.CreatedAt, statusEventsTo(domain.MessageStatusRead, bob.UserID, testdata.Alice.UserID, testdata.Charlie.UserID, bob.UserID)).Return(int64(4), nil)
+	s.mockMetrics.On("MessagesRead", int64(4)).Once()
+	s.mockLogger.On("Debug", "Message status updated successfully", "user", bob.UserID, "count", int64(4), "status", domain.MessageStatusRead).Return()
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/status", requestBody, bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UpdateMessageStatus(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response UpdateStatusResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal(int64(4), response.UpdatedCount)
+}
+
+// MarkMessagesDelivered Tests
+
+func (s *MessageHandlerTestSuite) TestMarkMessagesDelivered_Success() {
+	alice := testdata.Alice
+	bob := testdata.Bob
+	testMessage := testdata.ValidMessages()[0] // This is from Alice to Bob
+
+	messageID := testMessage.Key()
+	requestBody := UpdateStatusRequest{
+		MessageID: messageID,
+	}
+
+	// The delivery receipt is addressed to the sender
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(&testMessage, nil)
+	s.mockRepo.On("MarkMessagesUpToDelivered", mock.Anything, messageID, statusEventsTo(domain.MessageStatusDelivered, bob.UserID, alice.UserID)).Return(int64(2), nil)
+	s.mockLogger.On("Debug", "Message status updated successfully", "user", bob.UserID, "count", int64(2), "status", domain.MessageStatusDelivered).Return()
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/delivered", requestBody, bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MarkMessagesDelivered(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response UpdateStatusResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal(int64(2), response.UpdatedCount)
+}
+
+func (s *MessageHandlerTestSuite) TestMarkMessagesDelivered_AccessDenied() {
+	alice := testdata.Alice
+	testMessage := testdata.ValidMessages()[0] // This is from Alice to Bob
+
+	requestBody := UpdateStatusRequest{
+		MessageID: testMessage.Key(),
+	}
+
+	// Alice cannot acknowledge delivery of her own message
+	s.mockRepo.On("GetMessageByID", mock.Anything, testMessage.Key()).Return(&testMessage, nil)
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/delivered", requestBody, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MarkMessagesDelivered(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestMarkMessagesDelivered_GroupNotMember() {
+	eve := testdata.Eve
+	chatID := domain.NewGroupChatID()
+
+	messageID := domain.MessageID{
+		SenderID:   testdata.Alice.UserID,
+		ReceiverID: chatID,
+		CreatedAt:  testdata.BaseTime,
+	}
+
+	anchor := domain.Message{ID: domain.NewMessageID(), SenderID: messageID.SenderID, ReceiverID: chatID, CreatedAt: messageID.CreatedAt}
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(&anchor, nil)
+	s.mockChatRepo.On("MarkDeliveredUpTo", mock.Anything, chatID, eve.UserID, messageID.CreatedAt, statusEventsTo(domain.MessageStatusDelivered, eve.UserID, testdata.Alice.UserID, testdata.Charlie.UserID)).Return(int64(0), domain.ErrNotChatMember)
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/delivered", UpdateStatusRequest{MessageID: messageID}, eve)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MarkMessagesDelivered(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("ACCESS_DENIED", errorResp.Code)
+}
+
+// EditMessage Tests
+
+// recentMessage returns a message from Alice to Bob sent just now, with a server-assigned ID
+func recentMessage() domain.Message {
+	message := testdata.ValidMessages()[0]
+	message.ID = domain.NewMessageID()
+	message.CreatedAt = time.Now().UTC()
+	return message
+}
+
+func (s *MessageHandlerTestSuite) TestEditMessage_Success() {
+	alice := testdata.Alice
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockRepo.On("EditMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+		return msg.ID == original.ID && msg.Content == "Fixed typo" && msg.EditedAt != nil
+	}), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		return event.EventType == domain.MessageTypeMessageEdited && event.Recipient == testdata.Bob.UserID
+	}), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		return event.EventType == domain.MessageTypeMessageEdited && event.Recipient == alice.UserID
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Message edited successfully", "message_id", original.ID, "user", alice.UserID).Return()
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/"+original.ID, EditMessageRequest{Content: "Fixed typo"}, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.EditMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response SendMessageResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal(original.ID, response.ID)
+	s.Equal("Fixed typo", response.Content)
+	s.NotNil(response.EditedAt)
+}
+
+func (s *MessageHandlerTestSuite) TestEditMessage_NotSender() {
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/"+original.ID, EditMessageRequest{Content: "Not mine"}, testdata.Bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.EditMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("ACCESS_DENIED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestEditMessage_OutsiderSeesNotFound() {
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/"+original.ID, EditMessageRequest{Content: "Hijack"}, testdata.Eve)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.EditMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusNotFound, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("MESSAGE_NOT_FOUND", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestEditMessage_WindowExpired() {
+	original := recentMessage()
+	original.CreatedAt = time.Now().UTC().Add(-time.Hour)
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/"+original.ID, EditMessageRequest{Content: "Too late"}, testdata.Alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.EditMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("EDIT_WINDOW_EXPIRED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestEditMessage_Deleted() {
+	message := recentMessage()
+	original := message.Tombstone(time.Now().UTC())
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/"+original.ID, EditMessageRequest{Content: "Revive"}, testdata.Alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.EditMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusGone, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("MESSAGE_DELETED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestEditMessage_EmptyContent() {
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("PATCH", "/api/v1/messages/"+original.ID, EditMessageRequest{Content: "   "}, testdata.Alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.EditMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("VALIDATION_ERROR", errorResp.Code)
+}
+
+// DeleteMessage Tests
+
+func (s *MessageHandlerTestSuite) TestDeleteMessage_ForEveryone() {
+	alice := testdata.Alice
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockRepo.On("DeleteMessage", mock.Anything, original.ID, mock.AnythingOfType("time.Time"), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		var tombstone domain.Message
+		return event.EventType == domain.MessageTypeMessageDeleted &&
+			event.Recipient == testdata.Bob.UserID &&
+			json.Unmarshal(event.Payload, &tombstone) == nil &&
+			tombstone.Content == "" && tombstone.DeletedAt != nil
+	}), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		return event.EventType == domain.MessageTypeMessageDeleted && event.Recipient == alice.UserID
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Message deleted successfully", "message_id", original.ID, "user", alice.UserID, "scope", domain.DeleteForEveryone).Return()
+
+	req := s.createRequestWithUser("DELETE", "/api/v1/messages/"+original.ID+"?scope=everyone", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DeleteMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestDeleteMessage_ForEveryoneDeletesAttachment() {
+	alice := testdata.Alice
+	original := recentMessage()
+	original.Attach(domain.NewAttachment(alice.UserID, original.GetChatID(), "notes.txt"))
+	original.Attachment.MimeType = "text/plain"
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockRepo.On("DeleteMessage", mock.Anything, original.ID, mock.AnythingOfType("time.Time"), mock.Anything, mock.Anything).Return(nil)
+	s.mockBlobs.On("Delete", mock.Anything, original.AttachmentID()).Return(nil)
+	s.mockLogger.On("Debug", "Message deleted successfully", "message_id", original.ID, "user", alice.UserID, "scope", domain.DeleteForEveryone).Return()
+
+	req := s.createRequestWithUser("DELETE", "/api/v1/messages/"+original.ID+"?scope=everyone", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DeleteMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestDeleteMessage_ForEveryoneNotSender() {
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("DELETE", "/api/v1/messages/"+original.ID+"?scope=everyone", nil, testdata.Bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DeleteMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("ACCESS_DENIED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestDeleteMessage_WindowExpired() {
+	original := recentMessage()
+	original.CreatedAt = time.Now().UTC().Add(-2 * time.Hour)
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("DELETE", "/api/v1/messages/"+original.ID+"?scope=everyone", nil, testdata.Alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DeleteMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusForbidden, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("DELETE_WINDOW_EXPIRED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestDeleteMessage_ForMe() {
+	bob := testdata.Bob
+	original := recentMessage()
+	original.CreatedAt = time.Now().UTC().Add(-24 * time.Hour) // Hiding is never limited by the window
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockRepo.On("HideMessage", mock.Anything, original.ID, bob.UserID, mock.AnythingOfType("time.Time"), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		return event.EventType == domain.MessageTypeMessageDeleted && event.Recipient == bob.UserID
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Message deleted successfully", "message_id", original.ID, "user", bob.UserID, "scope", "").Return()
+
+	req := s.createRequestWithUser("DELETE", "/api/v1/messages/"+original.ID, nil, bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DeleteMessage(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestDeleteMessage_GroupNotMember() {
+	eve := testdata.Eve
+	original := recentMessage()
+	original.ReceiverID = domain.NewGroupChatID()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockChatRepo.On("IsMember", mock.Anything, original.ReceiverID, eve.UserID).Return(false, nil)
+
+	req := s.createRequestWithUser("DELETE", "/api/v1/messages/"+original.ID+"?scope=me", nil, eve)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DeleteMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusNotFound, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("MESSAGE_NOT_FOUND", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestDeleteMessage_InvalidScope() {
+	req := s.createRequestWithUser("DELETE", "/api/v1/messages/"+domain.NewMessageID()+"?scope=nobody", nil, testdata.Alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.DeleteMessage(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("VALIDATION_ERROR", errorResp.Code)
+}
+
+// Reaction Tests
+
+func (s *MessageHandlerTestSuite) TestAddReaction_Success() {
+	bob := testdata.Bob
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockRepo.On("AddReaction", mock.Anything, mock.MatchedBy(func(reaction domain.Reaction) bool {
+		return reaction.MessageID == original.ID && reaction.UserID == bob.UserID && reaction.Emoji == "👍"
+	}), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		var update domain.ReactionUpdate
+		return event.EventType == domain.MessageTypeReactionUpdated &&
+			event.Recipient == bob.UserID &&
+			json.Unmarshal(event.Payload, &update) == nil &&
+			update.Action == domain.ReactionAdded &&
+			update.Emoji == "👍" &&
+			update.ChatID == original.GetChatID()
+	}), mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		return event.EventType == domain.MessageTypeReactionUpdated && event.Recipient == testdata.Alice.UserID
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Reaction added successfully", "message_id", original.ID, "user", bob.UserID).Return()
+
+	req := s.createRequestWithUser("POST", "/api/v1/messages/"+original.ID+"/reactions", AddReactionRequest{Emoji: "👍"}, bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddReaction(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response ReactionResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal(original.ID, response.MessageID)
+	s.Equal(bob.UserID, response.UserID)
+	s.Equal("👍", response.Emoji)
+}
+
+func (s *MessageHandlerTestSuite) TestAddReaction_InvalidEmoji() {
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("POST", "/api/v1/messages/"+original.ID+"/reactions", AddReactionRequest{Emoji: "lol"}, testdata.Bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddReaction(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("VALIDATION_ERROR", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestAddReaction_DeletedMessage() {
+	message := recentMessage()
+	original := message.Tombstone(time.Now().UTC())
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("POST", "/api/v1/messages/"+original.ID+"/reactions", AddReactionRequest{Emoji: "👍"}, testdata.Bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddReaction(recorder, req)
+
+	// Assertions
+	var errorResp httpAdapter.ErrorResponse
+	s.Equal(http.StatusGone, recorder.Code)
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+	s.Equal("MESSAGE_DELETED", errorResp.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestAddReaction_Outsider() {
+	original := recentMessage()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+
+	req := s.createRequestWithUser("POST", "/api/v1/messages/"+original.ID+"/reactions", AddReactionRequest{Emoji: "👍"}, testdata.Eve)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.AddReaction(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestRemoveReaction_GroupMessage() {
+	bob := testdata.Bob
+	original := recentMessage()
+	original.ReceiverID = domain.NewGroupChatID()
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockChatRepo.On("IsMember", mock.Anything, original.ReceiverID, bob.UserID).Return(true, nil)
+	s.mockRepo.On("RemoveReaction", mock.Anything, original.ID, bob.UserID, mock.MatchedBy(func(event domain.OutboxEvent) bool {
+		var update domain.ReactionUpdate
+		return event.Recipient == original.ReceiverID &&
+			json.Unmarshal(event.Payload, &update) == nil &&
+			update.Action == domain.ReactionRemoved &&
+			update.Emoji == ""
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Reaction removed successfully", "message_id", original.ID, "user", bob.UserID).Return()
+
+	req := s.createRequestWithUser("DELETE", "/api/v1/messages/"+original.ID+"/reactions", nil, bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.RemoveReaction(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+// statusEventsTo matches an events builder producing one status update per recipient
+func statusEventsTo(status, updatedBy string, recipients ...string) interface{} {
+	return mock.MatchedBy(func(events domain.OutboxEventsFunc) bool {
+		affected := domain.MessageRange{From: testdata.BaseTime, To: testdata.BaseTime.Add(time.Minute), Count: 2, Senders: []string{testdata.Alice.UserID, testdata.Charlie.UserID}}
+		built, err := events(affected)
+		if err != nil || len(built) != len(recipients) {
+			return false
+		}
+
+		for i, event := range built {
+			var update ports.StatusUpdate
+			if event.EventType != domain.MessageTypeStatusUpdate ||
+				event.Recipient != recipients[i] ||
+				json.Unmarshal(event.Payload, &update) != nil ||
+				update.Status != status ||
+				update.UpdatedBy != updatedBy ||
+				update.Range == nil ||
+				update.Range.Count != affected.Count ||
+				!update.Range.From.Equal(affected.From) ||
+				!update.Range.To.Equal(affected.To) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestMessageHandlerSuite(t *testing.T) {
+	suite.Run(t, new(MessageHandlerTestSuite))
+}
@@ -5,29 +5,45 @@ import (
 	"messaging-app/internal/ports"
 )
 
+// Patterns of the routes whose operations are also WebSocket commands
+const (
+	sendMessagePattern      = "/api/v1/chats/{receiverId}/messages"
+	messageStatusPattern    = "/api/v1/messages/status"
+	messageDeliveredPattern = "/api/v1/messages/delivered"
+	messagePattern          = "/api/v1/messages/{messageId}"
+	reactionsPattern        = "/api/v1/messages/{messageId}/reactions"
+)
+
 type MessageRoutes struct {
+	config      MessageConfig
 	messageRepo ports.MessageRepository
-	publisher   ports.MessagePublisher
+	chatRepo    ports.ChatRepository
+	blobs       ports.BlobStore
+	metrics     ports.Metrics
 	logger      ports.Logger
 }
 
-func NewMessageRoutes(messageRepo ports.MessageRepository, publisher ports.MessagePublisher, logger ports.Logger) *MessageRoutes {
+func NewMessageRoutes(config MessageConfig, messageRepo ports.MessageRepository, chatRepo ports.ChatRepository, blobs ports.BlobStore, metrics ports.Metrics, logger ports.Logger) *MessageRoutes {
 	return &MessageRoutes{
+		config:      config,
 		messageRepo: messageRepo,
-		publisher:   publisher,
+		chatRepo:    chatRepo,
+		blobs:       blobs,
+		metrics:     metrics,
 		logger:      logger,
 	}
 }
 
 func (mr *MessageRoutes) GetRoutes() []httpAdapter.Route {
-	handler := NewMessageHandler(mr.messageRepo, mr.publisher, mr.logger)
+	handler := NewMessageHandler(mr.config, mr.messageRepo, mr.chatRepo, mr.blobs, mr.metrics, mr.logger)
 
 	return []httpAdapter.Route{
 		{
 			Method:      "POST",
-			Pattern:     "/api/v1/chats/{receiverId}/messages",
+			Pattern:     sendMessagePattern,
 			Handler:     handler.SendMessage,
 			RequireAuth: true,
+			RateLimit:   mr.config.SendRateLimit,
 		},
 		{
 			Method:      "GET",
@@ -37,9 +53,39 @@ func (mr *MessageRoutes) GetRoutes() []httpAdapter.Route {
 		},
 		{
 			Method:      "PATCH",
-			Pattern:     "/api/v1/messages/status",
+			Pattern:     messageStatusPattern,
 			Handler:     handler.UpdateMessageStatus,
 			RequireAuth: true,
 		},
+		{
+			Method:      "PATCH",
+			Pattern:     messageDeliveredPattern,
+			Handler:     handler.MarkMessagesDelivered,
+			RequireAuth: true,
+		},
+		{
+			Method:      "PATCH",
+			Pattern:     messagePattern,
+			Handler:     handler.EditMessage,
+			RequireAuth: true,
+		},
+		{
+			Method:      "DELETE",
+			Pattern:     messagePattern,
+			Handler:     handler.DeleteMessage,
+			RequireAuth: true,
+		},
+		{
+			Method:      "POST",
+			Pattern:     reactionsPattern,
+			Handler:     handler.AddReaction,
+			RequireAuth: true,
+		},
+		{
+			Method:      "DELETE",
+			Pattern:     reactionsPattern,
+			Handler:     handler.RemoveReaction,
+			RequireAuth: true,
+		},
 	}
 }
@@ -3,40 +3,177 @@ package http
 import (
 	"time"
 
+	httpAdapter "messaging-app/internal/adapters/http"
 	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
 )
 
+// IdempotencyKeyHeader lets HTTP clients pass client_message_id as a header
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 // Request models
 type SendMessageRequest struct {
+	Content string `json:"content" validate:"max=10000"` // Required unless an attachment is sent
+
+	// ClientMessageID is an idempotency key; retries with the same key return the original message
+	ClientMessageID string `json:"client_message_id,omitempty" validate:"max=100"`
+
+	// ReplyTo is the ID of a message of the same chat this one answers
+	ReplyTo string `json:"reply_to,omitempty"`
+
+	// AttachmentID is a file the sender uploaded to the same chat; Content becomes its caption
+	AttachmentID string `json:"attachment_id,omitempty"`
+}
+
+type EditMessageRequest struct {
 	Content string `json:"content" validate:"required,max=10000"`
 }
 
+type AddReactionRequest struct {
+	Emoji string `json:"emoji" validate:"required"`
+}
+
 type UpdateStatusRequest struct {
 	MessageID domain.MessageID `json:"message_id" validate:"required"`
 }
 
+type CreateGroupRequest struct {
+	Name    string   `json:"name" validate:"required,max=100"`
+	Members []string `json:"members" validate:"required"`
+}
+
+type AddMemberRequest struct {
+	UserID string `json:"user_id" validate:"required,max=100"`
+}
+
+type BlockUserRequest struct {
+	UserID string `json:"user_id" validate:"required,max=100"`
+}
+
+// MuteChatRequest mutes a chat until the given time, or until it is unmuted when omitted
+type MuteChatRequest struct {
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// SendMessageCommand is the payload of the send_message WebSocket command
+type SendMessageCommand struct {
+	ReceiverID string `json:"receiver_id" validate:"required,max=100"`
+	SendMessageRequest
+}
+
+// EditMessageCommand is the payload of the edit_message WebSocket command
+type EditMessageCommand struct {
+	MessageID string `json:"message_id" validate:"required"`
+	EditMessageRequest
+}
+
+// DeleteMessageCommand is the payload of the delete_message WebSocket command
+type DeleteMessageCommand struct {
+	MessageID string `json:"message_id" validate:"required"`
+	Scope     string `json:"scope" validate:"omitempty,oneof=me everyone"` // Defaults to me
+}
+
+// AddReactionCommand is the payload of the add_reaction WebSocket command
+type AddReactionCommand struct {
+	MessageID string `json:"message_id" validate:"required"`
+	AddReactionRequest
+}
+
+// RemoveReactionCommand is the payload of the remove_reaction WebSocket command
+type RemoveReactionCommand struct {
+	MessageID string `json:"message_id" validate:"required"`
+}
+
 type GetMessagesRequest struct {
-	Cursor string `json:"cursor"` // RFC3339 timestamp
+	Cursor string `json:"cursor"` // Opaque cursor, or RFC3339 timestamp for older clients
+	Before string `json:"before"` // Opaque cursor, page towards older messages
+	After  string `json:"after"`  // Opaque cursor, page towards newer messages
+	Around string `json:"around"` // Message ID to centre the page on
 	Limit  int    `json:"limit"`  // Max 100, default 50
 }
 
 // Response models
 type SendMessageResponse struct {
+	ID         string    `json:"id"`
 	SenderID   string    `json:"sender_id"`
 	ReceiverID string    `json:"receiver_id"`
 	CreatedAt  time.Time `json:"created_at"`
 	Content    string    `json:"content"`
 	Status     string    `json:"status"`
+
+	ClientMessageID string        `json:"client_message_id,omitempty"`
+	EditedAt        *time.Time    `json:"edited_at,omitempty"`
+	DeletedAt       *time.Time    `json:"deleted_at,omitempty"`
+	ReplyTo         *domain.Quote `json:"reply_to,omitempty"`
+
+	Kind       domain.MessageKind `json:"kind"`
+	Attachment *domain.Attachment `json:"attachment,omitempty"`
+}
+
+func newSendMessageResponse(message domain.Message) SendMessageResponse {
+	return SendMessageResponse{
+		ID:         message.ID,
+		SenderID:   message.SenderID,
+		ReceiverID: message.ReceiverID,
+		CreatedAt:  message.CreatedAt,
+		Content:    message.Content,
+		Status:     message.Status,
+
+		ClientMessageID: message.ClientMessageID,
+		EditedAt:        message.EditedAt,
+		DeletedAt:       message.DeletedAt,
+		ReplyTo:         message.ReplyTo,
+
+		Kind:       message.Kind,
+		Attachment: message.Attachment,
+	}
 }
 
 type GetChatsResponse struct {
-	Chats []domain.ChatSession `json:"chats"`
+	Chats      []domain.ChatSession `json:"chats"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	HasMore    bool                 `json:"has_more"`
+}
+
+// GetMessagesResponse is a page of history with cursors towards older and newer messages
+type GetMessagesResponse ports.PaginationResult
+
+// SearchResponse is a page of search hits, best matches first
+type SearchResponse ports.SearchResult
+
+type GroupResponse struct {
+	Group domain.Chat `json:"group"`
+}
+
+type BlocksResponse struct {
+	Blocks []domain.Block `json:"blocks"`
+}
+
+type MuteResponse domain.ChatMute
+
+type DeleteMessageResponse struct {
+	MessageID string `json:"message_id"`
+	Scope     string `json:"scope"`
+}
+
+type ReactionResponse domain.Reaction
+
+type AttachmentResponse domain.Attachment
+
+type TypingCommand struct {
+	ChatID string `json:"chat_id"`
+}
+
+type HeartbeatResponse struct {
+	Status domain.StatusType `json:"status"`
 }
 
-type GetMessagesResponse struct {
-	Messages   []domain.Message `json:"messages"`
-	NextCursor string           `json:"next_cursor,omitempty"`
-	HasMore    bool             `json:"has_more"`
+type TypingResponse struct {
+	ChatID string `json:"chat_id"`
+}
+
+type RemoveReactionResponse struct {
+	MessageID string `json:"message_id"`
 }
 
 type UpdateStatusResponse struct {
@@ -49,6 +186,18 @@ type ErrorResponse struct {
 	Details string `json:"details,omitempty"`
 }
 
+// handlerError describes a client-facing failure independently of the transport
+type handlerError struct {
+	StatusCode int
+	Message    string
+	Code       string
+	Details    string
+}
+
+func (e *handlerError) commandError() *httpAdapter.CommandError {
+	return &httpAdapter.CommandError{Message: e.Message, Code: e.Code, Details: e.Details}
+}
+
 // Success response wrapper
 type SuccessResponse struct {
 	Data    interface{} `json:"data"`
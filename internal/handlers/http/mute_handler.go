@@ -0,0 +1,151 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// MuteHandler mutes and unmutes chats for the authenticated user
+type MuteHandler struct {
+	ChatRepo   ports.ChatRepository
+	Authorizer *Authorizer
+	Logger     ports.Logger
+}
+
+func NewMuteHandler(chatRepo ports.ChatRepository, logger ports.Logger) *MuteHandler {
+	return &MuteHandler{
+		ChatRepo:   chatRepo,
+		Authorizer: NewAuthorizer(chatRepo),
+		Logger:     logger,
+	}
+}
+
+// MuteChat handles PUT /api/v1/chats/{chatId}/mute
+// The body is optional; muting again replaces the previous end of the mute
+func (h *MuteHandler) MuteChat(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := h.chatIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	var req MuteChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON", err.Error())
+		return
+	}
+
+	if herr := h.checkParticipant(r.Context(), user, chatID); herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	mute := domain.ChatMute{
+		ChatID:    chatID,
+		UserID:    user.UserID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if req.Until != nil {
+		until := req.Until.UTC()
+		mute.MutedUntil = &until
+	}
+	if err := mute.Validate(); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	if err := h.ChatRepo.MuteChat(r.Context(), mute); err != nil {
+		h.Logger.Error("Failed to mute chat", "error", err, "chat_id", chatID, "user", user.UserID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to mute chat", "MUTE_ERROR", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(MuteResponse(mute))
+
+	h.Logger.Debug("Chat muted successfully", "chat_id", chatID, "user", user.UserID)
+}
+
+// UnmuteChat handles DELETE /api/v1/chats/{chatId}/mute
+func (h *MuteHandler) UnmuteChat(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := h.chatIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	// Former group members may still lift a mute they set, so only the chat ID is checked
+	if _, err := domain.ParseChatID(chatID); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID", err.Error())
+		return
+	}
+
+	if err := h.ChatRepo.UnmuteChat(r.Context(), chatID, user.UserID); err != nil {
+		h.Logger.Error("Failed to unmute chat", "error", err, "chat_id", chatID, "user", user.UserID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to unmute chat", "UNMUTE_ERROR", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.Logger.Debug("Chat unmuted successfully", "chat_id", chatID, "user", user.UserID)
+}
+
+// checkParticipant ensures the user takes part in the chat they mute
+func (h *MuteHandler) checkParticipant(ctx context.Context, user domain.UserContext, chatID string) *handlerError {
+	isParticipant, err := h.Authorizer.CanAccessChat(ctx, user.UserID, chatID)
+	if errors.Is(err, domain.ErrInvalidChatID) {
+		return &handlerError{http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID", err.Error()}
+	}
+	if err != nil {
+		h.Logger.Error("Failed to check chat participation", "error", err, "chat_id", chatID, "user", user.UserID)
+		return &handlerError{http.StatusInternalServerError, "Failed to mute chat", "MUTE_ERROR", ""}
+	}
+	if !isParticipant {
+		return &handlerError{http.StatusForbidden, "Access denied", "ACCESS_DENIED", "User is not a participant in this chat"}
+	}
+	return nil
+}
+
+// chatIDFromPath extracts chatId from /api/v1/chats/{chatId}/mute
+func (h *MuteHandler) chatIDFromPath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing chat ID", "MISSING_CHAT_ID", "chatId path parameter is required")
+		return "", false
+	}
+	return pathParts[3], true
+}
+
+func (h *MuteHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message, code, details string) {
+	w.WriteHeader(statusCode)
+
+	response := httpAdapter.ErrorResponse{
+		Error:   message,
+		Code:    code,
+		Details: details,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to write error response", "error", err)
+	}
+}
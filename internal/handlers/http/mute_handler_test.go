@@ -0,0 +1,181 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MuteHandlerTestSuite struct {
+	suite.Suite
+	handler      *MuteHandler
+	mockChatRepo *mocks.ChatRepository
+	mockLogger   *mocks.Logger
+	helpers      *TestHelpers
+}
+
+func (s *MuteHandlerTestSuite) SetupTest() {
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.mockLogger = &mocks.Logger{}
+	s.handler = NewMuteHandler(s.mockChatRepo, s.mockLogger)
+	s.helpers = NewTestHelpers(s.T())
+}
+
+func (s *MuteHandlerTestSuite) TearDownTest() {
+	s.mockChatRepo.AssertExpectations(s.T())
+	s.mockLogger.AssertExpectations(s.T())
+}
+
+// MuteChat Tests
+
+func (s *MuteHandlerTestSuite) TestMuteChat_UntilUnmuted() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+
+	s.mockChatRepo.On("MuteChat", mock.Anything, mock.MatchedBy(func(mute domain.ChatMute) bool {
+		return mute.ChatID == chatID && mute.UserID == alice.UserID && mute.MutedUntil == nil
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Chat muted successfully", "chat_id", chatID, "user", alice.UserID).Return()
+
+	// The body is optional
+	req := s.helpers.CreateRequestWithUser("PUT", "/api/v1/chats/"+chatID+"/mute", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MuteChat(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response MuteResponse
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal(chatID, response.ChatID)
+	s.Nil(response.MutedUntil)
+}
+
+func (s *MuteHandlerTestSuite) TestMuteChat_Until() {
+	bob := testdata.Bob
+	chatID := domain.NewGroupChatID()
+	until := time.Now().Add(8 * time.Hour).UTC().Truncate(time.Second)
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, bob.UserID).Return(true, nil)
+	s.mockChatRepo.On("MuteChat", mock.Anything, mock.MatchedBy(func(mute domain.ChatMute) bool {
+		return mute.ChatID == chatID && mute.MutedUntil != nil && mute.MutedUntil.Equal(until)
+	})).Return(nil)
+	s.mockLogger.On("Debug", "Chat muted successfully", "chat_id", chatID, "user", bob.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("PUT", "/api/v1/chats/"+chatID+"/mute", MuteChatRequest{Until: &until}, bob)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MuteChat(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response MuteResponse
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Require().NotNil(response.MutedUntil)
+	s.True(response.MutedUntil.Equal(until))
+}
+
+func (s *MuteHandlerTestSuite) TestMuteChat_UntilInThePast() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+	until := time.Now().Add(-time.Minute)
+
+	req := s.helpers.CreateRequestWithUser("PUT", "/api/v1/chats/"+chatID+"/mute", MuteChatRequest{Until: &until}, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MuteChat(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR")
+}
+
+func (s *MuteHandlerTestSuite) TestMuteChat_NotParticipant() {
+	chatID := domain.ComputeChatID(testdata.Alice.UserID, testdata.Bob.UserID)
+
+	req := s.helpers.CreateRequestWithUser("PUT", "/api/v1/chats/"+chatID+"/mute", nil, testdata.Eve)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MuteChat(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusForbidden, "Access denied", "ACCESS_DENIED")
+}
+
+func (s *MuteHandlerTestSuite) TestMuteChat_InvalidChatID() {
+	req := s.helpers.CreateRequestWithUser("PUT", "/api/v1/chats/bob---alice/mute", nil, testdata.Alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MuteChat(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID")
+}
+
+func (s *MuteHandlerTestSuite) TestMuteChat_RepositoryError() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+
+	s.mockChatRepo.On("MuteChat", mock.Anything, mock.Anything).Return(errors.New("database error"))
+	s.mockLogger.On("Error", "Failed to mute chat", "error", mock.Anything, "chat_id", chatID, "user", alice.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("PUT", "/api/v1/chats/"+chatID+"/mute", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.MuteChat(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusInternalServerError, "Failed to mute chat", "MUTE_ERROR")
+}
+
+// UnmuteChat Tests
+
+func (s *MuteHandlerTestSuite) TestUnmuteChat_Success() {
+	alice := testdata.Alice
+	chatID := domain.NewGroupChatID()
+
+	// Former members can lift their mute, so membership is not checked
+	s.mockChatRepo.On("UnmuteChat", mock.Anything, chatID, alice.UserID).Return(nil)
+	s.mockLogger.On("Debug", "Chat unmuted successfully", "chat_id", chatID, "user", alice.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("DELETE", "/api/v1/chats/"+chatID+"/mute", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UnmuteChat(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *MuteHandlerTestSuite) TestUnmuteChat_InvalidChatID() {
+	req := s.helpers.CreateRequestWithUser("DELETE", "/api/v1/chats/alice_bob/mute", nil, testdata.Alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.UnmuteChat(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID")
+}
+
+func TestMuteHandlerSuite(t *testing.T) {
+	suite.Run(t, new(MuteHandlerTestSuite))
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/ports"
+)
+
+type MuteRoutes struct {
+	chatRepo ports.ChatRepository
+	logger   ports.Logger
+}
+
+func NewMuteRoutes(chatRepo ports.ChatRepository, logger ports.Logger) *MuteRoutes {
+	return &MuteRoutes{
+		chatRepo: chatRepo,
+		logger:   logger,
+	}
+}
+
+func (mr *MuteRoutes) GetRoutes() []httpAdapter.Route {
+	handler := NewMuteHandler(mr.chatRepo, mr.logger)
+
+	return []httpAdapter.Route{
+		{
+			Method:      "PUT",
+			Pattern:     "/api/v1/chats/{chatId}/mute",
+			Handler:     handler.MuteChat,
+			RequireAuth: true,
+		},
+		{
+			Method:      "DELETE",
+			Pattern:     "/api/v1/chats/{chatId}/mute",
+			Handler:     handler.UnmuteChat,
+			RequireAuth: true,
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// PresenceHandler receives heartbeats and typing notifications from clients
+type PresenceHandler struct {
+	Presence   ports.PresenceTracker
+	ChatRepo   ports.ChatRepository
+	Authorizer *Authorizer
+	Logger     ports.Logger
+}
+
+func NewPresenceHandler(presence ports.PresenceTracker, chatRepo ports.ChatRepository, logger ports.Logger) *PresenceHandler {
+	return &PresenceHandler{
+		Presence:   presence,
+		ChatRepo:   chatRepo,
+		Authorizer: NewAuthorizer(chatRepo),
+		Logger:     logger,
+	}
+}
+
+// Heartbeat handles POST /api/v1/presence/heartbeat
+func (h *PresenceHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	if herr := h.heartbeat(r.Context(), user); herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Typing handles POST /api/v1/chats/{chatId}/typing
+func (h *PresenceHandler) Typing(w http.ResponseWriter, r *http.Request) {
+	// Extract chatId from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Missing chat ID", "MISSING_CHAT_ID", "chatId path parameter is required")
+		return
+	}
+	chatID := pathParts[3]
+
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	if herr := h.typing(r.Context(), user, chatID); herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// heartbeat keeps the user online; shared by the HTTP and WebSocket transports
+func (h *PresenceHandler) heartbeat(ctx context.Context, user domain.UserContext) *handlerError {
+	if err := h.Presence.Heartbeat(ctx, user.UserID); err != nil {
+		h.Logger.Error("Failed to record heartbeat", "error", err, "user", user.UserID)
+		return &handlerError{StatusCode: http.StatusInternalServerError, Message: "Failed to record heartbeat", Code: "HEARTBEAT_ERROR"}
+	}
+	return nil
+}
+
+// typing tells the other participants of the chat that the user is typing
+func (h *PresenceHandler) typing(ctx context.Context, user domain.UserContext, chatID string) *handlerError {
+	isParticipant, err := h.Authorizer.CanAccessChat(ctx, user.UserID, chatID)
+	if errors.Is(err, domain.ErrInvalidChatID) {
+		return &handlerError{StatusCode: http.StatusBadRequest, Message: "Invalid chat ID", Code: "INVALID_CHAT_ID", Details: err.Error()}
+	}
+	if err != nil {
+		h.Logger.Error("Failed to check chat participation", "error", err, "chat_id", chatID, "user", user.UserID)
+		return &handlerError{StatusCode: http.StatusInternalServerError, Message: "Failed to publish typing", Code: "TYPING_ERROR"}
+	}
+	if !isParticipant {
+		return &handlerError{StatusCode: http.StatusForbidden, Message: "Access denied", Code: "ACCESS_DENIED", Details: "User is not a participant in this chat"}
+	}
+
+	if err := h.Presence.Typing(ctx, user.UserID, chatID); err != nil {
+		h.Logger.Error("Failed to publish typing", "error", err, "chat_id", chatID, "user", user.UserID)
+		return &handlerError{StatusCode: http.StatusInternalServerError, Message: "Failed to publish typing", Code: "TYPING_ERROR"}
+	}
+
+	h.Logger.Debug("Typing published", "chat_id", chatID, "user", user.UserID)
+	return nil
+}
+
+func (h *PresenceHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message, code, details string) {
+	w.WriteHeader(statusCode)
+
+	response := httpAdapter.ErrorResponse{
+		Error:   message,
+		Code:    code,
+		Details: details,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to write error response", "error", err)
+	}
+}
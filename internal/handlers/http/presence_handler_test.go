@@ -0,0 +1,214 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PresenceHandlerTestSuite struct {
+	suite.Suite
+	handler      *PresenceHandler
+	mockPresence *mocks.PresenceTracker
+	mockChatRepo *mocks.ChatRepository
+	mockLogger   *mocks.Logger
+	helpers      *TestHelpers
+}
+
+func (s *PresenceHandlerTestSuite) SetupTest() {
+	s.mockPresence = &mocks.PresenceTracker{}
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.mockLogger = &mocks.Logger{}
+	s.handler = NewPresenceHandler(s.mockPresence, s.mockChatRepo, s.mockLogger)
+	s.helpers = NewTestHelpers(s.T())
+}
+
+func (s *PresenceHandlerTestSuite) TearDownTest() {
+	s.mockPresence.AssertExpectations(s.T())
+	s.mockChatRepo.AssertExpectations(s.T())
+	s.mockLogger.AssertExpectations(s.T())
+}
+
+// Heartbeat Tests
+
+func (s *PresenceHandlerTestSuite) TestHeartbeat_Success() {
+	alice := testdata.Alice
+
+	s.mockPresence.On("Heartbeat", mock.Anything, alice.UserID).Return(nil)
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/presence/heartbeat", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.Heartbeat(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+	s.Empty(recorder.Body.Bytes())
+}
+
+func (s *PresenceHandlerTestSuite) TestHeartbeat_NoUserContext() {
+	req := s.helpers.CreateRequestWithoutUser("POST", "/api/v1/presence/heartbeat", nil)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.Heartbeat(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT")
+}
+
+func (s *PresenceHandlerTestSuite) TestHeartbeat_TrackerError() {
+	alice := testdata.Alice
+
+	s.mockPresence.On("Heartbeat", mock.Anything, alice.UserID).Return(errors.New("database down"))
+	s.mockLogger.On("Error", "Failed to record heartbeat", "error", mock.Anything, "user", alice.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/presence/heartbeat", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.Heartbeat(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusInternalServerError, "Failed to record heartbeat", "HEARTBEAT_ERROR")
+}
+
+// Typing Tests
+
+func (s *PresenceHandlerTestSuite) TestTyping_DirectChat() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+
+	s.mockPresence.On("Typing", mock.Anything, alice.UserID, chatID).Return(nil)
+	s.mockLogger.On("Debug", "Typing published", "chat_id", chatID, "user", alice.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/chats/"+chatID+"/typing", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.Typing(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *PresenceHandlerTestSuite) TestTyping_DirectChatOfOthers() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(testdata.Bob.UserID, testdata.Charlie.UserID)
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/chats/"+chatID+"/typing", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.Typing(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusForbidden, "Access denied", "ACCESS_DENIED")
+}
+
+func (s *PresenceHandlerTestSuite) TestTyping_InvalidChatID() {
+	alice := testdata.Alice
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/chats/"+alice.UserID+"/typing", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.Typing(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID")
+}
+
+func (s *PresenceHandlerTestSuite) TestTyping_GroupMember() {
+	alice := testdata.Alice
+	chatID := domain.NewGroupChatID()
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, alice.UserID).Return(true, nil)
+	s.mockPresence.On("Typing", mock.Anything, alice.UserID, chatID).Return(nil)
+	s.mockLogger.On("Debug", "Typing published", "chat_id", chatID, "user", alice.UserID).Return()
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/chats/"+chatID+"/typing", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.Typing(recorder, req)
+
+	// Assertions
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *PresenceHandlerTestSuite) TestTyping_NotGroupMember() {
+	alice := testdata.Alice
+	chatID := domain.NewGroupChatID()
+
+	s.mockChatRepo.On("IsMember", mock.Anything, chatID, alice.UserID).Return(false, nil)
+
+	req := s.helpers.CreateRequestWithUser("POST", "/api/v1/chats/"+chatID+"/typing", nil, alice)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.Typing(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusForbidden, "Access denied", "ACCESS_DENIED")
+}
+
+// WebSocket command Tests
+
+func (s *PresenceHandlerTestSuite) TestHeartbeatCommand_Success() {
+	alice := testdata.Alice
+
+	s.mockPresence.On("Heartbeat", mock.Anything, alice.UserID).Return(nil)
+
+	// Execute
+	result, err := s.handler.HeartbeatCommand(context.Background(), alice, nil)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(HeartbeatResponse{Status: domain.StatusOnline}, result)
+}
+
+func (s *PresenceHandlerTestSuite) TestTypingCommand_Success() {
+	alice := testdata.Alice
+	chatID := domain.ComputeChatID(alice.UserID, testdata.Bob.UserID)
+	data, _ := json.Marshal(TypingCommand{ChatID: chatID})
+
+	s.mockPresence.On("Typing", mock.Anything, alice.UserID, chatID).Return(nil)
+	s.mockLogger.On("Debug", "Typing published", "chat_id", chatID, "user", alice.UserID).Return()
+
+	// Execute
+	result, err := s.handler.TypingCommand(context.Background(), alice, data)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(TypingResponse{ChatID: chatID}, result)
+}
+
+func (s *PresenceHandlerTestSuite) TestTypingCommand_MissingChatID() {
+	data, _ := json.Marshal(TypingCommand{})
+
+	// Execute
+	result, err := s.handler.TypingCommand(context.Background(), testdata.Alice, data)
+
+	// Assertions
+	s.Nil(result)
+	var cmdErr *httpAdapter.CommandError
+	s.ErrorAs(err, &cmdErr)
+	s.Equal("MISSING_CHAT_ID", cmdErr.Code)
+}
+
+func TestPresenceHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PresenceHandlerTestSuite))
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/ports"
+)
+
+// Patterns of the routes whose operations are also WebSocket commands
+const (
+	heartbeatPattern = "/api/v1/presence/heartbeat"
+	typingPattern    = "/api/v1/chats/{chatId}/typing"
+)
+
+type PresenceRoutes struct {
+	presence ports.PresenceTracker
+	chatRepo ports.ChatRepository
+	logger   ports.Logger
+}
+
+func NewPresenceRoutes(presence ports.PresenceTracker, chatRepo ports.ChatRepository, logger ports.Logger) *PresenceRoutes {
+	return &PresenceRoutes{
+		presence: presence,
+		chatRepo: chatRepo,
+		logger:   logger,
+	}
+}
+
+func (pr *PresenceRoutes) GetRoutes() []httpAdapter.Route {
+	handler := NewPresenceHandler(pr.presence, pr.chatRepo, pr.logger)
+
+	return []httpAdapter.Route{
+		{
+			Method:      "POST",
+			Pattern:     heartbeatPattern,
+			Handler:     handler.Heartbeat,
+			RequireAuth: true,
+		},
+		{
+			Method:      "POST",
+			Pattern:     typingPattern,
+			Handler:     handler.Typing,
+			RequireAuth: true,
+		},
+	}
+}
@@ -12,23 +12,23 @@ import (
 
 type RoutesTestSuite struct {
 	suite.Suite
-	mockRepo      *mocks.MessageRepository
-	mockPublisher *mocks.MessagePublisher
-	mockLogger    *mocks.Logger
+	mockRepo     *mocks.MessageRepository
+	mockChatRepo *mocks.ChatRepository
+	mockLogger   *mocks.Logger
 }
 
 func (s *RoutesTestSuite) SetupTest() {
 	s.mockRepo = &mocks.MessageRepository{}
-	s.mockPublisher = &mocks.MessagePublisher{}
+	s.mockChatRepo = &mocks.ChatRepository{}
 	s.mockLogger = &mocks.Logger{}
 }
 
 func (s *RoutesTestSuite) TestMessageRoutes_GetRoutes() {
-	messageRoutes := NewMessageRoutes(s.mockRepo, s.mockPublisher, s.mockLogger)
+	messageRoutes := NewMessageRoutes(DefaultMessageConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, s.mockLogger)
 	routes := messageRoutes.GetRoutes()
 
 	// Verify we have the expected number of routes
-	s.Len(routes, 3)
+	s.Len(routes, 8)
 
 	// Create a map for easier lookup
 	routeMap := make(map[string]httpAdapter.Route)
@@ -44,6 +44,7 @@ func (s *RoutesTestSuite) TestMessageRoutes_GetRoutes() {
 	s.Equal("/api/v1/chats/{receiverId}/messages", sendRoute.Pattern)
 	s.True(sendRoute.RequireAuth)
 	s.NotNil(sendRoute.Handler)
+	s.Equal(DefaultMessageConfig().SendRateLimit, sendRoute.RateLimit)
 
 	// Verify GetMessages route
 	getRoute, exists := routeMap["GET /api/v1/chats/{chatId}/messages"]
@@ -60,6 +61,25 @@ func (s *RoutesTestSuite) TestMessageRoutes_GetRoutes() {
 	s.Equal("/api/v1/messages/status", updateRoute.Pattern)
 	s.True(updateRoute.RequireAuth)
 	s.NotNil(updateRoute.Handler)
+
+	// Verify MarkMessagesDelivered route
+	deliveredRoute, exists := routeMap["PATCH /api/v1/messages/delivered"]
+	s.True(exists, "MarkMessagesDelivered route should exist")
+	s.True(deliveredRoute.RequireAuth)
+	s.NotNil(deliveredRoute.Handler)
+
+	// Verify EditMessage, DeleteMessage and reaction routes
+	for _, key := range []string{
+		"PATCH /api/v1/messages/{messageId}",
+		"DELETE /api/v1/messages/{messageId}",
+		"POST /api/v1/messages/{messageId}/reactions",
+		"DELETE /api/v1/messages/{messageId}/reactions",
+	} {
+		route, exists := routeMap[key]
+		s.True(exists, "%s route should exist", key)
+		s.True(route.RequireAuth)
+		s.NotNil(route.Handler)
+	}
 }
 
 func (s *RoutesTestSuite) TestChatRoutes_GetRoutes() {
@@ -78,8 +98,120 @@ func (s *RoutesTestSuite) TestChatRoutes_GetRoutes() {
 	s.NotNil(route.Handler)
 }
 
+func (s *RoutesTestSuite) TestGroupRoutes_GetRoutes() {
+	groupRoutes := NewGroupRoutes(s.mockChatRepo, s.mockLogger)
+	routes := groupRoutes.GetRoutes()
+
+	s.Len(routes, 4)
+
+	routeMap := make(map[string]httpAdapter.Route)
+	for _, route := range routes {
+		routeMap[route.Method+" "+route.Pattern] = route
+	}
+
+	for _, key := range []string{
+		"POST /api/v1/groups",
+		"GET /api/v1/groups/{chatId}",
+		"POST /api/v1/groups/{chatId}/members",
+		"DELETE /api/v1/groups/{chatId}/members/{userId}",
+	} {
+		route, exists := routeMap[key]
+		s.True(exists, "%s route should exist", key)
+		s.True(route.RequireAuth)
+		s.NotNil(route.Handler)
+	}
+}
+
+func (s *RoutesTestSuite) TestPresenceRoutes_GetRoutes() {
+	presenceRoutes := NewPresenceRoutes(&mocks.PresenceTracker{}, s.mockChatRepo, s.mockLogger)
+	routes := presenceRoutes.GetRoutes()
+
+	s.Len(routes, 2)
+
+	routeMap := make(map[string]httpAdapter.Route)
+	for _, route := range routes {
+		routeMap[route.Method+" "+route.Pattern] = route
+	}
+
+	for _, key := range []string{
+		"POST /api/v1/presence/heartbeat",
+		"POST /api/v1/chats/{chatId}/typing",
+	} {
+		route, exists := routeMap[key]
+		s.True(exists, "%s route should exist", key)
+		s.True(route.RequireAuth)
+		s.NotNil(route.Handler)
+	}
+}
+
+func (s *RoutesTestSuite) TestAttachmentRoutes_GetRoutes() {
+	attachmentRoutes := NewAttachmentRoutes(DefaultAttachmentConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, s.mockLogger)
+	routes := attachmentRoutes.GetRoutes()
+
+	s.Len(routes, 2)
+
+	routeMap := make(map[string]httpAdapter.Route)
+	for _, route := range routes {
+		routeMap[route.Method+" "+route.Pattern] = route
+	}
+
+	for _, key := range []string{
+		"POST /api/v1/chats/{receiverId}/attachments",
+		"GET /api/v1/attachments/{attachmentId}",
+	} {
+		route, exists := routeMap[key]
+		s.True(exists, "%s route should exist", key)
+		s.True(route.RequireAuth)
+		s.NotNil(route.Handler)
+	}
+
+	// Uploads have their own rate limit, downloads use the server default
+	s.Equal(DefaultAttachmentConfig().UploadRateLimit, routeMap["POST /api/v1/chats/{receiverId}/attachments"].RateLimit)
+	s.True(routeMap["GET /api/v1/attachments/{attachmentId}"].RateLimit.IsZero())
+}
+
+func (s *RoutesTestSuite) TestSearchRoutes_GetRoutes() {
+	routes := NewSearchRoutes(s.mockRepo, s.mockChatRepo, s.mockLogger).GetRoutes()
+
+	s.Require().Len(routes, 1)
+	s.Equal("GET", routes[0].Method)
+	s.Equal("/api/v1/search", routes[0].Pattern)
+	s.True(routes[0].RequireAuth)
+	s.NotNil(routes[0].Handler)
+}
+
+func (s *RoutesTestSuite) TestBlockRoutes_GetRoutes() {
+	routes := NewBlockRoutes(&mocks.BlockRepository{}, s.mockLogger).GetRoutes()
+
+	s.Require().Len(routes, 3)
+	s.Equal("GET", routes[0].Method)
+	s.Equal("/api/v1/users/me/blocks", routes[0].Pattern)
+	s.Equal("POST", routes[1].Method)
+	s.Equal("/api/v1/users/me/blocks", routes[1].Pattern)
+	s.Equal("DELETE", routes[2].Method)
+	s.Equal("/api/v1/users/me/blocks/{userId}", routes[2].Pattern)
+	for _, route := range routes {
+		s.True(route.RequireAuth)
+		s.NotNil(route.Handler)
+	}
+}
+
+func (s *RoutesTestSuite) TestMuteRoutes_GetRoutes() {
+	routes := NewMuteRoutes(s.mockChatRepo, s.mockLogger).GetRoutes()
+
+	s.Require().Len(routes, 2)
+	s.Equal("PUT", routes[0].Method)
+	s.Equal("/api/v1/chats/{chatId}/mute", routes[0].Pattern)
+	s.Equal("DELETE", routes[1].Method)
+	s.Equal("/api/v1/chats/{chatId}/mute", routes[1].Pattern)
+	for _, route := range routes {
+		s.True(route.RequireAuth)
+		s.NotNil(route.Handler)
+	}
+}
+
 func (s *RoutesTestSuite) TestMessageRoutes_AllRoutesRequireAuth() {
-	messageRoutes := NewMessageRoutes(s.mockRepo, s.mockPublisher, s.mockLogger)
+	messageRoutes := NewMessageRoutes(DefaultMessageConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, s.mockLogger)
 	routes := messageRoutes.GetRoutes()
 
 	for _, route := range routes {
@@ -97,7 +229,7 @@ func (s *RoutesTestSuite) TestChatRoutes_AllRoutesRequireAuth() {
 }
 
 func (s *RoutesTestSuite) TestMessageRoutes_HandlerNotNil() {
-	messageRoutes := NewMessageRoutes(s.mockRepo, s.mockPublisher, s.mockLogger)
+	messageRoutes := NewMessageRoutes(DefaultMessageConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, s.mockLogger)
 	routes := messageRoutes.GetRoutes()
 
 	for _, route := range routes {
@@ -115,10 +247,22 @@ func (s *RoutesTestSuite) TestChatRoutes_HandlerNotNil() {
 }
 
 func (s *RoutesTestSuite) TestRoutePatterns_FollowAPIConvention() {
-	messageRoutes := NewMessageRoutes(s.mockRepo, s.mockPublisher, s.mockLogger)
+	messageRoutes := NewMessageRoutes(DefaultMessageConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, s.mockLogger)
 	chatRoutes := NewChatRoutes(s.mockRepo, s.mockLogger)
+	groupRoutes := NewGroupRoutes(s.mockChatRepo, s.mockLogger)
+	presenceRoutes := NewPresenceRoutes(&mocks.PresenceTracker{}, s.mockChatRepo, s.mockLogger)
+	attachmentRoutes := NewAttachmentRoutes(DefaultAttachmentConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, s.mockLogger)
+	searchRoutes := NewSearchRoutes(s.mockRepo, s.mockChatRepo, s.mockLogger)
+	blockRoutes := NewBlockRoutes(&mocks.BlockRepository{}, s.mockLogger)
+	muteRoutes := NewMuteRoutes(s.mockChatRepo, s.mockLogger)
 
 	allRoutes := append(messageRoutes.GetRoutes(), chatRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, groupRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, presenceRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, attachmentRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, searchRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, blockRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, muteRoutes.GetRoutes()...)
 
 	for _, route := range allRoutes {
 		// All routes should start with /api/v1
@@ -128,10 +272,22 @@ func (s *RoutesTestSuite) TestRoutePatterns_FollowAPIConvention() {
 }
 
 func (s *RoutesTestSuite) TestHTTPMethods_Valid() {
-	messageRoutes := NewMessageRoutes(s.mockRepo, s.mockPublisher, s.mockLogger)
+	messageRoutes := NewMessageRoutes(DefaultMessageConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, s.mockLogger)
 	chatRoutes := NewChatRoutes(s.mockRepo, s.mockLogger)
+	groupRoutes := NewGroupRoutes(s.mockChatRepo, s.mockLogger)
+	presenceRoutes := NewPresenceRoutes(&mocks.PresenceTracker{}, s.mockChatRepo, s.mockLogger)
+	attachmentRoutes := NewAttachmentRoutes(DefaultAttachmentConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, s.mockLogger)
+	searchRoutes := NewSearchRoutes(s.mockRepo, s.mockChatRepo, s.mockLogger)
+	blockRoutes := NewBlockRoutes(&mocks.BlockRepository{}, s.mockLogger)
+	muteRoutes := NewMuteRoutes(s.mockChatRepo, s.mockLogger)
 
 	allRoutes := append(messageRoutes.GetRoutes(), chatRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, groupRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, presenceRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, attachmentRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, searchRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, blockRoutes.GetRoutes()...)
+	allRoutes = append(allRoutes, muteRoutes.GetRoutes()...)
 	validMethods := map[string]bool{
 		"GET":    true,
 		"POST":   true,
@@ -148,9 +304,13 @@ func (s *RoutesTestSuite) TestHTTPMethods_Valid() {
 // Test that we can create route structures without panics
 func (s *RoutesTestSuite) TestRouteCreation_NoPanics() {
 	s.NotPanics(func() {
-		NewMessageRoutes(s.mockRepo, s.mockPublisher, s.mockLogger)
+		NewMessageRoutes(DefaultMessageConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, s.mockLogger)
 	}, "Creating MessageRoutes should not panic")
 
+	s.NotPanics(func() {
+		NewGroupRoutes(s.mockChatRepo, s.mockLogger)
+	}, "Creating GroupRoutes should not panic")
+
 	s.NotPanics(func() {
 		NewChatRoutes(s.mockRepo, s.mockLogger)
 	}, "Creating ChatRoutes should not panic")
@@ -158,7 +318,7 @@ func (s *RoutesTestSuite) TestRouteCreation_NoPanics() {
 
 // Test route patterns for consistency
 func (s *RoutesTestSuite) TestRoutePatterns_Consistency() {
-	messageRoutes := NewMessageRoutes(s.mockRepo, s.mockPublisher, s.mockLogger)
+	messageRoutes := NewMessageRoutes(DefaultMessageConfig(), s.mockRepo, s.mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, s.mockLogger)
 	routes := messageRoutes.GetRoutes()
 
 	// Check that chat-related routes use consistent path structure
@@ -179,14 +339,15 @@ func TestRoutesSuite(t *testing.T) {
 // Additional unit tests for specific route components
 func TestNewMessageRoutes(t *testing.T) {
 	mockRepo := &mocks.MessageRepository{}
-	mockPublisher := &mocks.MessagePublisher{}
+	mockChatRepo := &mocks.ChatRepository{}
 	mockLogger := &mocks.Logger{}
 
-	routes := NewMessageRoutes(mockRepo, mockPublisher, mockLogger)
+	routes := NewMessageRoutes(DefaultMessageConfig(), mockRepo, mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, mockLogger)
 
 	assert.NotNil(t, routes)
+	assert.Equal(t, DefaultMessageConfig(), routes.config)
 	assert.Equal(t, mockRepo, routes.messageRepo)
-	assert.Equal(t, mockPublisher, routes.publisher)
+	assert.Equal(t, mockChatRepo, routes.chatRepo)
 	assert.Equal(t, mockLogger, routes.logger)
 }
 
@@ -203,14 +364,14 @@ func TestNewChatRoutes(t *testing.T) {
 
 func TestMessageHandler_Creation(t *testing.T) {
 	mockRepo := &mocks.MessageRepository{}
-	mockPublisher := &mocks.MessagePublisher{}
+	mockChatRepo := &mocks.ChatRepository{}
 	mockLogger := &mocks.Logger{}
 
-	handler := NewMessageHandler(mockRepo, mockPublisher, mockLogger)
+	handler := NewMessageHandler(DefaultMessageConfig(), mockRepo, mockChatRepo, &mocks.BlobStore{}, &mocks.Metrics{}, mockLogger)
 
 	assert.NotNil(t, handler)
 	assert.Equal(t, mockRepo, handler.MessageRepo)
-	assert.Equal(t, mockPublisher, handler.Publisher)
+	assert.Equal(t, mockChatRepo, handler.ChatRepo)
 	assert.Equal(t, mockLogger, handler.Logger)
 }
 
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/ports"
+)
+
+// SearchHandler handles message search requests
+type SearchHandler struct {
+	MessageRepo ports.MessageRepository
+	ChatRepo    ports.ChatRepository
+	Authorizer  *Authorizer
+	Logger      ports.Logger
+}
+
+func NewSearchHandler(messageRepo ports.MessageRepository, chatRepo ports.ChatRepository, logger ports.Logger) *SearchHandler {
+	return &SearchHandler{
+		MessageRepo: messageRepo,
+		ChatRepo:    chatRepo,
+		Authorizer:  NewAuthorizer(chatRepo),
+		Logger:      logger,
+	}
+}
+
+// SearchMessages handles GET /api/v1/search
+func (h *SearchHandler) SearchMessages(w http.ResponseWriter, r *http.Request) {
+	user, ok := httpAdapter.GetUserFromContext(r.Context())
+	if !ok {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT", "")
+		return
+	}
+
+	search, herr := parseMessageSearch(r.URL.Query())
+	if herr != nil {
+		h.writeErrorResponse(w, herr.StatusCode, herr.Message, herr.Code, herr.Details)
+		return
+	}
+	search.UserID = user.UserID
+
+	// Without a chat filter the repository only searches the user's own chats
+	if search.ChatID != "" {
+		isParticipant, err := h.Authorizer.CanAccessChat(r.Context(), user.UserID, search.ChatID)
+		if errors.Is(err, domain.ErrInvalidChatID) {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid chat ID", "INVALID_CHAT_ID", err.Error())
+			return
+		}
+		if err != nil {
+			h.Logger.Error("Failed to check chat participation", "error", err, "chat_id", search.ChatID, "user", user.UserID)
+			h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to search messages", "SEARCH_ERROR", "")
+			return
+		}
+		if !isParticipant {
+			h.writeErrorResponse(w, http.StatusForbidden, "Access denied", "ACCESS_DENIED", "User is not a participant in this chat")
+			return
+		}
+	}
+
+	result, err := h.MessageRepo.SearchMessages(r.Context(), search)
+	if err != nil {
+		h.Logger.Error("Failed to search messages", "error", err, "user", user.UserID)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to search messages", "SEARCH_ERROR", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SearchResponse(result))
+
+	h.Logger.Debug("Messages searched successfully", "user", user.UserID, "count", len(result.Hits))
+}
+
+// parseMessageSearch reads the search text, filters and page from the query string
+// Dates are RFC3339 timestamps; from is inclusive and to exclusive
+func parseMessageSearch(query url.Values) (domain.MessageSearch, *handlerError) {
+	search := domain.MessageSearch{
+		Text:     query.Get("q"),
+		ChatID:   query.Get("chat_id"),
+		SenderID: query.Get("sender_id"),
+		Limit:    20, // Default limit
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			return search, &handlerError{http.StatusBadRequest, "Invalid limit", "INVALID_LIMIT", "Limit must be between 1 and 100"}
+		}
+		search.Limit = limit
+	}
+
+	for _, bound := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &search.From}, {"to", &search.To}} {
+		value := query.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return search, &handlerError{http.StatusBadRequest, "Invalid date", "INVALID_DATE", bound.name + " must be an RFC3339 timestamp"}
+		}
+		*bound.target = parsed.UTC()
+	}
+
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		cursor, err := domain.ParseSearchCursor(cursorStr)
+		if err != nil {
+			return search, &handlerError{http.StatusBadRequest, "Invalid cursor format", "INVALID_CURSOR", "Cursor must be a next_cursor value returned by a previous page"}
+		}
+		search.Cursor = cursor
+	}
+
+	if err := search.Validate(); err != nil {
+		return search, &handlerError{http.StatusBadRequest, "Validation failed", "VALIDATION_ERROR", "q must be 1 to " + strconv.Itoa(domain.MaxSearchQueryLength) + " characters and from must be before to"}
+	}
+
+	return search, nil
+}
+
+func (h *SearchHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message, code, details string) {
+	w.WriteHeader(statusCode)
+
+	response := httpAdapter.ErrorResponse{
+		Error:   message,
+		Code:    code,
+		Details: details,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.Logger.Error("Failed to write error response", "error", err)
+	}
+}
@@ -0,0 +1,200 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/internal/mocks"
+	"messaging-app/internal/ports"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SearchHandlerTestSuite struct {
+	suite.Suite
+	handler      *SearchHandler
+	mockRepo     *mocks.MessageRepository
+	mockChatRepo *mocks.ChatRepository
+	mockLogger   *mocks.Logger
+	helpers      *TestHelpers
+}
+
+func (s *SearchHandlerTestSuite) SetupTest() {
+	s.mockRepo = &mocks.MessageRepository{}
+	s.mockChatRepo = &mocks.ChatRepository{}
+	s.mockLogger = &mocks.Logger{}
+	s.handler = NewSearchHandler(s.mockRepo, s.mockChatRepo, s.mockLogger)
+	s.helpers = NewTestHelpers(s.T())
+}
+
+func (s *SearchHandlerTestSuite) TearDownTest() {
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockChatRepo.AssertExpectations(s.T())
+	s.mockLogger.AssertExpectations(s.T())
+}
+
+func (s *SearchHandlerTestSuite) searchRequest(params url.Values, user domain.UserContext) *http.Request {
+	return s.helpers.CreateRequestWithUser("GET", "/api/v1/search?"+params.Encode(), nil, user)
+}
+
+func (s *SearchHandlerTestSuite) TestSearchMessages_AllChats() {
+	alice := testdata.Alice
+	message := recentMessage()
+	hit := domain.SearchHit{Message: message, ChatID: message.GetChatID(), Rank: 0.5, Snippet: "<mark>lunch</mark> tomorrow"}
+
+	s.mockRepo.On("SearchMessages", mock.Anything, domain.MessageSearch{UserID: alice.UserID, Text: "lunch", Limit: 20}).
+		Return(ports.SearchResult{Hits: []domain.SearchHit{hit}, HasMore: true, NextCursor: hit.Cursor().Encode()}, nil)
+	s.mockLogger.On("Debug", "Messages searched successfully", "user", alice.UserID, "count", 1).Return()
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SearchMessages(recorder, s.searchRequest(url.Values{"q": {"lunch"}}, alice))
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response SearchResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Require().Len(response.Hits, 1)
+	s.Equal(message.ID, response.Hits[0].Message.ID)
+	s.Equal(hit.Snippet, response.Hits[0].Snippet)
+	s.True(response.HasMore)
+
+	cursor, err := domain.ParseSearchCursor(response.NextCursor)
+	s.NoError(err)
+	s.Equal(hit.Cursor().ID, cursor.ID)
+	s.Equal(0.5, cursor.Rank)
+}
+
+func (s *SearchHandlerTestSuite) TestSearchMessages_Filters() {
+	alice := testdata.Alice
+	groupID := domain.NewGroupChatID()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	s.mockChatRepo.On("IsMember", mock.Anything, groupID, alice.UserID).Return(true, nil)
+	s.mockRepo.On("SearchMessages", mock.Anything, domain.MessageSearch{
+		UserID:   alice.UserID,
+		Text:     "report",
+		ChatID:   groupID,
+		SenderID: testdata.Bob.UserID,
+		From:     from,
+		To:       to,
+		Limit:    5,
+	}).Return(ports.SearchResult{Hits: []domain.SearchHit{}}, nil)
+	s.mockLogger.On("Debug", "Messages searched successfully", "user", alice.UserID, "count", 0).Return()
+
+	params := url.Values{
+		"q":         {"report"},
+		"chat_id":   {groupID},
+		"sender_id": {testdata.Bob.UserID},
+		"from":      {from.Format(time.RFC3339)},
+		"to":        {to.Format(time.RFC3339)},
+		"limit":     {"5"},
+	}
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SearchMessages(recorder, s.searchRequest(params, alice))
+
+	// Assertions
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *SearchHandlerTestSuite) TestSearchMessages_NotParticipant() {
+	chatID := domain.ComputeChatID(testdata.Bob.UserID, testdata.Charlie.UserID)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SearchMessages(recorder, s.searchRequest(url.Values{"q": {"secret"}, "chat_id": {chatID}}, testdata.Alice))
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusForbidden, "Access denied", "ACCESS_DENIED")
+}
+
+func (s *SearchHandlerTestSuite) TestSearchMessages_ParticipantByNameOverlap() {
+	// A user ID contained in another one does not make its owner a participant
+	chatID := domain.ComputeChatID(testdata.Alice.UserID+"x", testdata.Bob.UserID)
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SearchMessages(recorder, s.searchRequest(url.Values{"q": {"secret"}, "chat_id": {chatID}}, testdata.Alice))
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusForbidden, "Access denied", "ACCESS_DENIED")
+}
+
+func (s *SearchHandlerTestSuite) TestSearchMessages_InvalidParameters() {
+	tests := []struct {
+		name   string
+		params url.Values
+		code   string
+	}{
+		{"missing query", url.Values{}, "VALIDATION_ERROR"},
+		{"blank query", url.Values{"q": {"   "}}, "VALIDATION_ERROR"},
+		{"long query", url.Values{"q": {strings.Repeat("a", domain.MaxSearchQueryLength+1)}}, "VALIDATION_ERROR"},
+		{"bad limit", url.Values{"q": {"a"}, "limit": {"101"}}, "INVALID_LIMIT"},
+		{"bad date", url.Values{"q": {"a"}, "from": {"yesterday"}}, "INVALID_DATE"},
+		{"empty range", url.Values{"q": {"a"}, "from": {"2024-02-01T00:00:00Z"}, "to": {"2024-01-01T00:00:00Z"}}, "VALIDATION_ERROR"},
+		{"bad cursor", url.Values{"q": {"a"}, "cursor": {"nope"}}, "INVALID_CURSOR"},
+		{"bad chat", url.Values{"q": {"a"}, "chat_id": {"nope"}}, "INVALID_CHAT_ID"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := httptest.NewRecorder()
+
+			// Execute
+			s.handler.SearchMessages(recorder, s.searchRequest(tt.params, testdata.Alice))
+
+			// Assertions
+			var errorResp httpAdapter.ErrorResponse
+			s.Equal(http.StatusBadRequest, recorder.Code)
+			s.NoError(json.Unmarshal(recorder.Body.Bytes(), &errorResp))
+			s.Equal(tt.code, errorResp.Code)
+		})
+	}
+}
+
+func (s *SearchHandlerTestSuite) TestSearchMessages_RepositoryError() {
+	alice := testdata.Alice
+
+	s.mockRepo.On("SearchMessages", mock.Anything, mock.Anything).Return(ports.SearchResult{}, errors.New("database down"))
+	s.mockLogger.On("Error", "Failed to search messages", "error", mock.Anything, "user", alice.UserID).Return()
+
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SearchMessages(recorder, s.searchRequest(url.Values{"q": {"lunch"}}, alice))
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusInternalServerError, "Failed to search messages", "SEARCH_ERROR")
+}
+
+func (s *SearchHandlerTestSuite) TestSearchMessages_NoUserContext() {
+	req := s.helpers.CreateRequestWithoutUser("GET", "/api/v1/search?q=lunch", nil)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	s.handler.SearchMessages(recorder, req)
+
+	// Assertions
+	s.helpers.AssertErrorResponse(recorder, http.StatusUnauthorized, "User context not found", "NO_USER_CONTEXT")
+}
+
+func TestSearchHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SearchHandlerTestSuite))
+}
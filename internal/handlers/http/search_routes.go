@@ -0,0 +1,33 @@
+package http
+
+import (
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/ports"
+)
+
+type SearchRoutes struct {
+	messageRepo ports.MessageRepository
+	chatRepo    ports.ChatRepository
+	logger      ports.Logger
+}
+
+func NewSearchRoutes(messageRepo ports.MessageRepository, chatRepo ports.ChatRepository, logger ports.Logger) *SearchRoutes {
+	return &SearchRoutes{
+		messageRepo: messageRepo,
+		chatRepo:    chatRepo,
+		logger:      logger,
+	}
+}
+
+func (sr *SearchRoutes) GetRoutes() []httpAdapter.Route {
+	handler := NewSearchHandler(sr.messageRepo, sr.chatRepo, sr.logger)
+
+	return []httpAdapter.Route{
+		{
+			Method:      "GET",
+			Pattern:     "/api/v1/search",
+			Handler:     handler.SearchMessages,
+			RequireAuth: true,
+		},
+	}
+}
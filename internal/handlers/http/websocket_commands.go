@@ -0,0 +1,180 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+)
+
+const (
+	CommandSendMessage    = "send_message"
+	CommandMarkRead       = "mark_read"
+	CommandMarkDelivered  = "mark_delivered"
+	CommandEditMessage    = "edit_message"
+	CommandDeleteMessage  = "delete_message"
+	CommandAddReaction    = "add_reaction"
+	CommandRemoveReaction = "remove_reaction"
+	CommandHeartbeat      = "heartbeat"
+	CommandTyping         = "typing"
+)
+
+// RegisterCommands exposes the message operations as WebSocket commands
+// Each command is rate limited together with the route doing the same over HTTP
+func (h *MessageHandler) RegisterCommands(gateway *httpAdapter.WebSocketGateway) {
+	gateway.HandleCommand(CommandSendMessage, httpAdapter.Route{Method: "POST", Pattern: sendMessagePattern, RateLimit: h.Config.SendRateLimit}, h.SendMessageCommand)
+	gateway.HandleCommand(CommandMarkRead, httpAdapter.Route{Method: "PATCH", Pattern: messageStatusPattern}, h.MarkReadCommand)
+	gateway.HandleCommand(CommandMarkDelivered, httpAdapter.Route{Method: "PATCH", Pattern: messageDeliveredPattern}, h.MarkDeliveredCommand)
+	gateway.HandleCommand(CommandEditMessage, httpAdapter.Route{Method: "PATCH", Pattern: messagePattern}, h.EditMessageCommand)
+	gateway.HandleCommand(CommandDeleteMessage, httpAdapter.Route{Method: "DELETE", Pattern: messagePattern}, h.DeleteMessageCommand)
+	gateway.HandleCommand(CommandAddReaction, httpAdapter.Route{Method: "POST", Pattern: reactionsPattern}, h.AddReactionCommand)
+	gateway.HandleCommand(CommandRemoveReaction, httpAdapter.Route{Method: "DELETE", Pattern: reactionsPattern}, h.RemoveReactionCommand)
+}
+
+// SendMessageCommand handles the send_message WebSocket command
+func (h *MessageHandler) SendMessageCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	var cmd SendMessageCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return nil, &httpAdapter.CommandError{Message: "Invalid JSON", Code: "INVALID_JSON", Details: err.Error()}
+	}
+
+	receiverID := strings.TrimSpace(cmd.ReceiverID)
+	if receiverID == "" {
+		return nil, &httpAdapter.CommandError{Message: "Missing receiver ID", Code: "MISSING_RECEIVER_ID", Details: "receiver_id is required"}
+	}
+
+	message, _, herr := h.sendMessage(ctx, user, receiverID, cmd.SendMessageRequest)
+	if herr != nil {
+		return nil, herr.commandError()
+	}
+
+	h.Logger.Debug("Message sent successfully", "sender", user.UserID, "receiver", receiverID)
+	return newSendMessageResponse(message), nil
+}
+
+// MarkReadCommand handles the mark_read WebSocket command
+func (h *MessageHandler) MarkReadCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	return h.statusCommand(ctx, user, data, domain.MessageStatusRead)
+}
+
+// MarkDeliveredCommand handles the mark_delivered WebSocket command
+func (h *MessageHandler) MarkDeliveredCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	return h.statusCommand(ctx, user, data, domain.MessageStatusDelivered)
+}
+
+func (h *MessageHandler) statusCommand(ctx context.Context, user domain.UserContext, data json.RawMessage, status string) (interface{}, error) {
+	var req UpdateStatusRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, &httpAdapter.CommandError{Message: "Invalid JSON", Code: "INVALID_JSON", Details: err.Error()}
+	}
+
+	affected, herr := h.updateMessageStatus(ctx, user, req, status)
+	if herr != nil {
+		return nil, herr.commandError()
+	}
+
+	h.Logger.Debug("Message status updated successfully", "user", user.UserID, "count", affected, "status", status)
+	return UpdateStatusResponse{UpdatedCount: affected}, nil
+}
+
+// EditMessageCommand handles the edit_message WebSocket command
+func (h *MessageHandler) EditMessageCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	var cmd EditMessageCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return nil, &httpAdapter.CommandError{Message: "Invalid JSON", Code: "INVALID_JSON", Details: err.Error()}
+	}
+
+	message, herr := h.editMessage(ctx, user, strings.TrimSpace(cmd.MessageID), cmd.EditMessageRequest)
+	if herr != nil {
+		return nil, herr.commandError()
+	}
+
+	h.Logger.Debug("Message edited successfully", "message_id", message.ID, "user", user.UserID)
+	return newSendMessageResponse(message), nil
+}
+
+// DeleteMessageCommand handles the delete_message WebSocket command
+func (h *MessageHandler) DeleteMessageCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	var cmd DeleteMessageCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return nil, &httpAdapter.CommandError{Message: "Invalid JSON", Code: "INVALID_JSON", Details: err.Error()}
+	}
+
+	if cmd.Scope == "" {
+		cmd.Scope = domain.DeleteForMe
+	}
+
+	messageID := strings.TrimSpace(cmd.MessageID)
+	if herr := h.deleteMessage(ctx, user, messageID, cmd.Scope); herr != nil {
+		return nil, herr.commandError()
+	}
+
+	h.Logger.Debug("Message deleted successfully", "message_id", messageID, "user", user.UserID, "scope", cmd.Scope)
+	return DeleteMessageResponse{MessageID: messageID, Scope: cmd.Scope}, nil
+}
+
+// AddReactionCommand handles the add_reaction WebSocket command
+func (h *MessageHandler) AddReactionCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	var cmd AddReactionCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return nil, &httpAdapter.CommandError{Message: "Invalid JSON", Code: "INVALID_JSON", Details: err.Error()}
+	}
+
+	reaction, herr := h.addReaction(ctx, user, strings.TrimSpace(cmd.MessageID), cmd.AddReactionRequest)
+	if herr != nil {
+		return nil, herr.commandError()
+	}
+
+	h.Logger.Debug("Reaction added successfully", "message_id", reaction.MessageID, "user", user.UserID)
+	return ReactionResponse(reaction), nil
+}
+
+// RemoveReactionCommand handles the remove_reaction WebSocket command
+func (h *MessageHandler) RemoveReactionCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	var cmd RemoveReactionCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return nil, &httpAdapter.CommandError{Message: "Invalid JSON", Code: "INVALID_JSON", Details: err.Error()}
+	}
+
+	messageID := strings.TrimSpace(cmd.MessageID)
+	if herr := h.removeReaction(ctx, user, messageID); herr != nil {
+		return nil, herr.commandError()
+	}
+
+	h.Logger.Debug("Reaction removed successfully", "message_id", messageID, "user", user.UserID)
+	return RemoveReactionResponse{MessageID: messageID}, nil
+}
+
+// RegisterCommands exposes heartbeats and typing notifications as WebSocket commands
+func (h *PresenceHandler) RegisterCommands(gateway *httpAdapter.WebSocketGateway) {
+	gateway.HandleCommand(CommandHeartbeat, httpAdapter.Route{Method: "POST", Pattern: heartbeatPattern}, h.HeartbeatCommand)
+	gateway.HandleCommand(CommandTyping, httpAdapter.Route{Method: "POST", Pattern: typingPattern}, h.TypingCommand)
+}
+
+// HeartbeatCommand handles the heartbeat WebSocket command
+func (h *PresenceHandler) HeartbeatCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	if herr := h.heartbeat(ctx, user); herr != nil {
+		return nil, herr.commandError()
+	}
+	return HeartbeatResponse{Status: domain.StatusOnline}, nil
+}
+
+// TypingCommand handles the typing WebSocket command
+func (h *PresenceHandler) TypingCommand(ctx context.Context, user domain.UserContext, data json.RawMessage) (interface{}, error) {
+	var cmd TypingCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return nil, &httpAdapter.CommandError{Message: "Invalid JSON", Code: "INVALID_JSON", Details: err.Error()}
+	}
+
+	chatID := strings.TrimSpace(cmd.ChatID)
+	if chatID == "" {
+		return nil, &httpAdapter.CommandError{Message: "Missing chat ID", Code: "MISSING_CHAT_ID", Details: "chat_id is required"}
+	}
+
+	if herr := h.typing(ctx, user, chatID); herr != nil {
+		return nil, herr.commandError()
+	}
+	return TypingResponse{ChatID: chatID}, nil
+}
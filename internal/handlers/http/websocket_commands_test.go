@@ -0,0 +1,194 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+
+	httpAdapter "messaging-app/internal/adapters/http"
+	"messaging-app/internal/domain"
+	"messaging-app/testdata"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// WebSocket command Tests
+
+func (s *MessageHandlerTestSuite) TestSendMessageCommand_Success() {
+	alice := testdata.Alice
+	bob := testdata.Bob
+
+	data, _ := json.Marshal(SendMessageCommand{
+		ReceiverID:         bob.UserID,
+		SendMessageRequest: SendMessageRequest{Content: "Hello over WebSocket"},
+	})
+
+	s.mockRepo.On("SaveMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+		return msg.SenderID == alice.UserID &&
+			msg.ReceiverID == bob.UserID &&
+			msg.Content == "Hello over WebSocket"
+	}), mock.AnythingOfType("domain.OutboxEvent")).Return(nil)
+	s.mockMetrics.On("MessageSent", domain.ChatTypeDirect).Once()
+	s.mockLogger.On("Debug", "Message sent successfully", "sender", alice.UserID, "receiver", bob.UserID).Return()
+
+	// Execute
+	result, err := s.handler.SendMessageCommand(context.Background(), alice, data)
+
+	// Assertions
+	s.NoError(err)
+	response, ok := result.(SendMessageResponse)
+	s.True(ok)
+	s.Equal(alice.UserID, response.SenderID)
+	s.Equal(bob.UserID, response.ReceiverID)
+	s.Equal("sent", response.Status)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessageCommand_MissingReceiver() {
+	data, _ := json.Marshal(SendMessageCommand{
+		SendMessageRequest: SendMessageRequest{Content: "Hello"},
+	})
+
+	// Execute
+	result, err := s.handler.SendMessageCommand(context.Background(), testdata.Alice, data)
+
+	// Assertions
+	s.Nil(result)
+	var cmdErr *httpAdapter.CommandError
+	s.ErrorAs(err, &cmdErr)
+	s.Equal("MISSING_RECEIVER_ID", cmdErr.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessageCommand_ValidationError() {
+	data, _ := json.Marshal(SendMessageCommand{
+		ReceiverID:         testdata.Bob.UserID,
+		SendMessageRequest: SendMessageRequest{Content: ""},
+	})
+
+	// Execute
+	_, err := s.handler.SendMessageCommand(context.Background(), testdata.Alice, data)
+
+	// Assertions
+	var cmdErr *httpAdapter.CommandError
+	s.ErrorAs(err, &cmdErr)
+	s.Equal("VALIDATION_ERROR", cmdErr.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestSendMessageCommand_InvalidJSON() {
+	// Execute
+	_, err := s.handler.SendMessageCommand(context.Background(), testdata.Alice, json.RawMessage(`{"receiver_id":`))
+
+	// Assertions
+	var cmdErr *httpAdapter.CommandError
+	s.ErrorAs(err, &cmdErr)
+	s.Equal("INVALID_JSON", cmdErr.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestMarkReadCommand_Success() {
+	bob := testdata.Bob
+	testMessage := testdata.ValidMessages()[0] // This is from Alice to Bob
+
+	messageID := domain.MessageID{
+		SenderID:   testMessage.SenderID,
+		ReceiverID: testMessage.ReceiverID,
+		CreatedAt:  testMessage.CreatedAt,
+	}
+	data, _ := json.Marshal(UpdateStatusRequest{MessageID: messageID})
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, messageID).Return(&testMessage, nil)
+	s.mockRepo.On("MarkMessagesUpToRead", mock.Anything, messageID, statusEventsTo(domain.MessageStatusRead, bob.UserID, testMessage.SenderID, bob.UserID)).Return(int64(2), nil)
+	s.mockMetrics.On("MessagesRead", int64(2)).Once()
+	s.mockLogger.On("Debug", "Message status updated successfully", "user", bob.UserID, "count", int64(2), "status", domain.MessageStatusRead).Return()
+
+	// Execute
+	result, err := s.handler.MarkReadCommand(context.Background(), bob, data)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(UpdateStatusResponse{UpdatedCount: 2}, result)
+}
+
+func (s *MessageHandlerTestSuite) TestMarkReadCommand_AccessDenied() {
+	testMessage := testdata.ValidMessages()[0] // This is from Alice to Bob
+
+	data, _ := json.Marshal(UpdateStatusRequest{MessageID: domain.MessageID{
+		SenderID:   testMessage.SenderID,
+		ReceiverID: testMessage.ReceiverID,
+		CreatedAt:  testMessage.CreatedAt,
+	}})
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, testMessage.Key()).Return(&testMessage, nil)
+
+	// Execute - Charlie did not receive the message
+	_, err := s.handler.MarkReadCommand(context.Background(), testdata.Charlie, data)
+
+	// Assertions
+	var cmdErr *httpAdapter.CommandError
+	s.ErrorAs(err, &cmdErr)
+	s.Equal("ACCESS_DENIED", cmdErr.Code)
+}
+
+func (s *MessageHandlerTestSuite) TestEditMessageCommand_Success() {
+	alice := testdata.Alice
+	original := recentMessage()
+
+	data, _ := json.Marshal(EditMessageCommand{
+		MessageID:          original.ID,
+		EditMessageRequest: EditMessageRequest{Content: "Edited over WebSocket"},
+	})
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockRepo.On("EditMessage", mock.Anything, mock.MatchedBy(func(msg domain.Message) bool {
+		return msg.Content == "Edited over WebSocket"
+	}), mock.AnythingOfType("domain.OutboxEvent"), mock.AnythingOfType("domain.OutboxEvent")).Return(nil)
+	s.mockLogger.On("Debug", "Message edited successfully", "message_id", original.ID, "user", alice.UserID).Return()
+
+	// Execute
+	result, err := s.handler.EditMessageCommand(context.Background(), alice, data)
+
+	// Assertions
+	s.NoError(err)
+	response, ok := result.(SendMessageResponse)
+	s.True(ok)
+	s.Equal("Edited over WebSocket", response.Content)
+	s.NotNil(response.EditedAt)
+}
+
+func (s *MessageHandlerTestSuite) TestDeleteMessageCommand_DefaultsToMe() {
+	bob := testdata.Bob
+	original := recentMessage()
+
+	data, _ := json.Marshal(DeleteMessageCommand{MessageID: original.ID})
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockRepo.On("HideMessage", mock.Anything, original.ID, bob.UserID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("domain.OutboxEvent")).Return(nil)
+	s.mockLogger.On("Debug", "Message deleted successfully", "message_id", original.ID, "user", bob.UserID, "scope", domain.DeleteForMe).Return()
+
+	// Execute
+	result, err := s.handler.DeleteMessageCommand(context.Background(), bob, data)
+
+	// Assertions
+	s.NoError(err)
+	s.Equal(DeleteMessageResponse{MessageID: original.ID, Scope: domain.DeleteForMe}, result)
+}
+
+func (s *MessageHandlerTestSuite) TestAddReactionCommand_Success() {
+	bob := testdata.Bob
+	original := recentMessage()
+
+	data, _ := json.Marshal(AddReactionCommand{
+		MessageID:          original.ID,
+		AddReactionRequest: AddReactionRequest{Emoji: "🎉"},
+	})
+
+	s.mockRepo.On("GetMessageByID", mock.Anything, domain.MessageID{ID: original.ID}).Return(&original, nil)
+	s.mockRepo.On("AddReaction", mock.Anything, mock.AnythingOfType("domain.Reaction"), mock.AnythingOfType("domain.OutboxEvent"), mock.AnythingOfType("domain.OutboxEvent")).Return(nil)
+	s.mockLogger.On("Debug", "Reaction added successfully", "message_id", original.ID, "user", bob.UserID).Return()
+
+	// Execute
+	result, err := s.handler.AddReactionCommand(context.Background(), bob, data)
+
+	// Assertions
+	s.NoError(err)
+	response, ok := result.(ReactionResponse)
+	s.True(ok)
+	s.Equal("🎉", response.Emoji)
+}
@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 	domain "messaging-app/internal/domain"
+	ports "messaging-app/internal/ports"
 
 	mock "github.com/stretchr/testify/mock"
-
-	ports "messaging-app/internal/ports"
 )
 
 // MessagePublisher is an autogenerated mock type for the MessagePublisher type
@@ -34,6 +33,24 @@ func (_m *MessagePublisher) Close() error {
 	return r0
 }
 
+// PublishGroupMessage provides a mock function with given fields: ctx, message, memberIDs
+func (_m *MessagePublisher) PublishGroupMessage(ctx context.Context, message domain.Message, memberIDs []string) error {
+	ret := _m.Called(ctx, message, memberIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishGroupMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Message, []string) error); ok {
+		r0 = rf(ctx, message, memberIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PublishMessage provides a mock function with given fields: ctx, message
 func (_m *MessagePublisher) PublishMessage(ctx context.Context, message domain.Message) error {
 	ret := _m.Called(ctx, message)
@@ -52,6 +69,60 @@ func (_m *MessagePublisher) PublishMessage(ctx context.Context, message domain.M
 	return r0
 }
 
+// PublishMessageUpdate provides a mock function with given fields: ctx, eventType, message, userIDs
+func (_m *MessagePublisher) PublishMessageUpdate(ctx context.Context, eventType domain.MessageType, message domain.Message, userIDs []string) error {
+	ret := _m.Called(ctx, eventType, message, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishMessageUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageType, domain.Message, []string) error); ok {
+		r0 = rf(ctx, eventType, message, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishPresence provides a mock function with given fields: ctx, presence, userIDs
+func (_m *MessagePublisher) PublishPresence(ctx context.Context, presence domain.Presence, userIDs []string) error {
+	ret := _m.Called(ctx, presence, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishPresence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Presence, []string) error); ok {
+		r0 = rf(ctx, presence, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishReactionUpdate provides a mock function with given fields: ctx, update, userIDs
+func (_m *MessagePublisher) PublishReactionUpdate(ctx context.Context, update domain.ReactionUpdate, userIDs []string) error {
+	ret := _m.Called(ctx, update, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishReactionUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReactionUpdate, []string) error); ok {
+		r0 = rf(ctx, update, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PublishStatusUpdate provides a mock function with given fields: ctx, userID, statusUpdate
 func (_m *MessagePublisher) PublishStatusUpdate(ctx context.Context, userID string, statusUpdate ports.StatusUpdate) error {
 	ret := _m.Called(ctx, userID, statusUpdate)
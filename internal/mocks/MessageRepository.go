@@ -8,6 +8,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	ports "messaging-app/internal/ports"
+
 	time "time"
 )
 
@@ -16,9 +18,144 @@ type MessageRepository struct {
 	mock.Mock
 }
 
-// GetChatSessions provides a mock function with given fields: ctx, userID
-func (_m *MessageRepository) GetChatSessions(ctx context.Context, userID string) ([]domain.ChatSession, error) {
-	ret := _m.Called(ctx, userID)
+// AddReaction provides a mock function with given fields: ctx, reaction, events
+func (_m *MessageRepository) AddReaction(ctx context.Context, reaction domain.Reaction, events ...domain.OutboxEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, reaction)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Reaction, ...domain.OutboxEvent) error); ok {
+		r0 = rf(ctx, reaction, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpiredAttachments provides a mock function with given fields: ctx, createdBefore, limit
+func (_m *MessageRepository) DeleteExpiredAttachments(ctx context.Context, createdBefore time.Time, limit int) ([]string, error) {
+	ret := _m.Called(ctx, createdBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredAttachments")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]string, error)); ok {
+		return rf(ctx, createdBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []string); ok {
+		r0 = rf(ctx, createdBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, createdBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMessage provides a mock function with given fields: ctx, messageID, deletedAt, events
+func (_m *MessageRepository) DeleteMessage(ctx context.Context, messageID string, deletedAt time.Time, events ...domain.OutboxEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, messageID, deletedAt)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, ...domain.OutboxEvent) error); ok {
+		r0 = rf(ctx, messageID, deletedAt, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditMessage provides a mock function with given fields: ctx, message, events
+func (_m *MessageRepository) EditMessage(ctx context.Context, message domain.Message, events ...domain.OutboxEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, message)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Message, ...domain.OutboxEvent) error); ok {
+		r0 = rf(ctx, message, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAttachment provides a mock function with given fields: ctx, attachmentID
+func (_m *MessageRepository) GetAttachment(ctx context.Context, attachmentID string) (*domain.Attachment, error) {
+	ret := _m.Called(ctx, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachment")
+	}
+
+	var r0 *domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Attachment, error)); ok {
+		return rf(ctx, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Attachment); ok {
+		r0 = rf(ctx, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChatSessions provides a mock function with given fields: ctx, userID, cursor, limit
+func (_m *MessageRepository) GetChatSessions(ctx context.Context, userID string, cursor domain.ChatCursor, limit int) ([]domain.ChatSession, error) {
+	ret := _m.Called(ctx, userID, cursor, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetChatSessions")
@@ -26,19 +163,49 @@ func (_m *MessageRepository) GetChatSessions(ctx context.Context, userID string)
 
 	var r0 []domain.ChatSession
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.ChatSession, error)); ok {
-		return rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ChatCursor, int) ([]domain.ChatSession, error)); ok {
+		return rf(ctx, userID, cursor, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.ChatSession); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ChatCursor, int) []domain.ChatSession); ok {
+		r0 = rf(ctx, userID, cursor, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.ChatSession)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.ChatCursor, int) error); ok {
+		r1 = rf(ctx, userID, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessageByClientID provides a mock function with given fields: ctx, senderID, clientMessageID
+func (_m *MessageRepository) GetMessageByClientID(ctx context.Context, senderID string, clientMessageID string) (*domain.Message, error) {
+	ret := _m.Called(ctx, senderID, clientMessageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageByClientID")
+	}
+
+	var r0 *domain.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Message, error)); ok {
+		return rf(ctx, senderID, clientMessageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Message); ok {
+		r0 = rf(ctx, senderID, clientMessageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, senderID, clientMessageID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,29 +243,27 @@ func (_m *MessageRepository) GetMessageByID(ctx context.Context, messageID domai
 	return r0, r1
 }
 
-// GetMessages provides a mock function with given fields: ctx, chatID, cursor, limit
-func (_m *MessageRepository) GetMessages(ctx context.Context, chatID string, cursor time.Time, limit int) ([]domain.Message, error) {
-	ret := _m.Called(ctx, chatID, cursor, limit)
+// GetMessages provides a mock function with given fields: ctx, chatID, page
+func (_m *MessageRepository) GetMessages(ctx context.Context, chatID string, page domain.MessagePage) (ports.PaginationResult, error) {
+	ret := _m.Called(ctx, chatID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMessages")
 	}
 
-	var r0 []domain.Message
+	var r0 ports.PaginationResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int) ([]domain.Message, error)); ok {
-		return rf(ctx, chatID, cursor, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.MessagePage) (ports.PaginationResult, error)); ok {
+		return rf(ctx, chatID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int) []domain.Message); ok {
-		r0 = rf(ctx, chatID, cursor, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.MessagePage) ports.PaginationResult); ok {
+		r0 = rf(ctx, chatID, page)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Message)
-		}
+		r0 = ret.Get(0).(ports.PaginationResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, int) error); ok {
-		r1 = rf(ctx, chatID, cursor, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.MessagePage) error); ok {
+		r1 = rf(ctx, chatID, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,6 +299,31 @@ func (_m *MessageRepository) GetUnreadCount(ctx context.Context, userID string,
 	return r0, r1
 }
 
+// HideMessage provides a mock function with given fields: ctx, messageID, userID, hiddenAt, events
+func (_m *MessageRepository) HideMessage(ctx context.Context, messageID string, userID string, hiddenAt time.Time, events ...domain.OutboxEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, messageID, userID, hiddenAt)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HideMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, ...domain.OutboxEvent) error); ok {
+		r0 = rf(ctx, messageID, userID, hiddenAt, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MarkChatAsRead provides a mock function with given fields: ctx, userID, chatID
 func (_m *MessageRepository) MarkChatAsRead(ctx context.Context, userID string, chatID string) error {
 	ret := _m.Called(ctx, userID, chatID)
@@ -152,9 +342,37 @@ func (_m *MessageRepository) MarkChatAsRead(ctx context.Context, userID string,
 	return r0
 }
 
-// MarkMessagesUpToRead provides a mock function with given fields: ctx, msg
-func (_m *MessageRepository) MarkMessagesUpToRead(ctx context.Context, msg domain.MessageID) (int64, error) {
-	ret := _m.Called(ctx, msg)
+// MarkMessagesUpToDelivered provides a mock function with given fields: ctx, msg, events
+func (_m *MessageRepository) MarkMessagesUpToDelivered(ctx context.Context, msg domain.MessageID, events domain.OutboxEventsFunc) (int64, error) {
+	ret := _m.Called(ctx, msg, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkMessagesUpToDelivered")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageID, domain.OutboxEventsFunc) (int64, error)); ok {
+		return rf(ctx, msg, events)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageID, domain.OutboxEventsFunc) int64); ok {
+		r0 = rf(ctx, msg, events)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MessageID, domain.OutboxEventsFunc) error); ok {
+		r1 = rf(ctx, msg, events)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkMessagesUpToRead provides a mock function with given fields: ctx, msg, events
+func (_m *MessageRepository) MarkMessagesUpToRead(ctx context.Context, msg domain.MessageID, events domain.OutboxEventsFunc) (int64, error) {
+	ret := _m.Called(ctx, msg, events)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MarkMessagesUpToRead")
@@ -162,17 +380,17 @@ func (_m *MessageRepository) MarkMessagesUpToRead(ctx context.Context, msg domai
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageID) (int64, error)); ok {
-		return rf(ctx, msg)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageID, domain.OutboxEventsFunc) (int64, error)); ok {
+		return rf(ctx, msg, events)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageID) int64); ok {
-		r0 = rf(ctx, msg)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageID, domain.OutboxEventsFunc) int64); ok {
+		r0 = rf(ctx, msg, events)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.MessageID) error); ok {
-		r1 = rf(ctx, msg)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MessageID, domain.OutboxEventsFunc) error); ok {
+		r1 = rf(ctx, msg, events)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -180,17 +398,67 @@ func (_m *MessageRepository) MarkMessagesUpToRead(ctx context.Context, msg domai
 	return r0, r1
 }
 
-// SaveMessage provides a mock function with given fields: ctx, message
-func (_m *MessageRepository) SaveMessage(ctx context.Context, message domain.Message) error {
-	ret := _m.Called(ctx, message)
+// RemoveReaction provides a mock function with given fields: ctx, messageID, userID, events
+func (_m *MessageRepository) RemoveReaction(ctx context.Context, messageID string, userID string, events ...domain.OutboxEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, messageID, userID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...domain.OutboxEvent) error); ok {
+		r0 = rf(ctx, messageID, userID, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveAttachment provides a mock function with given fields: ctx, attachment
+func (_m *MessageRepository) SaveAttachment(ctx context.Context, attachment domain.Attachment) error {
+	ret := _m.Called(ctx, attachment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Attachment) error); ok {
+		r0 = rf(ctx, attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveMessage provides a mock function with given fields: ctx, message, events
+func (_m *MessageRepository) SaveMessage(ctx context.Context, message domain.Message, events ...domain.OutboxEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, message)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveMessage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Message) error); ok {
-		r0 = rf(ctx, message)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Message, ...domain.OutboxEvent) error); ok {
+		r0 = rf(ctx, message, events...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -198,6 +466,34 @@ func (_m *MessageRepository) SaveMessage(ctx context.Context, message domain.Mes
 	return r0
 }
 
+// SearchMessages provides a mock function with given fields: ctx, search
+func (_m *MessageRepository) SearchMessages(ctx context.Context, search domain.MessageSearch) (ports.SearchResult, error) {
+	ret := _m.Called(ctx, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchMessages")
+	}
+
+	var r0 ports.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageSearch) (ports.SearchResult, error)); ok {
+		return rf(ctx, search)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MessageSearch) ports.SearchResult); ok {
+		r0 = rf(ctx, search)
+	} else {
+		r0 = ret.Get(0).(ports.SearchResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MessageSearch) error); ok {
+		r1 = rf(ctx, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMessageRepository creates a new instance of MessageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMessageRepository(t interface {
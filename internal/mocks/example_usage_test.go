@@ -91,10 +91,10 @@ func TestMockReturnValues(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetChatSessions", mock.Anything, "user1").Return(expectedSessions, nil)
+	mockRepo.On("GetChatSessions", mock.Anything, "user1", domain.ChatCursor{}, 50).Return(expectedSessions, nil)
 
 	ctx := context.Background()
-	sessions, err := mockRepo.GetChatSessions(ctx, "user1")
+	sessions, err := mockRepo.GetChatSessions(ctx, "user1", domain.ChatCursor{}, 50)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedSessions, sessions)
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"net/http"
+
+	"messaging-app/internal/domain"
+)
+
+//go:generate mockery --name=Authenticator --output=../mocks --outpkg=mocks
+
+type Authenticator interface {
+	// Authenticate resolves the user behind a request from its headers
+	// Returns domain.ErrMissingUserID, ErrMissingToken, ErrMalformedToken, ErrTokenExpired or ErrInvalidToken
+	// (possibly wrapped) when the credentials are rejected, or a user context validation error
+	Authenticate(ctx context.Context, header http.Header) (domain.UserContext, error)
+}
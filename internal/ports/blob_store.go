@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate mockery --name=BlobStore --output=../mocks --outpkg=mocks
+
+// BlobStore keeps the content of uploaded files, addressed by key
+type BlobStore interface {
+	// Put stores everything read from content under key, replacing any previous blob
+	// A failed Put leaves no partial blob behind
+	Put(ctx context.Context, key string, content io.Reader) error
+
+	// Get opens the blob stored under key; the caller closes it
+	// Returns domain.ErrBlobNotFound if there is no such blob
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the blob stored under key; deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"messaging-app/internal/domain"
+)
+
+//go:generate mockery --name=BlockRepository --output=../mocks --outpkg=mocks
+
+type BlockRepository interface {
+	// BlockUser stores a block; blocking a user that is already blocked is not an error
+	BlockUser(ctx context.Context, block domain.Block) error
+
+	// UnblockUser removes a block; unblocking a user that is not blocked is not an error
+	UnblockUser(ctx context.Context, blockerID, blockedID string) error
+
+	// GetBlockedUsers returns the blocks made by blockerID, most recent first
+	GetBlockedUsers(ctx context.Context, blockerID string) ([]domain.Block, error)
+}
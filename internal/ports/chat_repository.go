@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"messaging-app/internal/domain"
+)
+
+//go:generate mockery --name=ChatRepository --output=../mocks --outpkg=mocks
+
+type ChatRepository interface {
+	// CreateGroupChat stores a new group chat together with its initial members
+	CreateGroupChat(ctx context.Context, chat domain.Chat) error
+
+	// GetChat retrieves a group chat with its current members
+	// Returns ErrChatNotFound if the chat does not exist
+	GetChat(ctx context.Context, chatID string) (*domain.Chat, error)
+
+	// AddMember adds a user to an existing group chat
+	// Returns ErrChatNotFound if the chat does not exist and ErrMemberExists if the user already belongs to it
+	AddMember(ctx context.Context, member domain.ChatMember) error
+
+	// RemoveMember removes a user from a group chat
+	// Returns ErrNotChatMember if the user does not belong to it
+	RemoveMember(ctx context.Context, chatID, userID string) error
+
+	// IsMember checks if a user belongs to a group chat
+	IsMember(ctx context.Context, chatID, userID string) (bool, error)
+
+	// GetMemberIDs returns the user IDs of every member of a group chat
+	GetMemberIDs(ctx context.Context, chatID string) ([]string, error)
+
+	// MarkReadUpTo advances the member's read marker in a group chat
+	// Returns the number of messages from other members that became read
+	// The outbox events built from the affected range are written in the same transaction when any message became read
+	MarkReadUpTo(ctx context.Context, chatID, userID string, upTo time.Time, events domain.OutboxEventsFunc) (int64, error)
+
+	// MarkDeliveredUpTo advances the member's delivery marker in a group chat
+	// Returns the number of messages from other members that became delivered
+	// Messages already read by the member are not counted again
+	// The outbox events built from the affected range are written in the same transaction when any message became delivered
+	MarkDeliveredUpTo(ctx context.Context, chatID, userID string, upTo time.Time, events domain.OutboxEventsFunc) (int64, error)
+
+	// MuteChat mutes a 1:1 or group chat for a user, replacing any previous mute of that chat
+	MuteChat(ctx context.Context, mute domain.ChatMute) error
+
+	// UnmuteChat lifts the user's mute of a chat; unmuting a chat that is not muted is not an error
+	UnmuteChat(ctx context.Context, chatID, userID string) error
+
+	// GetMutedUserIDs returns which of userIDs have the chat muted at the given time
+	GetMutedUserIDs(ctx context.Context, chatID string, userIDs []string, at time.Time) ([]string, error)
+}
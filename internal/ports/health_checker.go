@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+)
+
+//go:generate mockery --name=HealthChecker --output=../mocks --outpkg=mocks
+
+// HealthChecker checks that a dependency of the instance is usable, for readiness probes
+type HealthChecker interface {
+	// Name identifies the component in health reports
+	Name() string
+
+	// Check returns an error when the component is unusable, or one wrapping
+	// domain.ErrHealthDegraded when it works but needs attention
+	Check(ctx context.Context) error
+}
@@ -14,6 +14,23 @@ type MessagePublisher interface {
 	// Subject pattern: messages.{receiver_id}
 	PublishMessage(ctx context.Context, message domain.Message) error
 
+	// PublishGroupMessage fans a group message out to every member except the sender
+	// Subject pattern: messages.{member_id}
+	PublishGroupMessage(ctx context.Context, message domain.Message, memberIDs []string) error
+
+	// PublishMessageUpdate notifies userIDs that a message was edited or deleted
+	// eventType is MessageTypeMessageEdited or MessageTypeMessageDeleted
+	// Subject pattern: messages.{user_id}
+	PublishMessageUpdate(ctx context.Context, eventType domain.MessageType, message domain.Message, userIDs []string) error
+
+	// PublishReactionUpdate notifies userIDs that a reaction to a message changed
+	// Subject pattern: messages.{user_id}
+	PublishReactionUpdate(ctx context.Context, update domain.ReactionUpdate, userIDs []string) error
+
+	// PublishPresence notifies userIDs that a contact came online, went offline or is typing
+	// Subject pattern: status.{user_id}
+	PublishPresence(ctx context.Context, presence domain.Presence, userIDs []string) error
+
 	// PublishStatusUpdate notifies about message status changes
 	// Subject pattern: status.{user_id}
 	PublishStatusUpdate(ctx context.Context, userID string, statusUpdate StatusUpdate) error
@@ -23,8 +40,14 @@ type MessagePublisher interface {
 }
 
 type StatusUpdate struct {
-	MessageID domain.MessageID `json:"message_id"`
-	Status    string           `json:"status"`
-	UpdatedBy string           `json:"updated_by"`
-	UpdatedAt time.Time        `json:"updated_at"`
+	MessageID domain.MessageID     `json:"message_id"`
+	Status    string               `json:"status"`
+	UpdatedBy string               `json:"updated_by"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	Range     *domain.MessageRange `json:"range,omitempty"`
+}
+
+// NewStatusOutboxEvent creates the event delivering a status update to userID
+func NewStatusOutboxEvent(userID string, statusUpdate StatusUpdate) (domain.OutboxEvent, error) {
+	return domain.NewOutboxEvent(domain.MessageTypeStatusUpdate, userID, statusUpdate)
 }
@@ -11,37 +11,118 @@ import (
 
 type MessageRepository interface {
 	// SaveMessage stores a new message with idempotency protection
-	// Returns ErrDuplicateMessage if message with same composite key exists
-	SaveMessage(ctx context.Context, message domain.Message) error
+	// The given outbox events are written in the same transaction
+	// Messages without an ID are assigned one derived from their timestamp
+	// Returns ErrDuplicateMessage if a message with the same ID, or the same sender and ClientMessageID, exists
+	// Returns ErrAttachmentInUse if the message's attachment was already sent with another message
+	// Returns ErrUserBlocked if either participant of a 1:1 message blocked the other
+	SaveMessage(ctx context.Context, message domain.Message, events ...domain.OutboxEvent) error
 
-	// GetMessages retrieves messages for a chat with cursor-based pagination
-	// cursor: timestamp to start from (exclusive), use time.Time{} for first page
-	// limit: maximum number of messages to return (1-100)
-	// Returns messages in descending order by created_at (newest first)
-	GetMessages(ctx context.Context, chatID string, cursor time.Time, limit int) ([]domain.Message, error)
+	// GetMessageByClientID retrieves the message a sender stored under an idempotency key
+	// Returns ErrMessageNotFound if the sender never used the key
+	GetMessageByClientID(ctx context.Context, senderID, clientMessageID string) (*domain.Message, error)
 
-	// GetChatSessions retrieves all chat sessions for a user
-	// Returns sessions ordered by last_message_at descending
-	GetChatSessions(ctx context.Context, userID string) ([]domain.ChatSession, error)
+	// GetMessages retrieves a page of a chat's history with keyset pagination
+	// chatID: either a 1:1 "a---b" ID or a group chat ID
+	// page: direction and cursor of the window, see domain.MessagePage; limit is 1-100
+	// Messages deleted for everyone are returned as tombstones, those page.UserID deleted for themselves are skipped
+	// Every message carries its reaction summaries
+	// Returns messages in descending order by (created_at, id) (newest first) with cursors to both sides
+	// Returns ErrMessageNotFound if the anchor of an around page is not part of the chat
+	GetMessages(ctx context.Context, chatID string, page domain.MessagePage) (PaginationResult, error)
+
+	// SearchMessages finds the messages matching search.Text in every chat search.UserID takes part in, with keyset pagination
+	// Groups are searched while the user is a member; tombstones and messages the user deleted for themselves never match
+	// search.Cursor is the position of the last hit of the previous page (exclusive); limit is 1-100
+	// Returns hits ordered by rank descending, ties broken by (created_at, id) descending
+	SearchMessages(ctx context.Context, search domain.MessageSearch) (SearchResult, error)
+
+	// EditMessage stores the new content and edited_at of a message
+	// The given outbox events are written in the same transaction
+	// Returns ErrMessageNotFound if the message does not exist, ErrMessageDeleted if it was deleted for everyone
+	EditMessage(ctx context.Context, message domain.Message, events ...domain.OutboxEvent) error
+
+	// DeleteMessage turns a message into a tombstone for everyone, erasing its content
+	// The metadata of its attachment is deleted too, so the file can no longer be downloaded;
+	// the caller deletes the content from the BlobStore once this returns
+	// The given outbox events are written in the same transaction
+	// Returns ErrMessageNotFound if the message does not exist, ErrMessageDeleted if it was already deleted
+	DeleteMessage(ctx context.Context, messageID string, deletedAt time.Time, events ...domain.OutboxEvent) error
+
+	// HideMessage deletes a message for userID only; GetMessages skips it for that user from then on
+	// The given outbox events are written in the same transaction, unless the message was already hidden
+	// Returns ErrMessageNotFound if the message does not exist
+	HideMessage(ctx context.Context, messageID, userID string, hiddenAt time.Time, events ...domain.OutboxEvent) error
+
+	// AddReaction sets the user's reaction to a message, replacing any previous emoji
+	// The given outbox events are written in the same transaction, unless the user already reacted with the same emoji
+	// Returns ErrMessageNotFound if the message does not exist
+	AddReaction(ctx context.Context, reaction domain.Reaction, events ...domain.OutboxEvent) error
+
+	// RemoveReaction removes the user's reaction to a message
+	// The given outbox events are written in the same transaction, unless there was no reaction to remove
+	RemoveReaction(ctx context.Context, messageID, userID string, events ...domain.OutboxEvent) error
+
+	// SaveAttachment stores the metadata of an uploaded file
+	// Returns ErrUserBlocked if it was uploaded to a 1:1 chat where either participant blocked the other
+	SaveAttachment(ctx context.Context, attachment domain.Attachment) error
+
+	// GetAttachment retrieves the metadata of an uploaded file
+	// Returns ErrAttachmentNotFound if it does not exist or its message was deleted for everyone
+	GetAttachment(ctx context.Context, attachmentID string) (*domain.Attachment, error)
+
+	// DeleteExpiredAttachments deletes up to limit uploads created before createdBefore that were never sent with a message
+	// Returns the IDs of the deleted attachments so their content can be removed from the BlobStore
+	DeleteExpiredAttachments(ctx context.Context, createdBefore time.Time, limit int) ([]string, error)
+
+	// GetChatSessions retrieves chat sessions for a user, including group chats, with keyset pagination
+	// cursor: position of the last session of the previous page (exclusive), use domain.ChatCursor{} for first page
+	// limit: maximum number of sessions to return (1-101; callers paging by 100 fetch one more to detect a next page)
+	// Direct chats with users the user blocked are left out, and muted chats report no unread messages
+	// Returns sessions ordered by last_message_at descending, ties broken by chat_id descending
+	GetChatSessions(ctx context.Context, userID string, cursor domain.ChatCursor, limit int) ([]domain.ChatSession, error)
 
 	// MarkMessagesUpToRead updates status for multiple messages to read
-	// It expects a messageId and will mark all previous messages of the same conversation as read
-	MarkMessagesUpToRead(ctx context.Context, msg domain.MessageID) (int64, error)
+	// It expects the composite form of a messageId and will mark all previous messages of the same conversation as read
+	// The outbox events built from the affected range are written in the same transaction when any message changed
+	MarkMessagesUpToRead(ctx context.Context, msg domain.MessageID, events domain.OutboxEventsFunc) (int64, error)
 
-	// GetMessageByID retrieves a specific message by its composite key
+	// MarkMessagesUpToDelivered marks sent messages up to msg (composite form) as delivered
+	// Messages that are already read are never downgraded
+	// The outbox events built from the affected range are written in the same transaction when any message changed
+	MarkMessagesUpToDelivered(ctx context.Context, msg domain.MessageID, events domain.OutboxEventsFunc) (int64, error)
+
+	// GetMessageByID retrieves a specific message by its ID, or by its composite key when no ID is given
 	GetMessageByID(ctx context.Context, messageID domain.MessageID) (*domain.Message, error)
 
 	// GetUnreadCount returns count of unread messages for a user in a specific chat
+	// Returns 0 while the user has the chat muted
 	GetUnreadCount(ctx context.Context, userID, chatID string) (int, error)
 
 	// MarkChatAsRead marks all messages in a chat as read for the receiver
 	MarkChatAsRead(ctx context.Context, userID, chatID string) error
 }
 
-// PaginationResult wraps paginated results
+// PaginationResult wraps a page of a chat's history
 type PaginationResult struct {
-	Messages   []domain.Message `json:"messages"`
-	NextCursor *time.Time       `json:"next_cursor,omitempty"`
-	HasMore    bool             `json:"has_more"`
-	Total      int              `json:"total,omitempty"`
+	Messages []domain.Message `json:"messages"`
+
+	// NextCursor continues towards older messages (before), set when HasMore
+	NextCursor string `json:"next_cursor,omitempty"`
+	// PrevCursor continues towards newer messages (after), set for any non-empty page
+	// so clients can catch up on what arrived meanwhile
+	PrevCursor string `json:"prev_cursor,omitempty"`
+
+	HasMore  bool `json:"has_more"`
+	HasNewer bool `json:"has_newer"`
+	Total    int  `json:"total,omitempty"`
+}
+
+// SearchResult wraps a page of search hits
+type SearchResult struct {
+	Hits []domain.SearchHit `json:"hits"`
+
+	// NextCursor continues with lower ranked hits, set when HasMore
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
 }
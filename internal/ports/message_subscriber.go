@@ -0,0 +1,32 @@
+package ports
+
+import "context"
+
+//go:generate mockery --name=MessageSubscriber --output=../mocks --outpkg=mocks
+//go:generate mockery --name=Subscription --output=../mocks --outpkg=mocks
+
+type MessageSubscriber interface {
+	// SubscribeUser delivers every real-time event addressed to a user
+	// Subject patterns: messages.{user_id} and status.{user_id}
+	// The handler receives the raw envelope payload as published
+	SubscribeUser(ctx context.Context, userID string, opts SubscribeOptions, handler func(event Event)) (Subscription, error)
+}
+
+type SubscribeOptions struct {
+	// ResumeAfter replays stored events with a greater sequence number
+	// Zero delivers new events only; ignored when the transport keeps no history
+	ResumeAfter uint64
+}
+
+// Event is a real-time event delivered to a subscriber
+type Event struct {
+	// Sequence is the position of the event in the durable stream, 0 when not persisted
+	Sequence uint64
+	Payload  []byte
+}
+
+// Subscription represents an active real-time subscription
+type Subscription interface {
+	// Unsubscribe stops delivery and releases the subscription
+	Unsubscribe() error
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"net/http"
+	"time"
+)
+
+//go:generate mockery --name=Metrics --output=../mocks --outpkg=mocks
+
+// Metrics records measurements of the service without tying callers to a monitoring backend
+type Metrics interface {
+	// ObserveHTTPRequest records a served request under its route pattern rather than its path
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+
+	// MessageSent counts a stored message, by chat type
+	MessageSent(chatType string)
+
+	// MessagesRead counts messages marked as read
+	MessagesRead(count int64)
+
+	// PublishFailed counts a real-time event that could not be published, by event
+	PublishFailed(event string)
+
+	// ObserveQuery records how long a repository operation took and whether it failed
+	ObserveQuery(operation string, duration time.Duration, err error)
+
+	// Handler exposes the collected metrics to scrapers
+	Handler() http.Handler
+}
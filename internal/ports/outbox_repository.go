@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"messaging-app/internal/domain"
+)
+
+//go:generate mockery --name=OutboxRepository --output=../mocks --outpkg=mocks
+
+// OutboxRepository gives the relay access to events written by the other repositories
+type OutboxRepository interface {
+	// ClaimEvents returns up to limit due events and hides them from other relays for lease
+	// Attempts is incremented for every claimed event
+	ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxEvent, error)
+
+	// DeleteEvent removes an event once it has been published
+	DeleteEvent(ctx context.Context, id int64) error
+
+	// RetryEvent schedules another delivery attempt and records why the last one failed,
+	// along with the group members the event has reached so far
+	RetryEvent(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string, deliveredTo []string) error
+
+	// FailEvent parks an event that exhausted its attempts so it is no longer claimed
+	FailEvent(ctx context.Context, id int64, lastError string) error
+
+	// OldestPendingEventAt returns when the oldest event that is still to be published was written,
+	// or nil when every event was published or failed
+	OldestPendingEventAt(ctx context.Context) (*time.Time, error)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"messaging-app/internal/domain"
+)
+
+//go:generate mockery --name=PresenceRepository --output=../mocks --outpkg=mocks
+
+type PresenceRepository interface {
+	// TouchPresence records that the user was active at seenAt and marks them online
+	// Returns true if the user was offline or never seen before
+	TouchPresence(ctx context.Context, userID string, seenAt time.Time) (bool, error)
+
+	// ExpirePresence marks online users not seen since cutoff as offline
+	// Returns the users that went offline with their last activity; each expiry is returned once across instances
+	ExpirePresence(ctx context.Context, cutoff time.Time) ([]domain.Presence, error)
+
+	// GetContactIDs returns every user sharing a 1:1 or group chat with userID
+	GetContactIDs(ctx context.Context, userID string) ([]string, error)
+}
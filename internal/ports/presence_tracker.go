@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+)
+
+//go:generate mockery --name=PresenceTracker --output=../mocks --outpkg=mocks
+
+// PresenceTracker turns activity reported by clients into presence updates for their contacts
+type PresenceTracker interface {
+	// Heartbeat keeps the user online; contacts are told when the user comes online
+	Heartbeat(ctx context.Context, userID string) error
+
+	// Typing tells the other participants of chatID that the user is typing
+	// The caller is expected to have checked that the user takes part in the chat
+	Typing(ctx context.Context, userID, chatID string) error
+}
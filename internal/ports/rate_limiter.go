@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"messaging-app/internal/domain"
+)
+
+//go:generate mockery --name=RateLimiter --output=../mocks --outpkg=mocks
+
+// RateLimiter keeps a token bucket per key
+type RateLimiter interface {
+	// Allow takes a token from the bucket for key, created full on first use
+	// Buckets that have refilled completely may be forgotten
+	Allow(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"messaging-app/internal/domain"
+)
+
+//go:generate mockery --name=UserDataRepository --output=../mocks --outpkg=mocks
+
+// UserDataRepository gathers and removes everything stored about a user, for data requests
+type UserDataRepository interface {
+	// ExportUserData returns the user's data; a user without data gets empty lists
+	ExportUserData(ctx context.Context, userID string) (domain.UserDataExport, error)
+
+	// PurgeUserData removes the messages the user sent, their reactions, hidden messages,
+	// memberships, mutes, presence, pending events, uploaded attachments and blocks in either direction
+	// Direct messages other users sent to the user remain in their senders' history
+	PurgeUserData(ctx context.Context, userID string) (domain.UserDataPurge, error)
+}
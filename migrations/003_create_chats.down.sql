@@ -0,0 +1,4 @@
+-- Drop group chat tables
+DROP INDEX IF EXISTS idx_chat_members_user;
+DROP TABLE IF EXISTS chat_members CASCADE;
+DROP TABLE IF EXISTS chats CASCADE;
\ No newline at end of file
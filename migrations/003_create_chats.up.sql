@@ -0,0 +1,37 @@
+-- Create group chats table
+CREATE TABLE IF NOT EXISTS chats (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT chats_id_is_group CHECK (id LIKE 'group:%'),
+    CONSTRAINT chats_name_not_empty CHECK (LENGTH(TRIM(name)) > 0)
+);
+
+-- Create chat members table
+CREATE TABLE IF NOT EXISTS chat_members (
+    chat_id TEXT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    role TEXT DEFAULT 'member' NOT NULL,
+    joined_at TIMESTAMP NOT NULL,
+    last_read_at TIMESTAMP,
+
+    PRIMARY KEY (chat_id, user_id),
+
+    -- Constraints
+    CONSTRAINT chat_members_role_check CHECK (role IN ('owner', 'member')),
+    CONSTRAINT chat_members_user_not_empty CHECK (LENGTH(TRIM(user_id)) > 0)
+);
+
+-- Index for listing the chats a user belongs to
+-- Supports: WHERE user_id = ?
+CREATE INDEX IF NOT EXISTS idx_chat_members_user
+ON chat_members(user_id);
+
+-- Add table comments
+COMMENT ON TABLE chats IS 'Group conversations; messages sent to a group use the chat ID as receiver_id';
+COMMENT ON COLUMN chats.id IS 'Group chat ID, always prefixed with group:';
+COMMENT ON TABLE chat_members IS 'Members of group chats';
+COMMENT ON COLUMN chat_members.last_read_at IS 'Timestamp of the latest message the member has read';
@@ -0,0 +1,28 @@
+-- Create outbox table for real-time events
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    recipient TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT,
+    failed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT outbox_event_type_not_empty CHECK (LENGTH(TRIM(event_type)) > 0),
+    CONSTRAINT outbox_recipient_not_empty CHECK (LENGTH(TRIM(recipient)) > 0)
+);
+
+-- Index for the relay polling due events
+-- Supports: WHERE failed_at IS NULL AND next_attempt_at <= ? ORDER BY next_attempt_at
+CREATE INDEX IF NOT EXISTS idx_outbox_due
+ON outbox(next_attempt_at)
+WHERE failed_at IS NULL;
+
+-- Add table comments
+COMMENT ON TABLE outbox IS 'Real-time events written with the change that produced them and relayed to NATS';
+COMMENT ON COLUMN outbox.recipient IS 'User ID, or group chat ID for messages fanned out to every member';
+COMMENT ON COLUMN outbox.next_attempt_at IS 'Earliest time the relay may (re)claim the event';
+COMMENT ON COLUMN outbox.failed_at IS 'Set when the event exhausted its delivery attempts';
@@ -0,0 +1,3 @@
+-- Drop group delivery markers
+ALTER TABLE chat_members
+DROP COLUMN IF EXISTS last_delivered_at;
@@ -0,0 +1,5 @@
+-- Track per-member delivery in group chats
+ALTER TABLE chat_members
+ADD COLUMN IF NOT EXISTS last_delivered_at TIMESTAMP;
+
+COMMENT ON COLUMN chat_members.last_delivered_at IS 'Timestamp of the latest message delivered to one of the member''s devices';
@@ -0,0 +1,10 @@
+-- Restore the composite primary key
+-- Fails if messages sharing (sender_id, receiver_id, created_at) were stored meanwhile
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_pkey;
+ALTER TABLE messages ADD CONSTRAINT messages_pkey PRIMARY KEY (sender_id, receiver_id, created_at);
+
+-- Drop message IDs
+ALTER TABLE messages
+DROP COLUMN IF EXISTS id;
+
+DROP FUNCTION IF EXISTS uuid_v7(TIMESTAMP);
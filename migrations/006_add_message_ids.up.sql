@@ -0,0 +1,33 @@
+-- Generate time-ordered UUIDv7 values: a 48-bit Unix millisecond timestamp
+-- followed by random bits, with the version nibble set to 7
+CREATE OR REPLACE FUNCTION uuid_v7(ts TIMESTAMP) RETURNS UUID AS $$
+    SELECT encode(
+        set_bit(
+            set_bit(
+                overlay(uuid_send(gen_random_uuid())
+                    PLACING substring(int8send(floor(extract(epoch FROM ts) * 1000)::BIGINT) FROM 3)
+                    FROM 1 FOR 6),
+                52, 1),
+            53, 1),
+        'hex')::UUID
+$$ LANGUAGE SQL VOLATILE;
+
+-- Add server-assigned message IDs
+ALTER TABLE messages
+ADD COLUMN IF NOT EXISTS id UUID;
+
+-- Backfill existing messages, keeping IDs ordered by creation time
+UPDATE messages
+SET id = uuid_v7(created_at)
+WHERE id IS NULL;
+
+ALTER TABLE messages
+ALTER COLUMN id SET NOT NULL;
+
+-- The ID becomes the primary key so messages created in the same microsecond no longer collide;
+-- idx_messages_status_update keeps lookups by (sender_id, receiver_id, created_at) fast
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_pkey;
+ALTER TABLE messages ADD CONSTRAINT messages_pkey PRIMARY KEY (id);
+
+COMMENT ON COLUMN messages.id IS 'Server-assigned UUIDv7 message ID (primary key)';
+COMMENT ON COLUMN messages.created_at IS 'Message creation timestamp';
@@ -0,0 +1,5 @@
+-- Drop client-supplied idempotency keys
+DROP INDEX IF EXISTS idx_messages_client_message_id;
+
+ALTER TABLE messages
+DROP COLUMN IF EXISTS client_message_id;
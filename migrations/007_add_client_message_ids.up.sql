@@ -0,0 +1,11 @@
+-- Add client-supplied idempotency keys
+ALTER TABLE messages
+ADD COLUMN IF NOT EXISTS client_message_id TEXT;
+
+-- A retried send with the same key must not create a second message
+-- Supports: WHERE sender_id = ? AND client_message_id = ?
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_client_message_id
+ON messages(sender_id, client_message_id)
+WHERE client_message_id IS NOT NULL;
+
+COMMENT ON COLUMN messages.client_message_id IS 'Idempotency key chosen by the sender, unique per sender';
@@ -0,0 +1,14 @@
+-- Drop per-user deletions
+DROP TABLE IF EXISTS hidden_messages;
+
+-- Tombstones have no content and cannot satisfy the original constraint
+-- (matched on content so this also runs before the up migration on a fresh database)
+DELETE FROM messages WHERE LENGTH(TRIM(content)) = 0;
+
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_content_not_empty;
+ALTER TABLE messages ADD CONSTRAINT messages_content_not_empty CHECK (LENGTH(TRIM(content)) > 0);
+
+-- Drop edit and deletion markers
+ALTER TABLE messages
+DROP COLUMN IF EXISTS edited_at,
+DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,26 @@
+-- Track edits and deletions for everyone on the message row
+ALTER TABLE messages
+ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP,
+ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Deleted messages stay as tombstones with their content erased
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_content_not_empty;
+ALTER TABLE messages ADD CONSTRAINT messages_content_not_empty
+CHECK (deleted_at IS NOT NULL OR LENGTH(TRIM(content)) > 0);
+
+-- Messages a user deleted only for themselves
+CREATE TABLE IF NOT EXISTS hidden_messages (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    hidden_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (message_id, user_id),
+
+    -- Constraints
+    CONSTRAINT hidden_messages_user_not_empty CHECK (LENGTH(TRIM(user_id)) > 0)
+);
+
+-- Add table comments
+COMMENT ON COLUMN messages.edited_at IS 'Timestamp of the latest content edit by the sender';
+COMMENT ON COLUMN messages.deleted_at IS 'Set when the sender deleted the message for everyone; the row remains as a tombstone';
+COMMENT ON TABLE hidden_messages IS 'Messages deleted for one user only, excluded from that user''s history';
@@ -0,0 +1,17 @@
+-- One emoji reaction per user and message
+CREATE TABLE IF NOT EXISTS message_reactions (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (message_id, user_id),
+
+    -- Constraints
+    CONSTRAINT message_reactions_user_not_empty CHECK (LENGTH(TRIM(user_id)) > 0),
+    CONSTRAINT message_reactions_emoji_not_empty CHECK (LENGTH(emoji) > 0)
+);
+
+-- Add table comments
+COMMENT ON TABLE message_reactions IS 'Emoji reactions to messages, replaced when a user reacts again';
+COMMENT ON COLUMN message_reactions.created_at IS 'When the user last set their reaction, used to order reaction summaries';
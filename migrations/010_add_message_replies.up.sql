@@ -0,0 +1,11 @@
+-- Replies reference the message they quote
+ALTER TABLE messages
+ADD COLUMN IF NOT EXISTS reply_to_id UUID REFERENCES messages(id) ON DELETE SET NULL;
+
+-- Find the replies to a message
+CREATE INDEX IF NOT EXISTS idx_messages_reply_to
+ON messages(reply_to_id)
+WHERE reply_to_id IS NOT NULL;
+
+-- Add table comments
+COMMENT ON COLUMN messages.reply_to_id IS 'Message this one replies to, always part of the same chat';
@@ -0,0 +1,18 @@
+-- Last known activity of every user, shared by all instances
+CREATE TABLE IF NOT EXISTS user_presence (
+    user_id TEXT PRIMARY KEY,
+    last_seen TIMESTAMP NOT NULL,
+    online BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Constraints
+    CONSTRAINT user_presence_user_not_empty CHECK (LENGTH(TRIM(user_id)) > 0)
+);
+
+-- Find online users whose heartbeat expired
+CREATE INDEX IF NOT EXISTS idx_user_presence_online
+ON user_presence(last_seen)
+WHERE online;
+
+-- Add table comments
+COMMENT ON TABLE user_presence IS 'Presence of users, refreshed by client heartbeats';
+COMMENT ON COLUMN user_presence.online IS 'Cleared once last_seen is older than the presence TTL';
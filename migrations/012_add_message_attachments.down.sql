@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_messages_attachment;
+
+-- Attachments without a caption cannot satisfy the previous constraint
+-- (matched on content so this also runs before the up migration on a fresh database)
+DELETE FROM messages WHERE deleted_at IS NULL AND LENGTH(TRIM(content)) = 0;
+
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_content_not_empty;
+ALTER TABLE messages ADD CONSTRAINT messages_content_not_empty
+CHECK (deleted_at IS NOT NULL OR LENGTH(TRIM(content)) > 0);
+
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_kind_check;
+
+ALTER TABLE messages
+DROP COLUMN IF EXISTS attachment_id,
+DROP COLUMN IF EXISTS kind;
+
+DROP TABLE IF EXISTS attachments;
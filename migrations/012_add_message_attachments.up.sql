@@ -0,0 +1,39 @@
+-- Metadata of uploaded files; the content lives in the blob store under the attachment ID
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY,
+    uploader_id TEXT NOT NULL,
+    chat_id TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    mime_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    checksum TEXT NOT NULL,
+    width INTEGER,
+    height INTEGER,
+    created_at TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT attachments_size_positive CHECK (size > 0),
+    CONSTRAINT attachments_file_name_length CHECK (LENGTH(file_name) BETWEEN 1 AND 255)
+);
+
+-- Messages render as text unless they carry an attachment
+ALTER TABLE messages
+ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'text',
+ADD COLUMN IF NOT EXISTS attachment_id UUID REFERENCES attachments(id) ON DELETE SET NULL;
+
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_kind_check;
+ALTER TABLE messages ADD CONSTRAINT messages_kind_check CHECK (kind IN ('text', 'image', 'file'));
+
+-- Attachments may be sent without a caption
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_content_not_empty;
+ALTER TABLE messages ADD CONSTRAINT messages_content_not_empty
+CHECK (deleted_at IS NOT NULL OR kind != 'text' OR LENGTH(TRIM(content)) > 0);
+
+-- An attachment is sent with a single message
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_attachment
+ON messages(attachment_id)
+WHERE attachment_id IS NOT NULL;
+
+-- Add table comments
+COMMENT ON TABLE attachments IS 'Files uploaded to a chat, sent once by their uploader';
+COMMENT ON COLUMN messages.kind IS 'text, image or file; content is an optional caption for the latter';
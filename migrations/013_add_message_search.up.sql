@@ -0,0 +1,13 @@
+-- Searchable form of the message content, kept in sync by Postgres on edits and deletions
+-- The simple configuration neither stems nor drops stop words, so it works the same for every language
+ALTER TABLE messages
+ADD COLUMN IF NOT EXISTS content_tsv TSVECTOR
+GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED;
+
+-- Full-text search over message content
+-- Supports: WHERE content_tsv @@ query
+CREATE INDEX IF NOT EXISTS idx_messages_content_tsv
+ON messages USING GIN (content_tsv);
+
+-- Add table comments
+COMMENT ON COLUMN messages.content_tsv IS 'Lexemes of the content for full-text search, empty for tombstones';
@@ -0,0 +1,30 @@
+-- Users a user no longer exchanges direct messages with
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id TEXT NOT NULL,
+    blocked_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (blocker_id, blocked_id),
+
+    -- Constraints
+    CONSTRAINT user_blocks_not_self CHECK (blocker_id != blocked_id),
+    CONSTRAINT user_blocks_blocked_not_empty CHECK (LENGTH(TRIM(blocked_id)) > 0)
+);
+
+-- Chats a user receives no real-time notifications from, until muted_until if set
+CREATE TABLE IF NOT EXISTS chat_mutes (
+    chat_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    muted_until TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (chat_id, user_id),
+
+    -- Constraints
+    CONSTRAINT chat_mutes_user_not_empty CHECK (LENGTH(TRIM(user_id)) > 0)
+);
+
+-- Add table comments
+COMMENT ON TABLE user_blocks IS 'Direct messages between blocker and blocked are refused in both directions';
+COMMENT ON TABLE chat_mutes IS 'Messages to a muted chat are stored but neither published to nor counted as unread for the user';
+COMMENT ON COLUMN chat_mutes.muted_until IS 'End of the mute; NULL mutes the chat until it is unmuted';
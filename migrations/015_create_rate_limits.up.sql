@@ -0,0 +1,15 @@
+-- Token buckets shared by every instance when rate limits use the postgres store
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    full_at TIMESTAMP NOT NULL
+);
+
+-- Drop buckets that have refilled completely
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_full_at
+ON rate_limit_buckets(full_at);
+
+-- Add table comments
+COMMENT ON TABLE rate_limit_buckets IS 'Rate limit token buckets keyed by route and client; a missing bucket is full';
+COMMENT ON COLUMN rate_limit_buckets.full_at IS 'When the bucket will have refilled completely and can be forgotten';
@@ -0,0 +1,6 @@
+-- Events carry the trace of the request that produced them so the relay can continue it
+ALTER TABLE outbox
+ADD COLUMN IF NOT EXISTS trace_context JSONB;
+
+-- Add table comments
+COMMENT ON COLUMN outbox.trace_context IS 'W3C trace context headers of the producing request, NULL when it was not traced';
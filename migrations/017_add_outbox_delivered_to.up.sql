@@ -0,0 +1,6 @@
+-- Group events are published to each member separately; retries skip the members already reached
+ALTER TABLE outbox
+ADD COLUMN IF NOT EXISTS delivered_to TEXT[] DEFAULT '{}' NOT NULL;
+
+-- Add table comments
+COMMENT ON COLUMN outbox.delivered_to IS 'Group members an earlier attempt already published the event to';
@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations so the binary can apply them
+// Files are named NNN_description.up.sql and NNN_description.down.sql
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -70,6 +70,13 @@ var (
 		Email:   "emoji@example.com",
 		Handler: "emoji_user_🎉",
 	}
+
+	// Dots separate NATS subject tokens, so they cannot appear in a user ID
+	DotUser = domain.UserContext{
+		UserID:  "user.with.dots",
+		Email:   "dots@example.com",
+		Handler: "dot.user",
+	}
 )
 
 // Edge case users (valid but unusual)
@@ -80,12 +87,6 @@ var (
 		Handler: "dash-user",
 	}
 
-	DotUser = domain.UserContext{
-		UserID:  "user.with.dots",
-		Email:   "dots@example.com",
-		Handler: "dot.user",
-	}
-
 	NumberUser = domain.UserContext{
 		UserID:  "user_with_numbers123",
 		Email:   "numbers123@example.com",
@@ -125,6 +126,7 @@ func InvalidUsers() []domain.UserContext {
 		WhitespaceUserID,
 		UnicodeUser,
 		EmojiUser,
+		DotUser,
 	}
 }
 
@@ -132,7 +134,6 @@ func InvalidUsers() []domain.UserContext {
 func EdgeCaseUsers() []domain.UserContext {
 	return []domain.UserContext{
 		DashUser,
-		DotUser,
 		NumberUser,
 		SingleCharUser,
 		UppercaseUser,